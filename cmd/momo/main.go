@@ -2,13 +2,14 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
 	"path/filepath"
-	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/cajundata/momorot/internal/db"
 	"github.com/cajundata/momorot/internal/export"
 	"github.com/cajundata/momorot/internal/fetch"
+	"github.com/cajundata/momorot/internal/pipeline"
 	"github.com/cajundata/momorot/internal/ui"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -33,11 +35,16 @@ func main() {
 	runCmd := flag.NewFlagSet("run", flag.ExitOnError)
 	refreshCmd := flag.NewFlagSet("refresh", flag.ExitOnError)
 	exportCmd := flag.NewFlagSet("export", flag.ExitOnError)
+	importCmd := flag.NewFlagSet("import", flag.ExitOnError)
+	validateCmd := flag.NewFlagSet("validate", flag.ExitOnError)
 	pingCmd := flag.NewFlagSet("ping", flag.ExitOnError)
+	backfillCmd := flag.NewFlagSet("backfill", flag.ExitOnError)
+	backtestCmd := flag.NewFlagSet("backtest", flag.ExitOnError)
+	sweepCmd := flag.NewFlagSet("sweep", flag.ExitOnError)
 
 	// Common flags
 	configPath := ""
-	for _, fs := range []*flag.FlagSet{runCmd, refreshCmd, exportCmd, pingCmd} {
+	for _, fs := range []*flag.FlagSet{runCmd, refreshCmd, exportCmd, importCmd, validateCmd, pingCmd, backfillCmd, backtestCmd, sweepCmd} {
 		fs.StringVar(&configPath, "config", "configs/config.yaml", "Path to configuration file")
 	}
 
@@ -47,6 +54,38 @@ func main() {
 	exportTopN := exportCmd.Int("top", 5, "Top N for leaders export")
 	exportDate := exportCmd.String("date", "", "Date for export (YYYY-MM-DD), defaults to today")
 
+	// Refresh command flags
+	refreshResume := refreshCmd.Bool("resume", false, "Continue the latest incomplete refresh run")
+
+	// Import command flags
+	importSymbol := importCmd.String("symbol", "", "Symbol for a single-file import (default: inferred from file name)")
+	importFormat := importCmd.String("format", "", "CSV format preset: stooq, yahoo, generic (default: providers.csv.format)")
+	importDelimiter := importCmd.String("delimiter", "", "Field delimiter, or \"tab\" (default: providers.csv.delimiter)")
+	importDecimalComma := importCmd.Bool("decimal-comma", false, "Numbers use a decimal comma (1.234,56)")
+
+	// Validate command flags
+	validateSymbol := validateCmd.String("symbol", "", "Validate only this symbol (default: all symbols)")
+
+	// Backfill command flags
+	backfillFrom := backfillCmd.String("from", "", "First date to rank (YYYY-MM-DD)")
+	backfillTo := backfillCmd.String("to", "", "Last date to rank (YYYY-MM-DD), defaults to today")
+
+	// Backtest command flags
+	var backtestOpts backtestOptions
+	backtestCmd.StringVar(&backtestOpts.from, "from", "", "First date of the backtest (YYYY-MM-DD)")
+	backtestCmd.StringVar(&backtestOpts.to, "to", "", "Last date of the backtest (YYYY-MM-DD), defaults to today")
+	backtestCmd.IntVar(&backtestOpts.topN, "top", 0, "Symbols held (default: app.top_n)")
+	backtestCmd.StringVar(&backtestOpts.rebalance, "rebalance", "", "Rebalance frequency: daily, monthly (default: backtest.rebalance)")
+	backtestCmd.Float64Var(&backtestOpts.costBps, "cost-bps", -1, "Transaction cost in basis points (default: backtest.cost_bps)")
+	backtestCmd.StringVar(&backtestOpts.cashSymbol, "cash", "", "Symbol held in place of cash, e.g. BIL (default: backtest.cash_symbol)")
+	backtestCmd.BoolVar(&backtestOpts.absoluteMomentum, "abs-momentum", false, "Rank with dual momentum, moving picks that do not beat cash to cash")
+	backtestCmd.BoolVar(&backtestOpts.export, "export", false, "Write the equity curve to a CSV file in data.export_dir")
+
+	// Sweep command flags
+	sweepFrom := sweepCmd.String("from", "", "First date of the backtests (YYYY-MM-DD), defaults to the earliest stored price")
+	sweepTo := sweepCmd.String("to", "", "Last date of the backtests (YYYY-MM-DD), defaults to today")
+	sweepWorkers := sweepCmd.Int("workers", 0, "Backtests run at once (default: sweep.workers)")
+
 	// Show usage if no subcommand provided
 	if len(os.Args) < 2 {
 		printUsage()
@@ -61,16 +100,40 @@ func main() {
 
 	case "refresh":
 		refreshCmd.Parse(os.Args[2:])
-		runRefresh(configPath)
+		runRefresh(configPath, *refreshResume)
 
 	case "export":
 		exportCmd.Parse(os.Args[2:])
 		runExport(configPath, *exportType, *exportSymbol, *exportTopN, *exportDate)
 
+	case "import":
+		importCmd.Parse(os.Args[2:])
+		if importCmd.NArg() != 1 {
+			fmt.Println("Usage: momo import [options] <file|directory|glob>")
+			os.Exit(1)
+		}
+		runImport(configPath, importCmd.Arg(0), *importSymbol, *importFormat, *importDelimiter, *importDecimalComma)
+
+	case "validate":
+		validateCmd.Parse(os.Args[2:])
+		runValidate(configPath, *validateSymbol)
+
 	case "ping":
 		pingCmd.Parse(os.Args[2:])
 		runPing(configPath)
 
+	case "backfill":
+		backfillCmd.Parse(os.Args[2:])
+		runBackfill(configPath, *backfillFrom, *backfillTo)
+
+	case "backtest":
+		backtestCmd.Parse(os.Args[2:])
+		runBacktest(configPath, backtestOpts)
+
+	case "sweep":
+		sweepCmd.Parse(os.Args[2:])
+		runSweep(configPath, *sweepFrom, *sweepTo, *sweepWorkers)
+
 	case "version", "--version", "-v":
 		printVersion()
 
@@ -95,6 +158,11 @@ COMMANDS:
     run         Launch the TUI application
     refresh     Refresh data and compute rankings
     export      Export data to CSV files
+    import      Import historical prices from CSV files
+    validate    Check stored prices for data quality problems
+    backfill    Recompute rankings for past dates from stored prices
+    backtest    Replay the rotation strategy over stored prices
+    sweep       Compare backtests across a grid of scoring parameters
     ping        Health check (verify config and DB)
     version     Show version information
     help        Show this help message
@@ -106,6 +174,9 @@ RUN OPTIONS:
 REFRESH OPTIONS:
     -config string
         Path to configuration file (default: configs/config.yaml)
+    -resume
+        Continue the latest incomplete refresh run, fetching only the
+        symbols it has not stored yet
 
 EXPORT OPTIONS:
     -config string
@@ -119,6 +190,63 @@ EXPORT OPTIONS:
     -date string
         Date for export (YYYY-MM-DD), defaults to today
 
+IMPORT OPTIONS:
+    -config string
+        Path to configuration file (default: configs/config.yaml)
+    -symbol string
+        Symbol for a single-file import (default: inferred from file name)
+    -format string
+        CSV format preset: stooq, yahoo, generic (default: providers.csv.format)
+    -delimiter string
+        Field delimiter, or "tab" (default: providers.csv.delimiter)
+    -decimal-comma
+        Numbers use a decimal comma (1.234,56)
+
+VALIDATE OPTIONS:
+    -config string
+        Path to configuration file (default: configs/config.yaml)
+    -symbol string
+        Validate only this symbol (default: all symbols)
+
+BACKFILL OPTIONS:
+    -config string
+        Path to configuration file (default: configs/config.yaml)
+    -from string
+        First date to rank (YYYY-MM-DD, required)
+    -to string
+        Last date to rank (YYYY-MM-DD), defaults to today
+
+BACKTEST OPTIONS:
+    -config string
+        Path to configuration file (default: configs/config.yaml)
+    -from string
+        First date of the backtest (YYYY-MM-DD, required)
+    -to string
+        Last date of the backtest (YYYY-MM-DD), defaults to today
+    -top int
+        Symbols held (default: app.top_n)
+    -rebalance string
+        Rebalance frequency: daily, monthly (default: backtest.rebalance)
+    -cost-bps float
+        Transaction cost in basis points (default: backtest.cost_bps)
+    -cash string
+        Symbol held in place of cash, e.g. BIL (default: backtest.cash_symbol)
+    -abs-momentum
+        Rank with dual momentum: picks that do not beat cash over 12 months go to cash
+    -export
+        Write the equity curve to a CSV file in data.export_dir
+
+SWEEP OPTIONS:
+    -config string
+        Path to configuration file (default: configs/config.yaml)
+    -from string
+        First date of the backtests (YYYY-MM-DD), defaults to the earliest
+        stored price
+    -to string
+        Last date of the backtests (YYYY-MM-DD), defaults to today
+    -workers int
+        Backtests run at once (default: sweep.workers)
+
 PING OPTIONS:
     -config string
         Path to configuration file (default: configs/config.yaml)
@@ -145,6 +273,30 @@ EXAMPLES:
     # Export runs history
     momo export -type runs
 
+    # Import Stooq history for one file or a whole directory
+    momo import data/stooq/spy.us.txt
+    momo import data/stooq/
+
+    # Import a file whose name is not the ticker
+    momo import -symbol SPY downloads/export.csv
+
+    # Import a Yahoo Finance download, or a semicolon-separated European export
+    momo import -format yahoo downloads/QQQ.csv
+    momo import -format generic -delimiter ";" -decimal-comma downloads/IWM.csv
+
+    # Check stored prices for gaps, bad bars and unadjusted splits
+    momo validate
+    momo validate -symbol SPY
+
+    # Rebuild the ranking history for 2024, one NYSE business day at a time
+    momo backfill -from 2024-01-01 -to 2024-12-31
+
+    # Backtest the top 3 with monthly rebalancing, holding BIL instead of cash
+    momo backtest -from 2020-01-01 -top 3 -cash BIL -abs-momentum -export
+
+    # Backtest every combination of the sweep section since 2020
+    momo sweep -from 2020-01-01
+
     # Health check
     momo ping
 
@@ -164,7 +316,7 @@ func printVersion() {
 // runTUI launches the Terminal UI application
 func runTUI(configPath string) {
 	// Load configuration
-	cfg, err := config.Load(configPath)
+	cfg, err := pipeline.LoadConfig(configPath)
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
@@ -208,10 +360,27 @@ func runTUI(configPath string) {
 	fmt.Println("Goodbye!")
 }
 
+// printFetchEvent prints live progress of a refresh as symbols are fetched
+func printFetchEvent(event fetch.FetchEvent) {
+	switch event.Type {
+	case fetch.FetchStarted:
+		fmt.Printf("Fetching %s (%s)...\n", event.Symbol, event.Provider)
+	case fetch.FetchRetrying:
+		fmt.Printf("  Retrying %s (retry %d)...\n", event.Symbol, event.Attempt)
+	case fetch.FetchFinished:
+		if event.Result.Success {
+			fmt.Printf("  [%d/%d] %s ✓ %d bars in %s\n", event.Completed, event.Total, event.Symbol,
+				event.Result.RecordsFetched, event.Result.Duration.Round(time.Millisecond))
+		} else {
+			fmt.Printf("  [%d/%d] %s ✗ %v\n", event.Completed, event.Total, event.Symbol, event.Result.Error)
+		}
+	}
+}
+
 // runRefresh performs a data refresh operation
-func runRefresh(configPath string) {
+func runRefresh(configPath string, resume bool) {
 	// Load configuration
-	cfg, err := config.Load(configPath)
+	cfg, err := pipeline.LoadConfig(configPath)
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
@@ -224,177 +393,83 @@ func runRefresh(configPath string) {
 	defer database.Close()
 
 	fmt.Println("Starting data refresh...")
-	startTime := time.Now()
 
-	// Create run record
-	runRepo := db.NewRunRepository(database)
-	runID, err := runRepo.Create("CLI refresh")
-	if err != nil {
-		log.Fatalf("Failed to create run: %v", err)
+	// Fetch, store and score with a timeout context; Ctrl+C aborts in-flight requests
+	ctx, cancel := context.WithTimeout(context.Background(), pipeline.RefreshTimeout(cfg, database))
+	defer cancel()
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	refresher := pipeline.NewRefresher(cfg, database, pipeline.NewProviders(cfg, database))
+	announced := false
+	refresher.SetProgress(func(event fetch.FetchEvent) {
+		if !announced {
+			announced = true
+			fmt.Printf("Fetching %d symbols (estimated %v)\n", event.Total,
+				fetch.EstimateFetchTime(event.Total, cfg.Fetcher.MaxWorkers))
+		}
+		printFetchEvent(event)
+	})
+	var result *pipeline.RefreshResult
+	if resume {
+		result, err = refresher.Resume(ctx)
+		if errors.Is(err, pipeline.ErrNothingToResume) {
+			fmt.Println("Nothing to resume: the latest refresh finished OK")
+			return
+		}
+	} else {
+		result, err = refresher.Run(ctx, "CLI refresh")
 	}
-
-	// Initialize Alpha Vantage client
-	avClient := fetch.NewAlphaVantageClient(
-		cfg.AlphaVantage.APIKey,
-		cfg.AlphaVantage.BaseURL,
-		cfg.AlphaVantage.DailyRequestLimit,
-		time.Duration(cfg.Fetcher.Timeout)*time.Second,
-		cfg.Fetcher.MaxRetries,
-	)
-
-	// Create scheduler for concurrent fetching
-	scheduler := fetch.NewScheduler(avClient, cfg.Fetcher.MaxWorkers)
-
-	// Get active symbols
-	symbolRepo := db.NewSymbolRepository(database)
-	activeSymbols, err := symbolRepo.ListActive()
 	if err != nil {
-		log.Fatalf("Failed to get active symbols: %v", err)
+		log.Fatalf("Refresh failed: %v", err)
 	}
-
-	// Extract symbol names
-	symbolNames := make([]string, len(activeSymbols))
-	for i, sym := range activeSymbols {
-		symbolNames[i] = sym.Symbol
+	if result.Resumed > 0 {
+		fmt.Printf("Resumed run %d (%d symbols already stored)\n", result.RunID, result.Resumed)
 	}
 
-	// Fetch data with timeout context
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-	defer cancel()
-
-	results, err := scheduler.FetchSymbols(ctx, symbolNames, "compact")
-	if err != nil {
-		log.Fatalf("Failed to fetch symbols: %v", err)
-	}
-
-	// Process results
-	successCount := 0
-	failureCount := 0
-	priceRepo := db.NewPriceRepository(database)
-	fetchLogRepo := db.NewFetchLogRepository(database)
-
-	for _, result := range results {
-		if result.Success {
-			fmt.Printf("Fetching %s... ✓\n", result.Symbol)
-			successCount++
-
-			// Fetch and store prices
-			dailyData, err := avClient.FetchDailyAdjusted(result.Symbol, "compact")
-			if err != nil {
-				fmt.Printf("  ⚠ Failed to fetch data: %v\n", err)
-				failureCount++
-				successCount--
-
-				// Log failure
-				errMsg := err.Error()
-				fetchLogRepo.Log(&db.FetchLog{
-					RunID:   runID,
-					Symbol:  result.Symbol,
-					Rows:    0,
-					OK:      false,
-					Message: &errMsg,
-				})
-				continue
-			}
+	for _, symbol := range result.Skipped {
+		fmt.Printf("Skipping %s... already up to date\n", symbol)
+	}
 
-			// Store prices in database
-			stored := 0
-			for dateStr, bar := range dailyData.TimeSeries {
-				// Parse string values to numeric types
-				open, _ := strconv.ParseFloat(bar.Open, 64)
-				high, _ := strconv.ParseFloat(bar.High, 64)
-				low, _ := strconv.ParseFloat(bar.Low, 64)
-				close, _ := strconv.ParseFloat(bar.Close, 64)
-				adjClose, _ := strconv.ParseFloat(bar.AdjustedClose, 64)
-				volume, _ := strconv.ParseInt(bar.Volume, 10, 64)
-
-				price := &db.Price{
-					Symbol:   result.Symbol,
-					Date:     dateStr,
-					Open:     open,
-					High:     high,
-					Low:      low,
-					Close:    close,
-					AdjClose: &adjClose,
-					Volume:   &volume,
-				}
-
-				if err := priceRepo.Create(price); err != nil {
-					// Ignore duplicate errors (already exists)
-					if err.Error() != "UNIQUE constraint failed: prices.symbol, prices.date" {
-						fmt.Printf("  ⚠ Failed to store price: %v\n", err)
-					}
-				} else {
-					stored++
-				}
-			}
+	if len(result.Symbols) > 0 {
+		fmt.Println()
+	}
+	for _, sr := range result.Symbols {
+		if sr.Err != nil {
+			fmt.Printf("  ✗ %s: %v\n", sr.Symbol, sr.Err)
+			continue
+		}
+		fmt.Printf("  ✓ %s: stored %d prices\n", sr.Symbol, sr.Rows)
+		if sr.Actions > 0 {
+			fmt.Printf("    Recorded %d corporate actions\n", sr.Actions)
+		}
+		if sr.Backfill != "" {
+			fmt.Printf("    Backfilled gaps: %s\n", sr.Backfill)
+		}
+		if sr.Findings > 0 {
+			fmt.Printf("    ⚠ %d data quality findings (see Logs screen)\n", sr.Findings)
+		}
+	}
 
-			// Log success
-			fetchLogRepo.Log(&db.FetchLog{
-				RunID:  runID,
-				Symbol: result.Symbol,
-				Rows:   stored,
-				OK:     true,
-			})
+	if len(result.Deferred) > 0 {
+		fmt.Printf("\n  ⚠ Daily quota reached; deferred to the next refresh: %s\n", strings.Join(result.Deferred, ", "))
+	}
 
-			fmt.Printf("  Stored %d prices\n", stored)
-		} else {
-			fmt.Printf("Fetching %s... ✗ %v\n", result.Symbol, result.Error)
-			failureCount++
-
-			// Log failure
-			errMsg := result.Error.Error()
-			fetchLogRepo.Log(&db.FetchLog{
-				RunID:   runID,
-				Symbol:  result.Symbol,
-				Rows:    0,
-				OK:      false,
-				Message: &errMsg,
-			})
-		}
-	}
-
-	// Compute analytics
-	fmt.Println("\nComputing analytics...")
-	orchestrator := analytics.NewOrchestrator(
-		database,
-		map[string]int{
-			"r1m":  cfg.Lookbacks.R1M,
-			"r3m":  cfg.Lookbacks.R3M,
-			"r6m":  cfg.Lookbacks.R6M,
-			"r12m": cfg.Lookbacks.R12M,
-		},
-		map[string]int{
-			"short": cfg.VolWindows.Short,
-			"long":  cfg.VolWindows.Long,
-		},
-		analytics.ScoringConfig{
-			PenaltyLambda:      cfg.Scoring.PenaltyLambda,
-			MinADV:             cfg.Scoring.MinADVUSD,
-			BreadthMinPositive: cfg.Scoring.BreadthMinPositive,
-			BreadthTotal:       cfg.Scoring.BreadthTotalLookbacks,
-		},
-	)
-
-	if _, err := orchestrator.ComputeAllIndicators(time.Now()); err != nil {
-		log.Fatalf("Failed to compute analytics: %v", err)
-	}
-
-	fmt.Println("  ✓ Analytics computed")
-
-	// Update run status
-	status := "OK"
-	if failureCount > 0 {
-		status = "ERROR"
-	}
-	if err := runRepo.Finish(runID, status, successCount, failureCount); err != nil {
-		log.Printf("Warning: Failed to update run status: %v", err)
+	for _, mr := range result.Metadata {
+		if mr.Err != nil {
+			fmt.Printf("  ⚠ %s metadata: %v\n", mr.Symbol, mr.Err)
+		} else if mr.Updated {
+			fmt.Printf("  ✓ %s: updated metadata\n", mr.Symbol)
+		}
 	}
 
+	fmt.Println("\n  ✓ Analytics computed")
+
 	// Auto-export if configured
 	if cfg.App.AutoExport {
 		fmt.Println("\nExporting data...")
 		exporter := export.New(database, cfg.Data.ExportDir)
+		exporter.SetStrategy(cfg.Scoring.Strategy)
 
 		if filename, err := exporter.ExportLeaders(cfg.App.TopN, ""); err == nil {
 			fmt.Printf("  ✓ Leaders: %s\n", filename)
@@ -409,16 +484,343 @@ func runRefresh(configPath string) {
 		}
 	}
 
-	duration := time.Since(startTime)
-	fmt.Printf("\nRefresh complete in %v\n", duration)
-	fmt.Printf("  Success: %d symbols\n", successCount)
-	fmt.Printf("  Failed: %d symbols\n", failureCount)
+	fmt.Printf("\nRefresh complete in %v\n", result.Duration)
+	fmt.Printf("  Success: %d symbols\n", result.Succeeded)
+	fmt.Printf("  Failed: %d symbols\n", result.Failed)
+}
+
+// runImport loads historical prices from CSV files into the database
+func runImport(configPath, target, symbol, format, delimiter string, decimalComma bool) {
+	// Load configuration
+	cfg, err := pipeline.LoadConfig(configPath)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Command-line format options override the configured CSV format
+	if format != "" {
+		cfg.Providers.CSV.Format = format
+		cfg.Providers.CSV.Columns = nil
+	}
+	if delimiter != "" {
+		cfg.Providers.CSV.Delimiter = delimiter
+	}
+	if decimalComma {
+		cfg.Providers.CSV.DecimalComma = true
+	}
+
+	paths, err := pipeline.ResolveImportPaths(target)
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	// Initialize database
+	database, err := initDatabase(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer database.Close()
+
+	fmt.Printf("Importing %d file(s)...\n", len(paths))
+
+	importer, err := pipeline.NewImporter(cfg, database)
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+	result, err := importer.Run(paths, symbol)
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	for _, fr := range result.Files {
+		if fr.Err != nil {
+			fmt.Printf("Importing %s (%s)... ✗ %v\n", fr.Path, fr.Symbol, fr.Err)
+			continue
+		}
+		fmt.Printf("Importing %s (%s)... ✓\n", fr.Path, fr.Symbol)
+		fmt.Printf("  Stored %d prices (%s to %s)\n", fr.Rows, fr.FromDate, fr.ToDate)
+		if fr.Actions > 0 {
+			fmt.Printf("  Recorded %d corporate actions\n", fr.Actions)
+		}
+		if fr.Findings > 0 {
+			fmt.Printf("  ⚠ %d data quality findings (see Logs screen)\n", fr.Findings)
+		}
+	}
+
+	for _, sym := range result.Created {
+		fmt.Printf("  + Added %s to the universe\n", sym)
+	}
+
+	if result.AnalyticsErr != nil {
+		fmt.Printf("\n  ⚠ Analytics not computed: %v\n", result.AnalyticsErr)
+	} else if result.Succeeded > 0 {
+		fmt.Println("\n  ✓ Analytics computed")
+	}
+
+	fmt.Printf("\nImport complete in %v\n", result.Duration)
+	fmt.Printf("  Success: %d files\n", result.Succeeded)
+	fmt.Printf("  Failed: %d files\n", result.Failed)
+}
+
+// runValidate runs the data quality checks over stored prices
+func runValidate(configPath, symbol string) {
+	// Load configuration
+	cfg, err := pipeline.LoadConfig(configPath)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Initialize database
+	database, err := initDatabase(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer database.Close()
+
+	var symbols []string
+	if symbol != "" {
+		symbols = []string{strings.ToUpper(symbol)}
+	}
+
+	results, err := pipeline.ValidateSymbols(database, symbols)
+	if err != nil {
+		log.Fatalf("Validation failed: %v", err)
+	}
+
+	total := 0
+	for _, vr := range results {
+		if vr.Err != nil {
+			fmt.Printf("  ✗ %s: %v\n", vr.Symbol, vr.Err)
+			continue
+		}
+		if len(vr.Findings) == 0 {
+			fmt.Printf("  ✓ %s\n", vr.Symbol)
+			continue
+		}
+		fmt.Printf("  ⚠ %s: %d findings\n", vr.Symbol, len(vr.Findings))
+		for _, f := range vr.Findings {
+			fmt.Printf("      %s %-5s %-12s %s\n", f.Date.Format("2006-01-02"), f.Severity, f.Check, f.Detail)
+		}
+		total += len(vr.Findings)
+	}
+
+	fmt.Printf("\nValidated %d symbols, %d findings\n", len(results), total)
+}
+
+// runBackfill computes and stores point-in-time rankings for every NYSE
+// business day in a date range
+func runBackfill(configPath, from, to string) {
+	if from == "" {
+		fmt.Println("Usage: momo backfill -from YYYY-MM-DD [-to YYYY-MM-DD]")
+		os.Exit(1)
+	}
+	fromDate, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		log.Fatalf("Invalid -from date %q: %v", from, err)
+	}
+	toDate := time.Now()
+	if to != "" {
+		if toDate, err = time.Parse("2006-01-02", to); err != nil {
+			log.Fatalf("Invalid -to date %q: %v", to, err)
+		}
+	}
+
+	// Load configuration
+	cfg, err := pipeline.LoadConfig(configPath)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Initialize database
+	database, err := initDatabase(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer database.Close()
+
+	fmt.Printf("Backfilling rankings from %s to %s...\n", fromDate.Format("2006-01-02"), toDate.Format("2006-01-02"))
+
+	start := time.Now()
+	skipped := 0
+	stored, err := pipeline.NewOrchestrator(cfg, database).Backfill(fromDate, toDate, func(day analytics.BackfillDay) {
+		if day.Err != nil {
+			skipped++
+			fmt.Printf("  ✗ %s: %v\n", day.Date.Format("2006-01-02"), day.Err)
+			return
+		}
+		if day.Symbols == 0 {
+			fmt.Printf("  ✓ %s: no symbols beat the cash hurdle\n", day.Date.Format("2006-01-02"))
+			return
+		}
+		fmt.Printf("  ✓ %s: ranked %d symbols\n", day.Date.Format("2006-01-02"), day.Symbols)
+	})
+	if err != nil {
+		log.Fatalf("Backfill failed: %v", err)
+	}
+
+	fmt.Printf("\nBackfill complete in %v\n", time.Since(start).Round(time.Millisecond))
+	fmt.Printf("  Stored: %d days\n", stored)
+	fmt.Printf("  Skipped: %d days\n", skipped)
+}
+
+// backtestOptions holds the backtest command flags. Zero values fall back to
+// the config.
+type backtestOptions struct {
+	from             string
+	to               string
+	topN             int
+	rebalance        string
+	costBps          float64
+	cashSymbol       string
+	absoluteMomentum bool
+	export           bool
+}
+
+// runBacktest replays the rotation strategy over stored prices and prints
+// the summary statistics
+func runBacktest(configPath string, opts backtestOptions) {
+	if opts.from == "" {
+		fmt.Println("Usage: momo backtest -from YYYY-MM-DD [-to YYYY-MM-DD] [options]")
+		os.Exit(1)
+	}
+	fromDate, err := time.Parse("2006-01-02", opts.from)
+	if err != nil {
+		log.Fatalf("Invalid -from date %q: %v", opts.from, err)
+	}
+	toDate := time.Now()
+	if opts.to != "" {
+		if toDate, err = time.Parse("2006-01-02", opts.to); err != nil {
+			log.Fatalf("Invalid -to date %q: %v", opts.to, err)
+		}
+	}
+
+	// Load configuration
+	cfg, err := pipeline.LoadConfig(configPath)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Initialize database
+	database, err := initDatabase(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer database.Close()
+
+	// Flags override the config
+	btCfg := pipeline.NewBacktestConfig(cfg, fromDate, toDate)
+	if opts.topN > 0 {
+		btCfg.TopN = opts.topN
+	}
+	if opts.rebalance != "" {
+		btCfg.Rebalance = opts.rebalance
+	}
+	if opts.costBps >= 0 {
+		btCfg.CostBps = opts.costBps
+	}
+	if opts.cashSymbol != "" {
+		btCfg.CashSymbol = strings.ToUpper(opts.cashSymbol)
+	}
+	if opts.absoluteMomentum {
+		btCfg.AbsoluteMomentum = true
+	}
+
+	fmt.Printf("Backtesting top %d, %s rebalancing, from %s to %s...\n",
+		btCfg.TopN, btCfg.Rebalance, fromDate.Format("2006-01-02"), toDate.Format("2006-01-02"))
+
+	start := time.Now()
+	result, err := pipeline.RunBacktest(cfg, database, btCfg)
+	if err != nil {
+		log.Fatalf("Backtest failed: %v", err)
+	}
+
+	first := result.Curve[0].Date
+	last := result.Curve[len(result.Curve)-1].Date
+	fmt.Printf("\nBacktest complete in %v\n", time.Since(start).Round(time.Millisecond))
+	fmt.Printf("  Period: %s to %s (%d days)\n", first.Format("2006-01-02"), last.Format("2006-01-02"), len(result.Curve))
+	fmt.Printf("  Rebalances: %d\n", result.Rebalances)
+	fmt.Printf("  Total return: %.2f%%\n", result.TotalReturn*100)
+	fmt.Printf("  CAGR: %.2f%%\n", result.CAGR*100)
+	fmt.Printf("  Max drawdown: %.2f%%\n", result.MaxDrawdown*100)
+	fmt.Printf("  Turnover: %.2f%% per rebalance\n", result.Turnover*100)
+	fmt.Printf("  Hit rate: %.2f%%\n", result.HitRate*100)
+	fmt.Printf("  Costs: %.2f%%\n", result.Costs*100)
+
+	if opts.export {
+		filename, err := export.New(database, cfg.Data.ExportDir).ExportBacktest(result)
+		if err != nil {
+			log.Fatalf("Export failed: %v", err)
+		}
+		fmt.Printf("\n✓ Exported equity curve to: %s\n", filename)
+	}
+}
+
+// runSweep backtests every combination of the configured parameter grid,
+// prints the comparison table best CAGR first and exports it to CSV
+func runSweep(configPath, from, to string, workers int) {
+	var fromDate time.Time
+	var err error
+	if from != "" {
+		if fromDate, err = time.Parse("2006-01-02", from); err != nil {
+			log.Fatalf("Invalid -from date %q: %v", from, err)
+		}
+	}
+	toDate := time.Now()
+	if to != "" {
+		if toDate, err = time.Parse("2006-01-02", to); err != nil {
+			log.Fatalf("Invalid -to date %q: %v", to, err)
+		}
+	}
+
+	// Load configuration
+	cfg, err := pipeline.LoadConfig(configPath)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	if workers > 0 {
+		cfg.Sweep.Workers = workers
+	}
+
+	// Initialize database
+	database, err := initDatabase(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer database.Close()
+
+	grid := pipeline.NewSweepGrid(cfg)
+	fmt.Printf("Sweeping %d combinations, %d at a time...\n", len(grid.Combinations()), cfg.Sweep.Workers)
+
+	start := time.Now()
+	results, err := pipeline.RunSweep(cfg, database, pipeline.NewBacktestConfig(cfg, fromDate, toDate), grid)
+	if err != nil {
+		log.Fatalf("Sweep failed: %v", err)
+	}
+
+	fmt.Printf("\nSweep complete in %v\n\n", time.Since(start).Round(time.Millisecond))
+	fmt.Printf("  %-7s %-28s %4s %9s %8s %8s %9s %8s\n", "Lambda", "Weights", "Top", "Return", "CAGR", "MaxDD", "Turnover", "HitRate")
+	for _, r := range results {
+		if r.Err != nil {
+			fmt.Printf("  %-7g %-28s %4d  ✗ %v\n", r.Params.Lambda, r.Params.WeightsLabel(), r.Params.TopN, r.Err)
+			continue
+		}
+		fmt.Printf("  %-7g %-28s %4d %8.2f%% %7.2f%% %7.2f%% %8.2f%% %7.2f%%\n",
+			r.Params.Lambda, r.Params.WeightsLabel(), r.Params.TopN,
+			r.Result.TotalReturn*100, r.Result.CAGR*100, r.Result.MaxDrawdown*100,
+			r.Result.Turnover*100, r.Result.HitRate*100)
+	}
+
+	filename, err := export.New(database, cfg.Data.ExportDir).ExportSweep(results)
+	if err != nil {
+		log.Fatalf("Export failed: %v", err)
+	}
+	fmt.Printf("\n✓ Exported comparison table to: %s\n", filename)
 }
 
 // runExport exports data to CSV files
 func runExport(configPath, exportType, symbol string, topN int, date string) {
 	// Load configuration
-	cfg, err := config.Load(configPath)
+	cfg, err := pipeline.LoadConfig(configPath)
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
@@ -432,6 +834,7 @@ func runExport(configPath, exportType, symbol string, topN int, date string) {
 
 	// Create exporter
 	exporter := export.New(database, cfg.Data.ExportDir)
+	exporter.SetStrategy(cfg.Scoring.Strategy)
 
 	var filename string
 	switch exportType {
@@ -477,7 +880,7 @@ func runPing(configPath string) {
 
 	// Check config
 	fmt.Printf("  Config file: %s\n", configPath)
-	cfg, err := config.Load(configPath)
+	cfg, err := pipeline.LoadConfig(configPath)
 	if err != nil {
 		fmt.Printf("  ✗ Config load failed: %v\n", err)
 		os.Exit(1)
@@ -551,6 +954,13 @@ func initDatabase(cfg *config.Config) (*db.DB, error) {
 		return nil, fmt.Errorf("failed to run migrations: %w", err)
 	}
 
+	// Runs left RUNNING by a crashed or killed process never finish on their own
+	if n, err := pipeline.MarkStaleRuns(cfg, database); err != nil {
+		log.Printf("Warning: Failed to check for interrupted runs: %v", err)
+	} else if n > 0 {
+		log.Printf("Marked %d interrupted run(s) as ERROR; continue with `momo refresh -resume`", n)
+	}
+
 	// Ensure all configured symbols exist in database
 	symbolRepo := db.NewSymbolRepository(database)
 	for _, symbol := range cfg.Universe {
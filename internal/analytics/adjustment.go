@@ -0,0 +1,204 @@
+package analytics
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cajundata/momorot/internal/db"
+)
+
+// CorporateAction is a dividend and/or split taking effect on an ex-date.
+type CorporateAction struct {
+	Date             time.Time
+	Dividend         float64 // Cash dividend per share, 0 if none
+	SplitCoefficient float64 // New shares per old share, 1 (or 0) if none
+}
+
+// AdjustCloses computes split- and dividend-adjusted closes from raw closes.
+// The most recent close is left unchanged and every earlier close is scaled by
+// the cumulative factor of the actions that take effect after it:
+//   - a split of n divides earlier closes by n
+//   - a dividend d multiplies earlier closes by 1 - d/close of the previous day
+//
+// Actions dated on a non-trading day apply from the next available bar; actions
+// after the last bar are ignored. Prices must be sorted by date ascending.
+func AdjustCloses(prices []PriceBar, actions []CorporateAction) []float64 {
+	adjusted := make([]float64, len(prices))
+	if len(prices) == 0 {
+		return adjusted
+	}
+
+	sorted := make([]CorporateAction, len(actions))
+	copy(sorted, actions)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+
+	// Multiplier that applies to all bars before index i, keyed by i
+	multipliers := make([]float64, len(prices))
+	for i := range multipliers {
+		multipliers[i] = 1
+	}
+
+	bar := 0
+	for _, action := range sorted {
+		for bar < len(prices) && prices[bar].Date.Before(action.Date) {
+			bar++
+		}
+		if bar == len(prices) {
+			break
+		}
+		if bar == 0 {
+			// Nothing earlier to adjust
+			continue
+		}
+
+		split := action.SplitCoefficient
+		if split <= 0 {
+			split = 1
+		}
+		m := 1 / split
+
+		// The dividend is paid in post-split shares, so compare it with the
+		// previous close expressed in the same terms
+		if prevClose := prices[bar-1].Close / split; action.Dividend > 0 && prevClose > 0 {
+			m *= 1 - action.Dividend/prevClose
+		}
+		multipliers[bar] *= m
+	}
+
+	factor := 1.0
+	for i := len(prices) - 1; i >= 0; i-- {
+		adjusted[i] = prices[i].Close * factor
+		factor *= multipliers[i]
+	}
+
+	return adjusted
+}
+
+// Adjuster rebuilds stored adjusted closes from raw closes and the corporate
+// actions recorded for a symbol, so the whole series uses one set of factors.
+// History older than the first recorded action keeps the provider's adjusted
+// closes, since the actions behind them (e.g. dividends in a CSV drop that has
+// none) are not known locally.
+type Adjuster struct {
+	database   *db.DB
+	priceRepo  *db.PriceRepository
+	actionRepo *db.CorporateActionRepository
+}
+
+// NewAdjuster creates a new adjusted close engine.
+func NewAdjuster(database *db.DB) *Adjuster {
+	return &Adjuster{
+		database:   database,
+		priceRepo:  db.NewPriceRepository(database),
+		actionRepo: db.NewCorporateActionRepository(database),
+	}
+}
+
+// RebuildSymbol recomputes adj_close for every stored price of symbol.
+// Returns the number of price rows updated.
+func (a *Adjuster) RebuildSymbol(symbol string) (int, error) {
+	rows, err := a.database.Query(`
+		SELECT date, close, adj_close
+		FROM prices
+		WHERE symbol = ?
+		ORDER BY date ASC
+	`, symbol)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query prices for %s: %w", symbol, err)
+	}
+	defer rows.Close()
+
+	var dates []string
+	var prices []PriceBar
+	for rows.Next() {
+		var dateStr string
+		var pb PriceBar
+		var adjClose sql.NullFloat64
+		if err := rows.Scan(&dateStr, &pb.Close, &adjClose); err != nil {
+			return 0, fmt.Errorf("failed to scan price row: %w", err)
+		}
+		pb.AdjClose = adjClose.Float64
+		pb.Date, err = time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse date %s: %w", dateStr, err)
+		}
+		dates = append(dates, dateStr)
+		prices = append(prices, pb)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error iterating price rows: %w", err)
+	}
+	rows.Close()
+
+	if len(prices) == 0 {
+		return 0, nil
+	}
+
+	stored, err := a.actionRepo.ListForSymbol(symbol)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get corporate actions for %s: %w", symbol, err)
+	}
+
+	actions := make([]CorporateAction, 0, len(stored))
+	for _, ca := range stored {
+		date, err := time.Parse("2006-01-02", ca.Date)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse action date %s: %w", ca.Date, err)
+		}
+		actions = append(actions, CorporateAction{
+			Date:             date,
+			Dividend:         ca.Dividend,
+			SplitCoefficient: ca.SplitCoefficient,
+		})
+	}
+
+	adjusted := AdjustCloses(prices, actions)
+	keepProviderHistory(prices, adjusted, actions)
+
+	updates := make([]db.Price, len(prices))
+	for i := range prices {
+		adjClose := adjusted[i]
+		updates[i] = db.Price{Symbol: symbol, Date: dates[i], AdjClose: &adjClose}
+	}
+
+	if err := a.priceRepo.UpdateAdjCloses(updates); err != nil {
+		return 0, fmt.Errorf("failed to update adjusted closes for %s: %w", symbol, err)
+	}
+
+	return len(updates), nil
+}
+
+// keepProviderHistory replaces the rebuilt closes before the first action with
+// the stored ones, scaled to meet the rebuilt close of the bar just before it.
+// Returns within that history stay the provider's, which may reflect actions
+// that were never recorded locally. Bars without a stored adjusted close keep
+// the rebuilt value, as do all bars if the anchor bar has none.
+func keepProviderHistory(prices []PriceBar, adjusted []float64, actions []CorporateAction) {
+	first := len(prices)
+	for _, action := range actions {
+		for i := 0; i < first; i++ {
+			if !prices[i].Date.Before(action.Date) {
+				first = i
+				break
+			}
+		}
+	}
+	if first == 0 {
+		return
+	}
+
+	anchor := prices[first-1].AdjClose
+	if anchor <= 0 {
+		return
+	}
+	scale := adjusted[first-1] / anchor
+	for i := 0; i < first; i++ {
+		if prices[i].AdjClose > 0 {
+			adjusted[i] = prices[i].AdjClose * scale
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package analytics
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cajundata/momorot/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func janDay(d int) time.Time {
+	return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestAdjustCloses_NoActions(t *testing.T) {
+	prices := []PriceBar{
+		{Date: janDay(2), Close: 100},
+		{Date: janDay(3), Close: 101},
+	}
+
+	assert.Equal(t, []float64{100, 101}, AdjustCloses(prices, nil))
+}
+
+func TestAdjustCloses_Split(t *testing.T) {
+	// 4-for-1 split effective on the 4th
+	prices := []PriceBar{
+		{Date: janDay(2), Close: 400},
+		{Date: janDay(3), Close: 404},
+		{Date: janDay(4), Close: 102},
+		{Date: janDay(5), Close: 103},
+	}
+	actions := []CorporateAction{{Date: janDay(4), SplitCoefficient: 4}}
+
+	adjusted := AdjustCloses(prices, actions)
+	assert.InDeltaSlice(t, []float64{100, 101, 102, 103}, adjusted, 1e-9)
+
+	// Returns across the split are continuous
+	for i := range prices {
+		prices[i].AdjClose = adjusted[i]
+	}
+	r1m, _, _, _, err := CalculateReturns(prices, map[string]int{"r1m": 3, "r3m": 1, "r6m": 1, "r12m": 1})
+	require.NoError(t, err)
+	assert.InDelta(t, 0.03, r1m, 1e-9)
+}
+
+func TestAdjustCloses_Dividend(t *testing.T) {
+	prices := []PriceBar{
+		{Date: janDay(2), Close: 100},
+		{Date: janDay(3), Close: 100},
+		{Date: janDay(4), Close: 98},
+	}
+	// $2 dividend going ex on the 4th: earlier closes scale by 1 - 2/100
+	actions := []CorporateAction{{Date: janDay(4), Dividend: 2, SplitCoefficient: 1}}
+
+	assert.InDeltaSlice(t, []float64{98, 98, 98}, AdjustCloses(prices, actions), 1e-9)
+}
+
+func TestAdjustCloses_ActionOnNonTradingDay(t *testing.T) {
+	prices := []PriceBar{
+		{Date: janDay(5), Close: 200}, // Friday
+		{Date: janDay(8), Close: 100}, // Monday
+	}
+	// Dated on the weekend, applies from Monday; actions before the first bar
+	// and after the last bar change nothing
+	actions := []CorporateAction{
+		{Date: janDay(1), SplitCoefficient: 10},
+		{Date: janDay(6), SplitCoefficient: 2},
+		{Date: janDay(20), Dividend: 5},
+	}
+
+	assert.InDeltaSlice(t, []float64{100, 100}, AdjustCloses(prices, actions), 1e-9)
+}
+
+func TestAdjuster_RebuildSymbol(t *testing.T) {
+	database, err := db.New(db.Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	require.NoError(t, err)
+	defer database.Close()
+	require.NoError(t, database.Migrate())
+
+	require.NoError(t, db.NewSymbolRepository(database).Create(&db.Symbol{Symbol: "AAPL", Name: "Apple", AssetType: "STOCK", Active: true}))
+
+	// Stale adj_close values from an earlier download that predates the split
+	priceRepo := db.NewPriceRepository(database)
+	for i, close := range []float64{400, 404, 102} {
+		adjClose := close
+		require.NoError(t, priceRepo.Create(&db.Price{
+			Symbol: "AAPL", Date: janDay(2 + i).Format("2006-01-02"),
+			Open: close, High: close, Low: close, Close: close, AdjClose: &adjClose,
+		}))
+	}
+
+	_, err = db.NewCorporateActionRepository(database).UpsertBatch([]db.CorporateAction{
+		{Symbol: "AAPL", Date: "2024-01-04", Dividend: 0, SplitCoefficient: 4},
+	})
+	require.NoError(t, err)
+
+	updated, err := NewAdjuster(database).RebuildSymbol("AAPL")
+	require.NoError(t, err)
+	assert.Equal(t, 3, updated)
+
+	prices, err := priceRepo.GetRange("AAPL", "2024-01-01", "2024-01-31")
+	require.NoError(t, err)
+	require.Len(t, prices, 3)
+	assert.InDelta(t, 100.0, *prices[0].AdjClose, 1e-9)
+	assert.InDelta(t, 101.0, *prices[1].AdjClose, 1e-9)
+	assert.InDelta(t, 102.0, *prices[2].AdjClose, 1e-9)
+	assert.Equal(t, 400.0, prices[0].Close) // Raw closes are untouched
+}
+
+func TestAdjuster_RebuildSymbolKeepsProviderHistory(t *testing.T) {
+	database, err := db.New(db.Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	require.NoError(t, err)
+	defer database.Close()
+	require.NoError(t, database.Migrate())
+
+	require.NoError(t, db.NewSymbolRepository(database).Create(&db.Symbol{Symbol: "SPY", Name: "SPY", AssetType: "ETF", Active: true}))
+
+	// A CSV drop adjusted for a dividend on January 3 that it does not carry,
+	// then a refresh with a $2 dividend on January 5
+	priceRepo := db.NewPriceRepository(database)
+	for i, bar := range []struct{ close, adjClose float64 }{
+		{100, 99}, {100, 100}, {100, 100}, {98, 98},
+	} {
+		adjClose := bar.adjClose
+		require.NoError(t, priceRepo.Create(&db.Price{
+			Symbol: "SPY", Date: janDay(2 + i).Format("2006-01-02"),
+			Open: bar.close, High: bar.close, Low: bar.close, Close: bar.close, AdjClose: &adjClose,
+		}))
+	}
+
+	_, err = db.NewCorporateActionRepository(database).UpsertBatch([]db.CorporateAction{
+		{Symbol: "SPY", Date: "2024-01-05", Dividend: 2, SplitCoefficient: 1},
+	})
+	require.NoError(t, err)
+
+	_, err = NewAdjuster(database).RebuildSymbol("SPY")
+	require.NoError(t, err)
+
+	prices, err := priceRepo.GetRange("SPY", "2024-01-01", "2024-01-31")
+	require.NoError(t, err)
+	require.Len(t, prices, 4)
+	assert.InDelta(t, 98.0, *prices[3].AdjClose, 1e-9)
+	assert.InDelta(t, 98.0, *prices[2].AdjClose, 1e-9)
+	assert.InDelta(t, 98.0, *prices[1].AdjClose, 1e-9)
+	assert.InDelta(t, 97.02, *prices[0].AdjClose, 1e-9) // The January 3 dividend is kept
+}
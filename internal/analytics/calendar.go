@@ -8,12 +8,14 @@ import (
 // Calendar handles NYSE trading day calculations and holiday detection.
 type Calendar struct {
 	holidays map[string]bool
+	years    map[int]bool // Years with a holiday list
 }
 
 // NewCalendar creates a new NYSE trading calendar with holidays for 2024-2030.
 func NewCalendar() *Calendar {
 	c := &Calendar{
 		holidays: make(map[string]bool),
+		years:    make(map[int]bool),
 	}
 	c.initializeHolidays()
 	return c
@@ -109,9 +111,18 @@ func (c *Calendar) initializeHolidays() {
 
 	for _, holiday := range nyseHolidays {
 		c.holidays[holiday] = true
+		if date, err := time.Parse("2006-01-02", holiday); err == nil {
+			c.years[date.Year()] = true
+		}
 	}
 }
 
+// Covers returns true if the calendar knows the holidays of the date's year.
+// Outside those years IsBusinessDay only excludes weekends.
+func (c *Calendar) Covers(date time.Time) bool {
+	return c.years[date.Year()]
+}
+
 // IsBusinessDay returns true if the date is a trading day (not weekend or holiday).
 func (c *Calendar) IsBusinessDay(date time.Time) bool {
 	// Check if weekend
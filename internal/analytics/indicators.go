@@ -8,8 +8,11 @@ import (
 
 // IndicatorCalculator computes momentum indicators from price data.
 type IndicatorCalculator struct {
-	lookbacks  map[string]int // r1m, r3m, r6m, r12m in trading days
+	lookbacks  map[string]int // r1m, r3m, r6m, r12m in trading days; skip > 0 adds 12-1 momentum
 	volWindows map[string]int // short, long volatility windows
+	specs      []IndicatorSpec // Configured indicators computed alongside the fixed set
+	risk       RiskConfig      // Risk metrics window, risk-free rate and benchmark
+	benchmark  []PriceBar      // Benchmark prices beta is measured against
 }
 
 // NewIndicatorCalculator creates a new indicator calculator with specified lookback periods.
@@ -20,6 +23,22 @@ func NewIndicatorCalculator(lookbacks, volWindows map[string]int) *IndicatorCalc
 	}
 }
 
+// SetSpecs sets the configured indicators to compute for every symbol.
+func (ic *IndicatorCalculator) SetSpecs(specs []IndicatorSpec) {
+	ic.specs = specs
+}
+
+// SetRisk sets the risk metrics computed for every symbol.
+func (ic *IndicatorCalculator) SetRisk(risk RiskConfig) {
+	ic.risk = risk
+}
+
+// SetBenchmark sets the benchmark prices beta is measured against. Without
+// them beta is left out.
+func (ic *IndicatorCalculator) SetBenchmark(prices []PriceBar) {
+	ic.benchmark = prices
+}
+
 // CalculateReturns computes multi-horizon total returns using adjusted close.
 // Returns are calculated as: (price_t / price_t-n) - 1
 func CalculateReturns(prices []PriceBar, lookbacks map[string]int) (r1m, r3m, r6m, r12m float64, err error) {
@@ -213,6 +232,39 @@ func (ic *IndicatorCalculator) ComputeIndicators(symbol string, prices []PriceBa
 		return nil, fmt.Errorf("failed to calculate ADV for %s: %w", symbol, err)
 	}
 
+	// 12-1 momentum over [t-R12M, t-skip] when a skip is configured, then
+	// configured indicators; those not blended into the score are left out
+	// while the history is too short for them
+	var values map[string]float64
+	if skip := ic.lookbacks["skip"]; skip > 0 {
+		r12m1, err := CalculateWindowReturn(sortedPrices, ic.lookbacks["r12m"], skip)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate 12-1 momentum for %s: %w", symbol, err)
+		}
+		values = map[string]float64{HorizonR12M1: r12m1}
+	}
+	for _, spec := range ic.specs {
+		value, err := spec.Compute(sortedPrices)
+		if err != nil {
+			if spec.Score {
+				return nil, fmt.Errorf("failed to calculate %s for %s: %w", spec.Name, symbol, err)
+			}
+			continue
+		}
+		if values == nil {
+			values = make(map[string]float64, len(ic.specs))
+		}
+		values[spec.Name] = value
+	}
+
+	// Risk metrics, left out while the history is too short for them
+	for name, value := range computeRisk(sortedPrices, ic.benchmark, ic.risk) {
+		if values == nil {
+			values = make(map[string]float64)
+		}
+		values[name] = value
+	}
+
 	return &Indicators{
 		Symbol:   symbol,
 		Date:     latestDate,
@@ -225,5 +277,6 @@ func (ic *IndicatorCalculator) ComputeIndicators(symbol string, prices []PriceBa
 		ADV:      adv,
 		Score:    0, // Will be computed by scoring module
 		Rank:     0, // Will be assigned by ranking algorithm
+		Values:   values,
 	}, nil
 }
@@ -2,7 +2,9 @@ package analytics
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/cajundata/momorot/internal/db"
@@ -15,7 +17,8 @@ type Orchestrator struct {
 	priceRepo    *db.PriceRepository
 	indicatorRepo *db.IndicatorRepository
 	calculator   *IndicatorCalculator
-	scorer       *Scorer
+	scoring      ScoringConfig // Scoring parameters, including the strategy
+	benchmark    string // Symbol beta is measured against
 }
 
 // NewOrchestrator creates a new analytics orchestrator.
@@ -26,40 +29,387 @@ func NewOrchestrator(database *db.DB, lookbacks, volWindows map[string]int, scor
 		priceRepo:     db.NewPriceRepository(database),
 		indicatorRepo: db.NewIndicatorRepository(database),
 		calculator:    NewIndicatorCalculator(lookbacks, volWindows),
-		scorer:        NewScorer(scoringConfig),
+		scoring:       scoringConfig,
 	}
 }
 
-// ComputeAllIndicators computes indicators for all active symbols and stores them in the database.
+// SetIndicatorSpecs sets the configured indicators computed for every symbol.
+// Those marked Score are blended into the momentum score.
+func (o *Orchestrator) SetIndicatorSpecs(specs []IndicatorSpec) {
+	o.calculator.SetSpecs(specs)
+	o.scoring.ExtraHorizons = ScoredNames(specs)
+}
+
+// SetScoring replaces the penalty factor and horizon weights used to score,
+// e.g. to try other parameters over the same history.
+func (o *Orchestrator) SetScoring(penaltyLambda float64, weights map[string]float64) {
+	o.scoring.PenaltyLambda = penaltyLambda
+	o.scoring.Weights = weights
+}
+
+// SetStrategy replaces the scoring strategy and the cash proxy dual momentum
+// measures absolute momentum against.
+func (o *Orchestrator) SetStrategy(name, cashProxy string) {
+	o.scoring.Strategy = name
+	o.scoring.CashProxy = cashProxy
+}
+
+// Strategy returns the name of the scoring strategy rankings are computed with.
+func (o *Orchestrator) Strategy() string {
+	if o.scoring.Strategy == "" {
+		return StrategyMomentum
+	}
+	return o.scoring.Strategy
+}
+
+// SetRisk sets the risk metrics computed for every symbol. Beta is measured
+// against the prices stored for risk.Benchmark.
+func (o *Orchestrator) SetRisk(risk RiskConfig) {
+	o.calculator.SetRisk(risk)
+	o.benchmark = risk.Benchmark
+}
+
+// LivePrice is a latest traded price standing in for the current bar when
+// computing provisional rankings.
+type LivePrice struct {
+	Date   time.Time // Trading day the price belongs to
+	Price  float64
+	Volume float64 // Volume traded so far on Date, 0 if unknown
+}
+
+// ComputeAllIndicators computes indicators for all active symbols from their
+// prices on or before asOfDate and stores them in the database.
 // Returns the number of symbols processed and any error encountered.
 func (o *Orchestrator) ComputeAllIndicators(asOfDate time.Time) (int, error) {
-	// Get list of active symbols
-	symbolRecords, err := o.symbolRepo.ListActive()
+	series, err := o.loadSeries(asOfDate.Format("2006-01-02"))
 	if err != nil {
-		return 0, fmt.Errorf("failed to list active symbols: %w", err)
+		return 0, err
 	}
 
-	// Extract symbol strings
-	symbols := make([]string, len(symbolRecords))
-	for i, sr := range symbolRecords {
-		symbols[i] = sr.Symbol
+	rankedSymbols, processedCount, err := o.rankToStore(series)
+	if err != nil {
+		return processedCount, err
 	}
 
-	if len(symbols) == 0 {
-		return 0, fmt.Errorf("no active symbols found")
+	// Persist indicators to database
+	indicatorsToSave := make([]db.Indicator, 0, len(rankedSymbols))
+	for _, rs := range rankedSymbols {
+		indicatorsToSave = append(indicatorsToSave, IndicatorRecord(rs))
 	}
 
-	indicatorsList := make([]*Indicators, 0, len(symbols))
-	processedCount := 0
+	err = o.indicatorRepo.UpsertBatch(indicatorsToSave)
+	if err != nil {
+		return processedCount, fmt.Errorf("failed to save indicators: %w", err)
+	}
 
-	// Compute indicators for each symbol
-	for _, symbol := range symbols {
-		// Fetch price data for this symbol
-		prices, err := o.fetchPricesForSymbol(symbol)
+	return processedCount, nil
+}
+
+// BackfillDay reports the outcome of one day of a backfill.
+type BackfillDay struct {
+	Date    time.Time
+	Symbols int   // Symbols ranked on the day, 0 if none beat the cash hurdle
+	Err     error // Why the day was not stored
+}
+
+// Backfill computes and stores indicators, scores and ranks for every NYSE
+// business day from from through to, each using only the prices on or before
+// that day. Only symbols with a bar on the day are ranked, and the ranking
+// replaces whatever was stored for the day. onDay, if not nil, is called after
+// each day. Returns the number of days stored.
+func (o *Orchestrator) Backfill(from, to time.Time, onDay func(BackfillDay)) (int, error) {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	if to.Before(from) {
+		return 0, fmt.Errorf("backfill range ends before it starts: %s to %s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+
+	series, err := o.loadSeries(to.Format("2006-01-02"))
+	if err != nil {
+		return 0, err
+	}
+
+	stored := 0
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if !IsBusinessDay(day) {
+			continue
+		}
+
+		result := BackfillDay{Date: day}
+		if count, err := o.storeDay(series.through(day), day); err != nil {
+			result.Err = err
+		} else {
+			result.Symbols = count
+			stored++
+		}
+		if onDay != nil {
+			onDay(result)
+		}
+	}
+
+	return stored, nil
+}
+
+// IndicatorRecord converts a ranked symbol into its indicators table row.
+func IndicatorRecord(rs *SymbolScore) db.Indicator {
+	r1m := rs.Indicators.R1M
+	r3m := rs.Indicators.R3M
+	r6m := rs.Indicators.R6M
+	r12m := rs.Indicators.R12M
+	vol3m := rs.Indicators.Vol3M
+	vol6m := rs.Indicators.Vol6M
+	adv := rs.Indicators.ADV
+
+	// Symbols left unranked (see rankToStore) are stored without score or rank
+	var score *float64
+	var rank *int
+	if rs.Indicators.Rank > 0 {
+		s, r := rs.Score, rs.Indicators.Rank
+		score, rank = &s, &r
+	}
+
+	return db.Indicator{
+		Symbol:   rs.Symbol,
+		Date:     rs.Indicators.Date.Format("2006-01-02"),
+		R1M:      &r1m,
+		R3M:      &r3m,
+		R6M:      &r6m,
+		R12M:     &r12m,
+		Vol3M:    &vol3m,
+		Vol6M:    &vol6m,
+		ADV:      &adv,
+		Score:    score,
+		Rank:     rank,
+		Values:   rs.Indicators.Values,
+		Strategy: rs.Strategy,
+	}
+}
+
+// storeDay ranks the symbols in series and replaces the ranking stored for day.
+// Returns the number of symbols ranked.
+func (o *Orchestrator) storeDay(series *History, day time.Time) (int, error) {
+	rankedSymbols, _, err := o.rankToStore(series)
+	if err != nil {
+		return 0, err
+	}
+
+	records := make([]db.Indicator, 0, len(rankedSymbols))
+	ranked := 0
+	for _, rs := range rankedSymbols {
+		records = append(records, IndicatorRecord(rs))
+		if rs.Indicators.Rank > 0 {
+			ranked++
+		}
+	}
+	if err := o.indicatorRepo.ReplaceDate(day.Format("2006-01-02"), o.Strategy(), records); err != nil {
+		return 0, fmt.Errorf("failed to save indicators: %w", err)
+	}
+
+	return ranked, nil
+}
+
+// ComputeProvisional ranks all active symbols as if the live prices were the
+// current bars. A live price for a day after the last stored bar is appended as
+// a new bar; one for the same day replaces that bar's close. The result is never
+// stored: it is a preview of where the ranking is heading before the close.
+func (o *Orchestrator) ComputeProvisional(live map[string]LivePrice) ([]*SymbolScore, error) {
+	series, err := o.loadSeries("")
+	if err != nil {
+		return nil, err
+	}
+
+	rankedSymbols, _, err := o.rankSeries(series, live)
+	return rankedSymbols, err
+}
+
+// History holds the price history of the active symbols and the benchmark,
+// each sorted by date ascending, for ranking past days without querying the
+// database for each one.
+type History struct {
+	symbols   []string              // Active symbols, the ones ranked
+	prices    map[string][]PriceBar // Active and extra symbols
+	benchmark []PriceBar
+}
+
+// NewHistory creates a history from prices sorted by date ascending. All
+// symbols are ranked and there is no benchmark.
+func NewHistory(prices map[string][]PriceBar) *History {
+	history := &History{prices: prices}
+	for symbol := range prices {
+		history.symbols = append(history.symbols, symbol)
+	}
+	sort.Strings(history.symbols)
+	return history
+}
+
+// LoadHistory loads the prices on or before through of all active symbols and
+// the benchmark, plus those of extra symbols that are not ranked (e.g. a cash
+// proxy).
+func (o *Orchestrator) LoadHistory(through time.Time, extra ...string) (*History, error) {
+	history, err := o.loadSeries(through.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, symbol := range extra {
+		if _, ok := history.prices[symbol]; ok {
+			continue
+		}
+		if prices, err := o.fetchPricesForSymbol(symbol, through.Format("2006-01-02")); err == nil {
+			history.prices[symbol] = prices
+		}
+	}
+
+	return history, nil
+}
+
+// Rank scores and ranks the symbols with a bar on day, using only the prices
+// on or before it. Nothing is stored.
+func (o *Orchestrator) Rank(history *History, day time.Time) ([]*SymbolScore, error) {
+	rankedSymbols, _, err := o.rankSeries(history.through(day), nil)
+	return rankedSymbols, err
+}
+
+// Start returns the date of the earliest bar of the active symbols, or the
+// zero time if there are none.
+func (h *History) Start() time.Time {
+	var start time.Time
+	for _, symbol := range h.symbols {
+		if prices := h.prices[symbol]; len(prices) > 0 && (start.IsZero() || prices[0].Date.Before(start)) {
+			start = prices[0].Date
+		}
+	}
+	return start
+}
+
+// Symbols returns the active symbols in the history.
+func (h *History) Symbols() []string {
+	return h.symbols
+}
+
+// CloseOn returns the adjusted close of symbol's last bar on or before day,
+// and false when there is none.
+func (h *History) CloseOn(symbol string, day time.Time) (float64, bool) {
+	prices := h.prices[symbol]
+	n := sort.Search(len(prices), func(i int) bool { return prices[i].Date.After(day) })
+	if n == 0 {
+		return 0, false
+	}
+	return prices[n-1].AdjClose, true
+}
+
+// loadSeries loads the prices of all active symbols and the benchmark on or
+// before through (YYYY-MM-DD); an empty through loads all prices. Symbols
+// without prices are left out.
+func (o *Orchestrator) loadSeries(through string) (*History, error) {
+	// Get list of active symbols
+	symbolRecords, err := o.symbolRepo.ListActive()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active symbols: %w", err)
+	}
+
+	if len(symbolRecords) == 0 {
+		return nil, fmt.Errorf("no active symbols found")
+	}
+
+	series := &History{prices: make(map[string][]PriceBar, len(symbolRecords))}
+	for _, sr := range symbolRecords {
+		prices, err := o.fetchPricesForSymbol(sr.Symbol, through)
 		if err != nil {
 			// Log error but continue with other symbols
 			continue
 		}
+		series.symbols = append(series.symbols, sr.Symbol)
+		series.prices[sr.Symbol] = prices
+	}
+
+	// Benchmark prices for beta; without them beta is left out
+	if o.benchmark != "" {
+		if prices, err := o.fetchPricesForSymbol(o.benchmark, through); err == nil {
+			series.benchmark = prices
+		}
+	}
+
+	return series, nil
+}
+
+// through returns the history as it stood at the close of day: bars after day
+// are dropped, as are symbols without a bar on day.
+func (h *History) through(day time.Time) *History {
+	cut := func(prices []PriceBar) []PriceBar {
+		n := sort.Search(len(prices), func(i int) bool { return prices[i].Date.After(day) })
+		return prices[:n]
+	}
+
+	result := &History{prices: make(map[string][]PriceBar), benchmark: cut(h.benchmark)}
+	for _, symbol := range h.symbols {
+		prices := cut(h.prices[symbol])
+		if len(prices) == 0 || !prices[len(prices)-1].Date.Equal(day) {
+			continue
+		}
+		result.symbols = append(result.symbols, symbol)
+		result.prices[symbol] = prices
+	}
+	return result
+}
+
+// rankSeries computes indicators for every symbol in series, with live prices
+// applied, and scores and ranks them. Returns the ranked symbols and the number
+// of symbols processed.
+func (o *Orchestrator) rankSeries(series *History, live map[string]LivePrice) ([]*SymbolScore, int, error) {
+	indicatorsList, err := o.computeSeries(series, live)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rankedSymbols, err := o.scoreAndRank(indicatorsList)
+	return rankedSymbols, len(indicatorsList), err
+}
+
+// rankToStore ranks series like rankSeries for a ranking that is stored. When
+// no symbol beats the cash hurdle, the symbols are returned unranked instead
+// of failing, so the day is stored as out of the market rather than left to
+// an older ranking.
+func (o *Orchestrator) rankToStore(series *History) ([]*SymbolScore, int, error) {
+	indicatorsList, err := o.computeSeries(series, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rankedSymbols, err := o.scoreAndRank(indicatorsList)
+	if errors.Is(err, ErrBelowHurdle) {
+		rankedSymbols = make([]*SymbolScore, 0, len(indicatorsList))
+		for _, ind := range indicatorsList {
+			rankedSymbols = append(rankedSymbols, &SymbolScore{
+				Symbol:     ind.Symbol,
+				Volatility: ind.Vol6M,
+				Liquidity:  ind.ADV,
+				Strategy:   o.Strategy(),
+				Indicators: *ind,
+			})
+		}
+		err = nil
+	}
+	return rankedSymbols, len(indicatorsList), err
+}
+
+// computeSeries computes indicators for every symbol in series, with live
+// prices applied. Symbols whose indicators cannot be computed are left out.
+func (o *Orchestrator) computeSeries(series *History, live map[string]LivePrice) ([]*Indicators, error) {
+	benchmark := series.benchmark
+	if lp, ok := live[o.benchmark]; ok {
+		benchmark = applyLivePrice(benchmark, lp)
+	}
+	o.calculator.SetBenchmark(benchmark)
+
+	indicatorsList := make([]*Indicators, 0, len(series.symbols))
+
+	// Compute indicators for each symbol
+	for _, symbol := range series.symbols {
+		prices := series.prices[symbol]
+		if lp, ok := live[symbol]; ok {
+			prices = applyLivePrice(prices, lp)
+		}
 
 		// Compute indicators
 		indicators, err := o.calculator.ComputeIndicators(symbol, prices)
@@ -69,72 +419,76 @@ func (o *Orchestrator) ComputeAllIndicators(asOfDate time.Time) (int, error) {
 		}
 
 		indicatorsList = append(indicatorsList, indicators)
-		processedCount++
 	}
 
 	if len(indicatorsList) == 0 {
-		return 0, fmt.Errorf("no indicators could be computed")
+		return nil, fmt.Errorf("no indicators could be computed")
 	}
 
-	// Score and rank all symbols
-	rankedSymbols, err := o.scorer.ScoreAndRank(indicatorsList)
+	return indicatorsList, nil
+}
+
+// scoreAndRank scores and ranks indicatorsList with the configured strategy.
+func (o *Orchestrator) scoreAndRank(indicatorsList []*Indicators) ([]*SymbolScore, error) {
+	strategy, err := NewStrategy(o.scoring)
 	if err != nil {
-		return processedCount, fmt.Errorf("failed to score and rank: %w", err)
+		return nil, err
 	}
-
-	// Update indicators with scores and ranks
-	for _, rs := range rankedSymbols {
-		rs.Indicators.Score = rs.Score
-		rs.Indicators.Rank = rs.Indicators.Rank
+	rankedSymbols, err := strategy.ScoreAndRank(indicatorsList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to score and rank: %w", err)
 	}
 
-	// Persist indicators to database
-	indicatorsToSave := make([]db.Indicator, 0, len(rankedSymbols))
-	for _, rs := range rankedSymbols {
-		r1m := rs.Indicators.R1M
-		r3m := rs.Indicators.R3M
-		r6m := rs.Indicators.R6M
-		r12m := rs.Indicators.R12M
-		vol3m := rs.Indicators.Vol3M
-		vol6m := rs.Indicators.Vol6M
-		adv := rs.Indicators.ADV
-		score := rs.Score
-		rank := rs.Indicators.Rank
-
-		indicatorsToSave = append(indicatorsToSave, db.Indicator{
-			Symbol: rs.Symbol,
-			Date:   rs.Indicators.Date.Format("2006-01-02"),
-			R1M:    &r1m,
-			R3M:    &r3m,
-			R6M:    &r6m,
-			R12M:   &r12m,
-			Vol3M:  &vol3m,
-			Vol6M:  &vol6m,
-			ADV:    &adv,
-			Score:  &score,
-			Rank:   &rank,
-		})
-	}
+	return rankedSymbols, nil
+}
 
-	err = o.indicatorRepo.UpsertBatch(indicatorsToSave)
-	if err != nil {
-		return processedCount, fmt.Errorf("failed to save indicators: %w", err)
+// applyLivePrice returns prices (sorted ascending) with lp as the current bar.
+// Live prices older than the last stored bar are ignored.
+func applyLivePrice(prices []PriceBar, lp LivePrice) []PriceBar {
+	if len(prices) == 0 || lp.Price <= 0 {
+		return prices
 	}
 
-	return processedCount, nil
+	last := prices[len(prices)-1]
+	switch {
+	case lp.Date.After(last.Date):
+		bar := PriceBar{Date: lp.Date, Open: lp.Price, High: lp.Price, Low: lp.Price, Close: lp.Price, AdjClose: lp.Price, Volume: lp.Volume}
+		return append(prices[:len(prices):len(prices)], bar)
+	case lp.Date.Equal(last.Date):
+		updated := make([]PriceBar, len(prices))
+		copy(updated, prices)
+		bar := &updated[len(updated)-1]
+		// Keep the bar's adjustment factor
+		ratio := 1.0
+		if bar.Close > 0 && bar.AdjClose > 0 {
+			ratio = bar.AdjClose / bar.Close
+		}
+		bar.Close = lp.Price
+		bar.AdjClose = lp.Price * ratio
+		if lp.Volume > bar.Volume {
+			bar.Volume = lp.Volume
+		}
+		return updated
+	}
+	return prices
 }
 
-// fetchPricesForSymbol retrieves price data for a symbol from the database.
-func (o *Orchestrator) fetchPricesForSymbol(symbol string) ([]PriceBar, error) {
+// fetchPricesForSymbol retrieves price data for a symbol from the database,
+// up to and including through (YYYY-MM-DD) unless it is empty.
+func (o *Orchestrator) fetchPricesForSymbol(symbol, through string) ([]PriceBar, error) {
+	if through == "" {
+		through = "9999-12-31"
+	}
+
 	// Query prices from database
 	query := `
 		SELECT date, open, high, low, close, adj_close, volume
 		FROM prices
-		WHERE symbol = ?
+		WHERE symbol = ? AND date <= ?
 		ORDER BY date ASC
 	`
 
-	rows, err := o.database.Query(query, symbol)
+	rows, err := o.database.Query(query, symbol, through)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query prices for %s: %w", symbol, err)
 	}
@@ -192,19 +546,20 @@ func (o *Orchestrator) fetchPricesForSymbol(symbol string) ([]PriceBar, error) {
 	return prices, nil
 }
 
-// GetTopRanked retrieves the top N ranked symbols from the database for a given date.
+// GetTopRanked retrieves the top N symbols the orchestrator's strategy ranked
+// for a given date from the database.
 func (o *Orchestrator) GetTopRanked(asOfDate time.Time, n int) ([]*SymbolScore, error) {
 	dateStr := asOfDate.Format("2006-01-02")
 
 	query := `
 		SELECT symbol, r_1m, r_3m, r_6m, r_12m, vol_3m, vol_6m, adv, score, rank
 		FROM indicators
-		WHERE date = ?
+		WHERE date = ? AND strategy = ?
 		ORDER BY rank ASC
 		LIMIT ?
 	`
 
-	rows, err := o.database.Query(query, dateStr, n)
+	rows, err := o.database.Query(query, dateStr, o.Strategy(), n)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query top ranked symbols: %w", err)
 	}
@@ -226,6 +581,7 @@ func (o *Orchestrator) GetTopRanked(asOfDate time.Time, n int) ([]*SymbolScore,
 			Score:      getFloat(score),
 			Volatility: getFloat(vol6m),
 			Liquidity:  getFloat(adv),
+			Strategy:   o.Strategy(),
 			Indicators: Indicators{
 				Symbol: symbol,
 				Date:   asOfDate,
@@ -0,0 +1,224 @@
+package analytics
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/cajundata/momorot/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyLivePrice(t *testing.T) {
+	prices := []PriceBar{
+		{Date: janDay(2), Close: 100, AdjClose: 100, Volume: 1000},
+		{Date: janDay(3), Close: 200, AdjClose: 100, Volume: 1000}, // Adjusted for a later 2:1 split
+	}
+
+	// A new trading day becomes the current bar
+	appended := applyLivePrice(prices, LivePrice{Date: janDay(4), Price: 110, Volume: 500})
+	require.Len(t, appended, 3)
+	assert.Equal(t, PriceBar{Date: janDay(4), Open: 110, High: 110, Low: 110, Close: 110, AdjClose: 110, Volume: 500}, appended[2])
+
+	// The same day replaces the close, keeping the adjustment factor
+	replaced := applyLivePrice(prices, LivePrice{Date: janDay(3), Price: 220, Volume: 500})
+	require.Len(t, replaced, 2)
+	assert.Equal(t, 220.0, replaced[1].Close)
+	assert.InDelta(t, 110.0, replaced[1].AdjClose, 1e-9)
+	assert.Equal(t, 1000.0, replaced[1].Volume)
+	assert.Equal(t, 200.0, prices[1].Close) // Input is untouched
+
+	// Stale prices are ignored
+	assert.Equal(t, prices, applyLivePrice(prices, LivePrice{Date: janDay(1), Price: 90}))
+}
+
+func TestOrchestrator_ComputeProvisional(t *testing.T) {
+	database, err := db.New(db.Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	require.NoError(t, err)
+	defer database.Close()
+	require.NoError(t, database.Migrate())
+
+	symbolRepo := db.NewSymbolRepository(database)
+	priceRepo := db.NewPriceRepository(database)
+	for _, symbol := range []string{"AAA", "BBB"} {
+		require.NoError(t, symbolRepo.Create(&db.Symbol{Symbol: symbol, Name: symbol, AssetType: "ETF", Active: true}))
+
+		// AAA gains 1% a day and BBB 2%, so BBB leads on stored closes
+		step := 1.01
+		if symbol == "BBB" {
+			step = 1.02
+		}
+		close := 100.0
+		for d := 2; d <= 12; d++ {
+			volume := int64(1_000_000)
+			require.NoError(t, priceRepo.Create(&db.Price{
+				Symbol: symbol, Date: fmt.Sprintf("2024-01-%02d", d),
+				Open: close, High: close, Low: close, Close: close, AdjClose: &close, Volume: &volume,
+			}))
+			close *= step
+		}
+	}
+
+	lookbacks := map[string]int{"r1m": 1, "r3m": 2, "r6m": 3, "r12m": 4}
+	volWindows := map[string]int{"short": 3, "long": 5}
+	orchestrator := NewOrchestrator(database, lookbacks, volWindows, ScoringConfig{PenaltyLambda: 0, BreadthMinPositive: 0, BreadthTotal: 4})
+
+	_, err = orchestrator.ComputeAllIndicators(janDay(12))
+	require.NoError(t, err)
+	stored, err := db.NewIndicatorRepository(database).GetTopN("2024-01-12", db.DefaultStrategy, 10)
+	require.NoError(t, err)
+	require.Len(t, stored, 2)
+	assert.Equal(t, "BBB", stored[0].Symbol)
+
+	// BBB collapsing today flips the order
+	ranked, err := orchestrator.ComputeProvisional(map[string]LivePrice{
+		"BBB": {Date: janDay(15), Price: 50},
+	})
+	require.NoError(t, err)
+	require.Len(t, ranked, 2)
+	assert.Equal(t, "AAA", ranked[0].Symbol)
+	assert.Equal(t, janDay(15), ranked[1].Indicators.Date)
+
+	// Nothing provisional is stored
+	after, err := db.NewIndicatorRepository(database).GetTopN("2024-01-12", db.DefaultStrategy, 10)
+	require.NoError(t, err)
+	assert.Equal(t, stored, after)
+	provisional, err := db.NewIndicatorRepository(database).GetTopN("2024-01-15", db.DefaultStrategy, 10)
+	require.NoError(t, err)
+	assert.Empty(t, provisional)
+}
+
+// seedOrchestrator stores AAA rising 1% a day and BBB rising 2% a day until
+// January 10 and falling 5% a day after, for the business days from January 2
+// to January 19, 2024.
+func seedOrchestrator(t *testing.T) *Orchestrator {
+	database, err := db.New(db.Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	require.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+	require.NoError(t, database.Migrate())
+
+	symbolRepo := db.NewSymbolRepository(database)
+	priceRepo := db.NewPriceRepository(database)
+	for _, symbol := range []string{"AAA", "BBB"} {
+		require.NoError(t, symbolRepo.Create(&db.Symbol{Symbol: symbol, Name: symbol, AssetType: "ETF", Active: true}))
+
+		close := 100.0
+		for d := janDay(2); !d.After(janDay(19)); d = d.AddDate(0, 0, 1) {
+			if !IsBusinessDay(d) {
+				continue
+			}
+			volume := int64(1_000_000)
+			adjClose := close
+			require.NoError(t, priceRepo.Create(&db.Price{
+				Symbol: symbol, Date: d.Format("2006-01-02"),
+				Open: close, High: close, Low: close, Close: close, AdjClose: &adjClose, Volume: &volume,
+			}))
+			switch {
+			case symbol == "AAA":
+				close *= 1.01
+			case d.Before(janDay(10)):
+				close *= 1.02
+			default:
+				close *= 0.95
+			}
+		}
+	}
+
+	lookbacks := map[string]int{"r1m": 1, "r3m": 2, "r6m": 3, "r12m": 4}
+	volWindows := map[string]int{"short": 3, "long": 4}
+	return NewOrchestrator(database, lookbacks, volWindows, ScoringConfig{PenaltyLambda: 0, BreadthMinPositive: 0, BreadthTotal: 4})
+}
+
+func TestOrchestrator_ComputeAllIndicatorsAsOf(t *testing.T) {
+	orchestrator := seedOrchestrator(t)
+	indicatorRepo := db.NewIndicatorRepository(orchestrator.database)
+
+	// Only prices up to January 10 are used
+	_, err := orchestrator.ComputeAllIndicators(janDay(10))
+	require.NoError(t, err)
+
+	top, err := indicatorRepo.GetTopN("2024-01-10", db.DefaultStrategy, 10)
+	require.NoError(t, err)
+	require.Len(t, top, 2)
+	assert.Equal(t, "BBB", top[0].Symbol)
+
+	later, err := indicatorRepo.GetTopN("2024-01-19", db.DefaultStrategy, 10)
+	require.NoError(t, err)
+	assert.Empty(t, later)
+}
+
+func TestOrchestrator_Backfill(t *testing.T) {
+	orchestrator := seedOrchestrator(t)
+	indicatorRepo := db.NewIndicatorRepository(orchestrator.database)
+
+	// A stale row for a day in the range is removed: CCC is no longer active
+	require.NoError(t, db.NewSymbolRepository(orchestrator.database).Create(&db.Symbol{Symbol: "CCC", Name: "CCC", AssetType: "ETF"}))
+	require.NoError(t, db.NewPriceRepository(orchestrator.database).Create(&db.Price{Symbol: "CCC", Date: "2024-01-16", Open: 1, High: 1, Low: 1, Close: 1}))
+	rank := 1
+	require.NoError(t, indicatorRepo.UpsertBatch([]db.Indicator{{Symbol: "CCC", Date: "2024-01-16", Rank: &rank}}))
+
+	var days []BackfillDay
+	stored, err := orchestrator.Backfill(janDay(1), janDay(19), func(day BackfillDay) {
+		days = append(days, day)
+	})
+	require.NoError(t, err)
+
+	// January 1 and 15 are holidays; the first days lack enough history
+	require.Len(t, days, 13)
+	assert.Equal(t, janDay(2), days[0].Date)
+	assert.Error(t, days[0].Err)
+	assert.Equal(t, 9, stored)
+	assert.Equal(t, janDay(19), days[len(days)-1].Date)
+	assert.Equal(t, 2, days[len(days)-1].Symbols)
+
+	// Each day is ranked with the prices known then: BBB leads until it turns
+	leaders := make(map[string]string)
+	for _, date := range []string{"2024-01-09", "2024-01-10", "2024-01-16", "2024-01-19"} {
+		top, err := indicatorRepo.GetTopN(date, db.DefaultStrategy, 10)
+		require.NoError(t, err)
+		require.Len(t, top, 2, date)
+		leaders[date] = top[0].Symbol
+	}
+	assert.Equal(t, map[string]string{
+		"2024-01-09": "BBB",
+		"2024-01-10": "BBB",
+		"2024-01-16": "AAA",
+		"2024-01-19": "AAA",
+	}, leaders)
+
+	_, err = orchestrator.Backfill(janDay(19), janDay(2), nil)
+	assert.Error(t, err)
+}
+
+func TestOrchestrator_BelowHurdle(t *testing.T) {
+	orchestrator := seedOrchestrator(t)
+	orchestrator.SetStrategy(StrategyDualMomentum, "")
+	orchestrator.scoring.RiskFreeRate = 10 // Nothing returns 1000%
+
+	// The day is stored out of the market: indicators without ranks
+	_, err := orchestrator.ComputeAllIndicators(janDay(19))
+	require.NoError(t, err)
+
+	var unranked int
+	require.NoError(t, orchestrator.database.QueryRow(
+		"SELECT COUNT(*) FROM indicators WHERE date = '2024-01-19' AND strategy = ? AND rank IS NULL", StrategyDualMomentum,
+	).Scan(&unranked))
+	assert.Equal(t, 2, unranked)
+
+	top, err := db.NewIndicatorRepository(orchestrator.database).GetTopN("2024-01-19", StrategyDualMomentum, 10)
+	require.NoError(t, err)
+	assert.Empty(t, top)
+
+	// Backfilled days too
+	var days []BackfillDay
+	stored, err := orchestrator.Backfill(janDay(16), janDay(19), func(day BackfillDay) {
+		days = append(days, day)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 4, stored)
+	for _, day := range days {
+		assert.NoError(t, day.Err)
+		assert.Zero(t, day.Symbols)
+	}
+}
@@ -0,0 +1,338 @@
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/cajundata/momorot/internal/db"
+)
+
+// Data quality checks run over a stored price series.
+const (
+	CheckMissingDays = "missing_days" // NYSE business days without a bar
+	CheckBadPrice    = "bad_price"    // Zero or negative open, high, low or close
+	CheckHighLow     = "high_low"     // High below low
+	CheckOHLCRange   = "ohlc_range"   // Open or close outside the high/low range
+	CheckSplitJump   = "split_jump"   // Close-to-close move matching a common split ratio
+	CheckJump        = "jump"         // Extreme close-to-close move
+	CheckVolumeGap   = "volume_gap"   // Days without volume in a series that has volume
+)
+
+// Finding severities.
+const (
+	SeverityWarn  = "WARN"
+	SeverityError = "ERROR"
+)
+
+// JumpThreshold is the absolute single-day return above which a move is
+// reported as extreme.
+const JumpThreshold = 0.4
+
+// splitRatios are the split factors a single-day jump is compared against, and
+// splitTolerance is how close the move has to be to one of them.
+var splitRatios = []float64{2, 3, 4, 5, 10}
+
+const splitTolerance = 0.03
+
+// QualityFinding is a problem found in a price series. Date is the affected
+// bar, or the first missing day of a gap.
+type QualityFinding struct {
+	Date     time.Time
+	Check    string
+	Severity string
+	Detail   string
+}
+
+// CheckPrices validates a price series sorted by date ascending. Gaps are only
+// reported for years the calendar has holidays for. Jumps are measured on
+// adjusted closes when both bars have one; raw-close jumps across a recorded
+// split are expected and not reported.
+func CheckPrices(prices []PriceBar, actions []CorporateAction, cal *Calendar) []QualityFinding {
+	var findings []QualityFinding
+
+	hasVolume := false
+	for _, p := range prices {
+		if p.Volume > 0 {
+			hasVolume = true
+			break
+		}
+	}
+
+	var volumeGap []time.Time
+	flushVolumeGap := func() {
+		if len(volumeGap) > 0 {
+			findings = append(findings, QualityFinding{
+				Date:     volumeGap[0],
+				Check:    CheckVolumeGap,
+				Severity: SeverityWarn,
+				Detail:   describeDays(volumeGap, "with no volume"),
+			})
+			volumeGap = nil
+		}
+	}
+
+	for i, p := range prices {
+		if i > 0 {
+			if missing := missingBusinessDays(prices[i-1].Date, p.Date, cal); len(missing) > 0 {
+				findings = append(findings, QualityFinding{
+					Date:     missing[0],
+					Check:    CheckMissingDays,
+					Severity: SeverityWarn,
+					Detail:   describeDays(missing, "missing"),
+				})
+			}
+		}
+
+		valid := true
+		if bad := nonPositiveFields(p); len(bad) > 0 {
+			valid = false
+			findings = append(findings, QualityFinding{
+				Date:     p.Date,
+				Check:    CheckBadPrice,
+				Severity: SeverityError,
+				Detail:   "non-positive " + strings.Join(bad, ", "),
+			})
+		} else if p.High < p.Low {
+			valid = false
+			findings = append(findings, QualityFinding{
+				Date:     p.Date,
+				Check:    CheckHighLow,
+				Severity: SeverityError,
+				Detail:   fmt.Sprintf("high %.2f below low %.2f", p.High, p.Low),
+			})
+		} else if outside := outsideRange(p); len(outside) > 0 {
+			findings = append(findings, QualityFinding{
+				Date:     p.Date,
+				Check:    CheckOHLCRange,
+				Severity: SeverityWarn,
+				Detail:   fmt.Sprintf("%s outside low/high %.2f-%.2f", strings.Join(outside, ", "), p.Low, p.High),
+			})
+		}
+
+		if valid && i > 0 && prices[i-1].Close > 0 {
+			if f, ok := checkJump(prices[i-1], p, actions); ok {
+				findings = append(findings, f)
+			}
+		}
+
+		if hasVolume && p.Volume <= 0 {
+			volumeGap = append(volumeGap, p.Date)
+		} else {
+			flushVolumeGap()
+		}
+	}
+	flushVolumeGap()
+
+	return findings
+}
+
+// Gap is a run of consecutive business days missing from a price series.
+type Gap struct {
+	From time.Time // First missing day
+	To   time.Time // Last missing day
+	Days int       // Number of missing business days
+}
+
+// FindGaps returns the gaps between consecutive dates (sorted ascending) for
+// the years the calendar covers.
+func FindGaps(dates []time.Time, cal *Calendar) []Gap {
+	var gaps []Gap
+	for i := 1; i < len(dates); i++ {
+		if missing := missingBusinessDays(dates[i-1], dates[i], cal); len(missing) > 0 {
+			gaps = append(gaps, Gap{From: missing[0], To: missing[len(missing)-1], Days: len(missing)})
+		}
+	}
+	return gaps
+}
+
+// missingBusinessDays returns the business days strictly between prev and next
+// that the calendar covers.
+func missingBusinessDays(prev, next time.Time, cal *Calendar) []time.Time {
+	var missing []time.Time
+	for day := prev.AddDate(0, 0, 1); day.Before(next); day = day.AddDate(0, 0, 1) {
+		if cal.Covers(day) && cal.IsBusinessDay(day) {
+			missing = append(missing, day)
+		}
+	}
+	return missing
+}
+
+// nonPositiveFields names the price fields of p that are zero or negative.
+func nonPositiveFields(p PriceBar) []string {
+	var bad []string
+	for _, field := range []struct {
+		name  string
+		value float64
+	}{{"open", p.Open}, {"high", p.High}, {"low", p.Low}, {"close", p.Close}} {
+		if field.value <= 0 {
+			bad = append(bad, fmt.Sprintf("%s %.2f", field.name, field.value))
+		}
+	}
+	return bad
+}
+
+// outsideRange names the open and close of p when they fall outside its
+// high/low range.
+func outsideRange(p PriceBar) []string {
+	const epsilon = 1e-6
+
+	var outside []string
+	if p.Open < p.Low-epsilon || p.Open > p.High+epsilon {
+		outside = append(outside, fmt.Sprintf("open %.2f", p.Open))
+	}
+	if p.Close < p.Low-epsilon || p.Close > p.High+epsilon {
+		outside = append(outside, fmt.Sprintf("close %.2f", p.Close))
+	}
+	return outside
+}
+
+// checkJump compares the closes of two consecutive bars. A move close to a
+// split ratio is an error, since it usually means a split is missing from the
+// corporate actions; any other move beyond JumpThreshold is a warning.
+func checkJump(prev, cur PriceBar, actions []CorporateAction) (QualityFinding, bool) {
+	from, to := prev.Close, cur.Close
+	if prev.AdjClose > 0 && cur.AdjClose > 0 {
+		from, to = prev.AdjClose, cur.AdjClose
+	} else if splitBetween(prev.Date, cur.Date, actions) {
+		return QualityFinding{}, false
+	}
+
+	ratio := to / from
+	move := fmt.Sprintf("close moved %+.1f%% from %.2f to %.2f", (ratio-1)*100, from, to)
+
+	for _, factor := range splitRatios {
+		for _, candidate := range []float64{1 / factor, factor} {
+			if math.Abs(ratio/candidate-1) <= splitTolerance {
+				return QualityFinding{
+					Date:     cur.Date,
+					Check:    CheckSplitJump,
+					Severity: SeverityError,
+					Detail:   fmt.Sprintf("%s, looks like an unadjusted %s split", move, splitLabel(candidate)),
+				}, true
+			}
+		}
+	}
+
+	if math.Abs(ratio-1) > JumpThreshold {
+		return QualityFinding{
+			Date:     cur.Date,
+			Check:    CheckJump,
+			Severity: SeverityWarn,
+			Detail:   move,
+		}, true
+	}
+
+	return QualityFinding{}, false
+}
+
+// splitBetween reports whether a split takes effect after prev and on or before cur.
+func splitBetween(prev, cur time.Time, actions []CorporateAction) bool {
+	for _, a := range actions {
+		if a.SplitCoefficient > 0 && a.SplitCoefficient != 1 && a.Date.After(prev) && !a.Date.After(cur) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitLabel names the split that turns a close into ratio times itself,
+// e.g. 0.5 -> "2:1" and 2 -> "1:2" (reverse split).
+func splitLabel(ratio float64) string {
+	if ratio < 1 {
+		return fmt.Sprintf("%g:1", math.Round(1/ratio))
+	}
+	return fmt.Sprintf("1:%g", math.Round(ratio))
+}
+
+// describeDays summarizes consecutive days, e.g. "3 days missing (2024-01-02 to 2024-01-04)".
+func describeDays(days []time.Time, what string) string {
+	first := days[0].Format("2006-01-02")
+	if len(days) == 1 {
+		return fmt.Sprintf("1 day %s (%s)", what, first)
+	}
+	return fmt.Sprintf("%d days %s (%s to %s)", len(days), what, first, days[len(days)-1].Format("2006-01-02"))
+}
+
+// Validator runs the data quality checks over stored prices and records the
+// findings, replacing those from the previous pass.
+type Validator struct {
+	calendar    *Calendar
+	priceRepo   *db.PriceRepository
+	actionRepo  *db.CorporateActionRepository
+	findingRepo *db.QualityFindingRepository
+}
+
+// NewValidator creates a new data quality validator.
+func NewValidator(database *db.DB) *Validator {
+	return &Validator{
+		calendar:    NewCalendar(),
+		priceRepo:   db.NewPriceRepository(database),
+		actionRepo:  db.NewCorporateActionRepository(database),
+		findingRepo: db.NewQualityFindingRepository(database),
+	}
+}
+
+// ValidateSymbol checks every stored price of symbol and stores the findings
+// for the given run (0 when validating outside a run).
+func (v *Validator) ValidateSymbol(symbol string, runID int64) ([]QualityFinding, error) {
+	stored, err := v.priceRepo.GetRange(symbol, "0000-01-01", "9999-12-31")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prices for %s: %w", symbol, err)
+	}
+
+	prices := make([]PriceBar, 0, len(stored))
+	for _, p := range stored {
+		date, err := time.Parse("2006-01-02", p.Date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse date %s: %w", p.Date, err)
+		}
+		pb := PriceBar{Date: date, Open: p.Open, High: p.High, Low: p.Low, Close: p.Close}
+		if p.AdjClose != nil {
+			pb.AdjClose = *p.AdjClose
+		}
+		if p.Volume != nil {
+			pb.Volume = float64(*p.Volume)
+		}
+		prices = append(prices, pb)
+	}
+
+	storedActions, err := v.actionRepo.ListForSymbol(symbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get corporate actions for %s: %w", symbol, err)
+	}
+
+	actions := make([]CorporateAction, 0, len(storedActions))
+	for _, ca := range storedActions {
+		date, err := time.Parse("2006-01-02", ca.Date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse action date %s: %w", ca.Date, err)
+		}
+		actions = append(actions, CorporateAction{Date: date, Dividend: ca.Dividend, SplitCoefficient: ca.SplitCoefficient})
+	}
+
+	findings := CheckPrices(prices, actions, v.calendar)
+
+	var run *int64
+	if runID != 0 {
+		run = &runID
+	}
+	records := make([]db.QualityFinding, len(findings))
+	for i, f := range findings {
+		records[i] = db.QualityFinding{
+			Symbol:   symbol,
+			Date:     f.Date.Format("2006-01-02"),
+			Check:    f.Check,
+			Severity: f.Severity,
+			Detail:   f.Detail,
+			RunID:    run,
+		}
+	}
+
+	if err := v.findingRepo.ReplaceForSymbol(symbol, records); err != nil {
+		return nil, fmt.Errorf("failed to store quality findings for %s: %w", symbol, err)
+	}
+
+	return findings, nil
+}
@@ -0,0 +1,144 @@
+package analytics
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cajundata/momorot/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cleanBar is a consistent bar closing at close.
+func cleanBar(d int, close float64) PriceBar {
+	return PriceBar{Date: janDay(d), Open: close, High: close + 1, Low: close - 1, Close: close, Volume: 1000}
+}
+
+func TestCheckPrices_Clean(t *testing.T) {
+	// Jan 2-5 2024 (Tue-Fri), then Mon the 8th
+	prices := []PriceBar{cleanBar(2, 100), cleanBar(3, 101), cleanBar(4, 100), cleanBar(5, 102), cleanBar(8, 103)}
+
+	assert.Empty(t, CheckPrices(prices, nil, NewCalendar()))
+}
+
+func TestCheckPrices_MissingDays(t *testing.T) {
+	// Jan 15 2024 is MLK day, so only the 10th-12th are missing
+	prices := []PriceBar{cleanBar(9, 100), cleanBar(16, 101)}
+
+	findings := CheckPrices(prices, nil, NewCalendar())
+	require.Len(t, findings, 1)
+	assert.Equal(t, CheckMissingDays, findings[0].Check)
+	assert.Equal(t, janDay(10), findings[0].Date)
+	assert.Equal(t, "3 days missing (2024-01-10 to 2024-01-12)", findings[0].Detail)
+}
+
+func TestCheckPrices_MissingDaysOutsideCalendar(t *testing.T) {
+	prices := []PriceBar{cleanBar(2, 100), cleanBar(3, 101)}
+	prices[0].Date = prices[0].Date.AddDate(-10, 0, 0)
+	prices[1].Date = prices[1].Date.AddDate(-10, 0, 0).AddDate(0, 0, 14)
+
+	// No holiday list for 2014, so gaps there cannot be told apart from closures
+	assert.Empty(t, CheckPrices(prices, nil, NewCalendar()))
+}
+
+func TestCheckPrices_BarChecks(t *testing.T) {
+	prices := []PriceBar{
+		cleanBar(2, 100),
+		{Date: janDay(3), Open: 0, High: 101, Low: 99, Close: 100, Volume: 1000},
+		{Date: janDay(4), Open: 100, High: 95, Low: 99, Close: 98, Volume: 1000},
+		{Date: janDay(5), Open: 110, High: 105, Low: 99, Close: 103, Volume: 1000},
+	}
+
+	findings := CheckPrices(prices, nil, NewCalendar())
+	require.Len(t, findings, 3)
+	assert.Equal(t, CheckBadPrice, findings[0].Check)
+	assert.Equal(t, SeverityError, findings[0].Severity)
+	assert.Equal(t, "non-positive open 0.00", findings[0].Detail)
+	assert.Equal(t, CheckHighLow, findings[1].Check)
+	assert.Equal(t, CheckOHLCRange, findings[2].Check)
+	assert.Equal(t, SeverityWarn, findings[2].Severity)
+	assert.Contains(t, findings[2].Detail, "open 110.00")
+}
+
+func TestCheckPrices_Jumps(t *testing.T) {
+	prices := []PriceBar{cleanBar(2, 400), cleanBar(3, 404), cleanBar(4, 101), cleanBar(5, 160)}
+
+	findings := CheckPrices(prices, nil, NewCalendar())
+	require.Len(t, findings, 2)
+	assert.Equal(t, CheckSplitJump, findings[0].Check)
+	assert.Equal(t, janDay(4), findings[0].Date)
+	assert.Contains(t, findings[0].Detail, "unadjusted 4:1 split")
+	assert.Equal(t, CheckJump, findings[1].Check)
+	assert.Equal(t, SeverityWarn, findings[1].Severity)
+
+	// A recorded split explains the raw jump
+	actions := []CorporateAction{{Date: janDay(4), SplitCoefficient: 4}}
+	findings = CheckPrices(prices, actions, NewCalendar())
+	require.Len(t, findings, 1)
+	assert.Equal(t, CheckJump, findings[0].Check)
+
+	// Adjusted closes are used when present
+	for i, adj := range []float64{100, 101, 101, 102} {
+		prices[i].AdjClose = adj
+	}
+	assert.Empty(t, CheckPrices(prices, nil, NewCalendar()))
+}
+
+func TestCheckPrices_VolumeGaps(t *testing.T) {
+	prices := []PriceBar{cleanBar(2, 100), cleanBar(3, 100), cleanBar(4, 100), cleanBar(5, 100)}
+	prices[1].Volume = 0
+	prices[2].Volume = 0
+
+	findings := CheckPrices(prices, nil, NewCalendar())
+	require.Len(t, findings, 1)
+	assert.Equal(t, CheckVolumeGap, findings[0].Check)
+	assert.Equal(t, "2 days with no volume (2024-01-03 to 2024-01-04)", findings[0].Detail)
+
+	// Series without any volume (close-only imports) are not flagged
+	for i := range prices {
+		prices[i].Volume = 0
+	}
+	assert.Empty(t, CheckPrices(prices, nil, NewCalendar()))
+}
+
+func TestValidator_ValidateSymbol(t *testing.T) {
+	database, err := db.New(db.Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	require.NoError(t, err)
+	defer database.Close()
+	require.NoError(t, database.Migrate())
+
+	require.NoError(t, db.NewSymbolRepository(database).Create(&db.Symbol{Symbol: "AAPL", Name: "Apple", AssetType: "STOCK", Active: true}))
+
+	priceRepo := db.NewPriceRepository(database)
+	for _, d := range []int{2, 3, 5} {
+		require.NoError(t, priceRepo.Create(&db.Price{
+			Symbol: "AAPL", Date: janDay(d).Format("2006-01-02"),
+			Open: 100, High: 101, Low: 99, Close: 100,
+		}))
+	}
+
+	findings, err := NewValidator(database).ValidateSymbol("AAPL", 0)
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+
+	stored, err := db.NewQualityFindingRepository(database).ListForSymbol("AAPL")
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.Equal(t, "2024-01-04", stored[0].Date)
+	assert.Equal(t, CheckMissingDays, stored[0].Check)
+	assert.Nil(t, stored[0].RunID)
+}
+
+func TestFindGaps(t *testing.T) {
+	// Jan 15 2024 is MLK day and Jan 20-21 a weekend
+	dates := []time.Time{janDay(2), janDay(3), janDay(9), janDay(16), janDay(17), janDay(23)}
+
+	gaps := FindGaps(dates, NewCalendar())
+	require.Len(t, gaps, 3)
+	assert.Equal(t, Gap{From: janDay(4), To: janDay(8), Days: 3}, gaps[0])
+	assert.Equal(t, Gap{From: janDay(10), To: janDay(12), Days: 3}, gaps[1])
+	assert.Equal(t, Gap{From: janDay(18), To: janDay(22), Days: 3}, gaps[2])
+
+	assert.Empty(t, FindGaps(dates[:2], NewCalendar()))
+}
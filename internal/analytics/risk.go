@@ -0,0 +1,276 @@
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Names of the risk metrics stored in Indicators.Values.
+const (
+	RiskMaxDrawdown = "max_drawdown" // Largest peak-to-trough decline, as a positive fraction
+	RiskDownsideDev = "downside_dev" // Annualized deviation of daily returns below the risk-free rate
+	RiskSharpe      = "sharpe"       // Annualized excess return per unit of volatility
+	RiskSortino     = "sortino"      // Annualized excess return per unit of downside deviation
+	RiskUlcer       = "ulcer"        // Root mean square of the drawdowns from the running peak
+	RiskBeta        = "beta"         // Sensitivity of daily returns to the benchmark's
+)
+
+// tradingDaysPerYear annualizes daily statistics.
+const tradingDaysPerYear = 252
+
+// RiskConfig controls the risk metrics computed for every symbol.
+type RiskConfig struct {
+	Window       int     // Trading days the metrics are measured over; 0 disables them
+	RiskFreeRate float64 // Annual rate subtracted from returns in Sharpe and Sortino
+	Benchmark    string  // Symbol beta is measured against; empty skips beta
+}
+
+// riskWindow returns the last window+1 bars of prices sorted by date ascending.
+func riskWindow(prices []PriceBar, window int) ([]PriceBar, error) {
+	if window < 1 {
+		return nil, fmt.Errorf("invalid window %d", window)
+	}
+	if len(prices) < window+1 {
+		return nil, fmt.Errorf("insufficient data: need %d bars, have %d", window+1, len(prices))
+	}
+
+	sorted := make([]PriceBar, len(prices))
+	copy(sorted, prices)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+
+	return sorted[len(sorted)-window-1:], nil
+}
+
+// dailyReturns returns the simple daily returns between consecutive bars.
+func dailyReturns(bars []PriceBar) ([]float64, error) {
+	returns := make([]float64, 0, len(bars)-1)
+	for i := 1; i < len(bars); i++ {
+		if bars[i-1].AdjClose == 0 {
+			return nil, fmt.Errorf("zero price encountered, cannot calculate return")
+		}
+		returns = append(returns, bars[i].AdjClose/bars[i-1].AdjClose-1)
+	}
+	return returns, nil
+}
+
+// drawdowns returns each bar's decline from the running peak as a positive fraction.
+func drawdowns(bars []PriceBar) ([]float64, error) {
+	result := make([]float64, len(bars))
+	peak := 0.0
+	for i, bar := range bars {
+		if bar.AdjClose <= 0 {
+			return nil, fmt.Errorf("non-positive price encountered, cannot calculate drawdown")
+		}
+		peak = math.Max(peak, bar.AdjClose)
+		result[i] = 1 - bar.AdjClose/peak
+	}
+	return result, nil
+}
+
+// CalculateMaxDrawdown computes the largest peak-to-trough decline over the
+// window, e.g. 0.25 for a 25% drop.
+func CalculateMaxDrawdown(prices []PriceBar, window int) (float64, error) {
+	bars, err := riskWindow(prices, window)
+	if err != nil {
+		return 0, err
+	}
+
+	dd, err := drawdowns(bars)
+	if err != nil {
+		return 0, err
+	}
+
+	maxDD := 0.0
+	for _, d := range dd {
+		maxDD = math.Max(maxDD, d)
+	}
+	return maxDD, nil
+}
+
+// CalculateUlcerIndex computes the root mean square of the drawdowns from the
+// running peak over the window. Unlike max drawdown it grows with both the
+// depth and the length of declines.
+func CalculateUlcerIndex(prices []PriceBar, window int) (float64, error) {
+	bars, err := riskWindow(prices, window)
+	if err != nil {
+		return 0, err
+	}
+
+	dd, err := drawdowns(bars)
+	if err != nil {
+		return 0, err
+	}
+
+	sumSquares := 0.0
+	for _, d := range dd {
+		sumSquares += d * d
+	}
+	return math.Sqrt(sumSquares / float64(len(dd))), nil
+}
+
+// CalculateDownsideDeviation computes the annualized root mean square of daily
+// returns below the daily risk-free rate. Returns above it count as 0.
+func CalculateDownsideDeviation(prices []PriceBar, window int, riskFreeRate float64) (float64, error) {
+	bars, err := riskWindow(prices, window)
+	if err != nil {
+		return 0, err
+	}
+
+	returns, err := dailyReturns(bars)
+	if err != nil {
+		return 0, err
+	}
+
+	return downsideDeviation(returns, riskFreeRate/tradingDaysPerYear) * math.Sqrt(tradingDaysPerYear), nil
+}
+
+// downsideDeviation returns the daily root mean square shortfall below target.
+func downsideDeviation(returns []float64, target float64) float64 {
+	sumSquares := 0.0
+	for _, r := range returns {
+		if shortfall := math.Min(r-target, 0); shortfall < 0 {
+			sumSquares += shortfall * shortfall
+		}
+	}
+	return math.Sqrt(sumSquares / float64(len(returns)))
+}
+
+// CalculateSharpe computes the annualized Sharpe ratio of daily returns over
+// the window: mean excess return over its standard deviation, times sqrt(252).
+// A window without any variation has a ratio of 0.
+func CalculateSharpe(prices []PriceBar, window int, riskFreeRate float64) (float64, error) {
+	bars, err := riskWindow(prices, window)
+	if err != nil {
+		return 0, err
+	}
+
+	returns, err := dailyReturns(bars)
+	if err != nil {
+		return 0, err
+	}
+
+	mean, stdDev := meanStdDev(returns)
+	if stdDev == 0 {
+		return 0, nil
+	}
+	return (mean - riskFreeRate/tradingDaysPerYear) / stdDev * math.Sqrt(tradingDaysPerYear), nil
+}
+
+// CalculateSortino computes the annualized Sortino ratio of daily returns over
+// the window: mean excess return over the downside deviation, times sqrt(252).
+// A window without any shortfall has a ratio of 0.
+func CalculateSortino(prices []PriceBar, window int, riskFreeRate float64) (float64, error) {
+	bars, err := riskWindow(prices, window)
+	if err != nil {
+		return 0, err
+	}
+
+	returns, err := dailyReturns(bars)
+	if err != nil {
+		return 0, err
+	}
+
+	target := riskFreeRate / tradingDaysPerYear
+	dd := downsideDeviation(returns, target)
+	if dd == 0 {
+		return 0, nil
+	}
+	mean, _ := meanStdDev(returns)
+	return (mean - target) / dd * math.Sqrt(tradingDaysPerYear), nil
+}
+
+// CalculateBeta computes the beta of daily returns against the benchmark's over
+// the window. Only days where both series have consecutive bars are used.
+func CalculateBeta(prices, benchmark []PriceBar, window int) (float64, error) {
+	bars, err := riskWindow(prices, window)
+	if err != nil {
+		return 0, err
+	}
+
+	benchmarkClose := make(map[time.Time]float64, len(benchmark))
+	for _, bar := range benchmark {
+		benchmarkClose[bar.Date] = bar.AdjClose
+	}
+
+	var returns, benchmarkReturns []float64
+	for i := 1; i < len(bars); i++ {
+		prev, okPrev := benchmarkClose[bars[i-1].Date]
+		cur, okCur := benchmarkClose[bars[i].Date]
+		if !okPrev || !okCur || prev == 0 || bars[i-1].AdjClose == 0 {
+			continue
+		}
+		returns = append(returns, bars[i].AdjClose/bars[i-1].AdjClose-1)
+		benchmarkReturns = append(benchmarkReturns, cur/prev-1)
+	}
+
+	if len(returns) < 2 {
+		return 0, fmt.Errorf("insufficient overlap with benchmark: %d common days", len(returns))
+	}
+
+	mean, _ := meanStdDev(returns)
+	benchmarkMean, benchmarkStdDev := meanStdDev(benchmarkReturns)
+	if benchmarkStdDev == 0 {
+		return 0, fmt.Errorf("benchmark returns have no variance")
+	}
+
+	covariance := 0.0
+	for i := range returns {
+		covariance += (returns[i] - mean) * (benchmarkReturns[i] - benchmarkMean)
+	}
+	covariance /= float64(len(returns))
+
+	return covariance / (benchmarkStdDev * benchmarkStdDev), nil
+}
+
+// meanStdDev returns the mean and population standard deviation of values.
+func meanStdDev(values []float64) (mean, stdDev float64) {
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+
+	variance := 0.0
+	for _, v := range values {
+		diff := v - mean
+		variance += diff * diff
+	}
+	variance /= float64(len(values))
+
+	return mean, math.Sqrt(variance)
+}
+
+// computeRisk returns the risk metrics of prices sorted by date ascending.
+// Metrics the history is too short for are left out.
+func computeRisk(prices, benchmark []PriceBar, cfg RiskConfig) map[string]float64 {
+	if cfg.Window < 1 {
+		return nil
+	}
+
+	metrics := make(map[string]float64)
+	set := func(name string, value float64, err error) {
+		if err == nil {
+			metrics[name] = value
+		}
+	}
+
+	value, err := CalculateMaxDrawdown(prices, cfg.Window)
+	set(RiskMaxDrawdown, value, err)
+	value, err = CalculateDownsideDeviation(prices, cfg.Window, cfg.RiskFreeRate)
+	set(RiskDownsideDev, value, err)
+	value, err = CalculateSharpe(prices, cfg.Window, cfg.RiskFreeRate)
+	set(RiskSharpe, value, err)
+	value, err = CalculateSortino(prices, cfg.Window, cfg.RiskFreeRate)
+	set(RiskSortino, value, err)
+	value, err = CalculateUlcerIndex(prices, cfg.Window)
+	set(RiskUlcer, value, err)
+	if len(benchmark) > 0 {
+		value, err = CalculateBeta(prices, benchmark, cfg.Window)
+		set(RiskBeta, value, err)
+	}
+
+	return metrics
+}
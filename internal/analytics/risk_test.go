@@ -0,0 +1,149 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// closesToBars returns daily bars with the given adjusted closes.
+func closesToBars(closes ...float64) []PriceBar {
+	bars := make([]PriceBar, len(closes))
+	for i, c := range closes {
+		bars[i] = PriceBar{Date: janDay(1).AddDate(0, 0, i), Close: c, AdjClose: c, Volume: 1000}
+	}
+	return bars
+}
+
+func TestCalculateMaxDrawdown(t *testing.T) {
+	prices := closesToBars(100, 120, 90, 110, 60, 80)
+
+	dd, err := CalculateMaxDrawdown(prices, 5)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, dd, 1e-9) // 120 to 60
+
+	// Only the last 3 bars: 110 to 60
+	dd, err = CalculateMaxDrawdown(prices, 2)
+	require.NoError(t, err)
+	assert.InDelta(t, 50.0/110.0, dd, 1e-9)
+
+	dd, err = CalculateMaxDrawdown(risingPrices(10), 9)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, dd)
+
+	_, err = CalculateMaxDrawdown(prices, 6)
+	assert.Error(t, err)
+}
+
+func TestCalculateUlcerIndex(t *testing.T) {
+	// Drawdowns 0, 0, 0.5, 0.25
+	ulcer, err := CalculateUlcerIndex(closesToBars(50, 100, 50, 75), 3)
+	require.NoError(t, err)
+	assert.InDelta(t, math.Sqrt((0.25+0.0625)/4), ulcer, 1e-9)
+}
+
+func TestCalculateDownsideDeviationAndRatios(t *testing.T) {
+	// Daily returns +10%, -10%, +10%, -10%
+	prices := closesToBars(100, 110, 99, 108.9, 98.01)
+
+	dd, err := CalculateDownsideDeviation(prices, 4, 0)
+	require.NoError(t, err)
+	assert.InDelta(t, math.Sqrt(0.02/4)*math.Sqrt(252), dd, 1e-9)
+
+	// Zero mean return
+	sharpe, err := CalculateSharpe(prices, 4, 0)
+	require.NoError(t, err)
+	assert.InDelta(t, 0, sharpe, 1e-9)
+
+	sortino, err := CalculateSortino(prices, 4, 0)
+	require.NoError(t, err)
+	assert.InDelta(t, 0, sortino, 1e-9)
+
+	// A positive risk-free rate makes both negative
+	sharpe, err = CalculateSharpe(prices, 4, 0.05)
+	require.NoError(t, err)
+	assert.Less(t, sharpe, 0.0)
+
+	// Steady gains have no downside and no variation
+	sortino, err = CalculateSortino(closesToBars(100, 110, 121), 2, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, sortino)
+	sharpe, err = CalculateSharpe(closesToBars(100, 110, 121), 2, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, sharpe)
+}
+
+func TestCalculateBeta(t *testing.T) {
+	benchmark := closesToBars(100, 102, 99, 104, 103)
+
+	// Twice the benchmark's daily moves
+	prices := make([]PriceBar, len(benchmark))
+	prices[0] = benchmark[0]
+	for i := 1; i < len(benchmark); i++ {
+		move := benchmark[i].AdjClose/benchmark[i-1].AdjClose - 1
+		prices[i] = benchmark[i]
+		prices[i].AdjClose = prices[i-1].AdjClose * (1 + 2*move)
+	}
+
+	beta, err := CalculateBeta(prices, benchmark, 4)
+	require.NoError(t, err)
+	assert.InDelta(t, 2.0, beta, 1e-9)
+
+	beta, err = CalculateBeta(benchmark, benchmark, 4)
+	require.NoError(t, err)
+	assert.InDelta(t, 1.0, beta, 1e-9)
+
+	// Days the benchmark has no bars for are skipped
+	_, err = CalculateBeta(prices, benchmark[:2], 4)
+	assert.Error(t, err)
+}
+
+func TestComputeIndicators_Risk(t *testing.T) {
+	calc := NewIndicatorCalculator(
+		map[string]int{"r1m": 2, "r3m": 4, "r6m": 6, "r12m": 8},
+		map[string]int{"short": 3, "long": 5},
+	)
+
+	ind, err := calc.ComputeIndicators("SPY", risingPrices(20))
+	require.NoError(t, err)
+	assert.Empty(t, ind.Values)
+
+	calc.SetRisk(RiskConfig{Window: 10, Benchmark: "SPY"})
+	ind, err = calc.ComputeIndicators("SPY", risingPrices(20))
+	require.NoError(t, err)
+	for _, name := range []string{RiskMaxDrawdown, RiskDownsideDev, RiskSharpe, RiskSortino, RiskUlcer} {
+		assert.Contains(t, ind.Values, name)
+	}
+	assert.NotContains(t, ind.Values, RiskBeta) // No benchmark prices
+
+	calc.SetBenchmark(risingPrices(20))
+	ind, err = calc.ComputeIndicators("SPY", risingPrices(20))
+	require.NoError(t, err)
+	assert.InDelta(t, 1.0, ind.Values[RiskBeta], 1e-9)
+
+	// Too little history leaves the metrics out
+	calc.SetRisk(RiskConfig{Window: 30})
+	ind, err = calc.ComputeIndicators("SPY", risingPrices(20))
+	require.NoError(t, err)
+	assert.Empty(t, ind.Values)
+}
+
+func TestScorer_Penalty(t *testing.T) {
+	// Same momentum; AAA is more volatile, BBB had the deeper drawdown
+	aaa := &Indicators{Symbol: "AAA", R1M: 0.1, R3M: 0.1, R6M: 0.1, R12M: 0.1, Vol6M: 0.4, Values: map[string]float64{RiskMaxDrawdown: 0.1}}
+	bbb := &Indicators{Symbol: "BBB", R1M: 0.1, R3M: 0.1, R6M: 0.1, R12M: 0.1, Vol6M: 0.2, Values: map[string]float64{RiskMaxDrawdown: 0.3}}
+	ccc := &Indicators{Symbol: "CCC", R1M: 0.1, R3M: 0.1, R6M: 0.1, R12M: 0.1, Vol6M: 0.3}
+
+	ranked, err := NewScorer(ScoringConfig{PenaltyLambda: 0.5}).ScoreAndRank([]*Indicators{aaa, bbb, ccc})
+	require.NoError(t, err)
+	require.Len(t, ranked, 3)
+	assert.Equal(t, "BBB", ranked[0].Symbol)
+
+	// CCC has no drawdown value and is not ranked
+	ranked, err = NewScorer(ScoringConfig{PenaltyLambda: 0.5, Penalty: RiskMaxDrawdown}).ScoreAndRank([]*Indicators{aaa, bbb, ccc})
+	require.NoError(t, err)
+	require.Len(t, ranked, 2)
+	assert.Equal(t, "AAA", ranked[0].Symbol)
+}
@@ -8,12 +8,54 @@ import (
 
 // ScoringConfig contains parameters for momentum scoring.
 type ScoringConfig struct {
-	PenaltyLambda      float64 // Volatility penalty factor (default: 0.35)
-	MinADV             float64 // Minimum average dollar volume threshold
-	BreadthMinPositive int     // Minimum number of positive lookbacks required
-	BreadthTotal       int     // Total number of lookbacks to check
+	PenaltyLambda      float64  // Volatility penalty factor (default: 0.35)
+	MinADV             float64  // Minimum average dollar volume threshold
+	BreadthMinPositive int      // Minimum number of positive lookbacks required
+	BreadthTotal       int      // Total number of lookbacks to check
+	ExtraHorizons      []string // Configured returns blended in alongside R1M-R12M
+	Momentum           string   // MomentumBlend (default), MomentumClassic or MomentumBlendClassic
+	Penalty            string   // Risk measure scaled by PenaltyLambda: vol6m (default), vol3m or a risk metric
+
+	// Weights of the scored horizons by name; horizons not listed weigh 1,
+	// so nil averages them equally
+	Weights map[string]float64
+
+	// ZScoreHorizons z-scores each horizon and the penalty term across the
+	// universe before blending, so no horizon dominates by its spread alone
+	ZScoreHorizons bool
+
+	Strategy     string  // Registered strategy name; empty means StrategyMomentum
+	CashProxy    string  // T-bill proxy symbol the dual momentum filter compares against
+	RiskFreeRate float64 // Dual momentum hurdle when the cash proxy is not ranked
 }
 
+// Penalty terms besides the risk metrics (RiskMaxDrawdown, RiskDownsideDev,
+// RiskUlcer and RiskBeta) selectable with ScoringConfig.Penalty.
+const (
+	PenaltyVol6M = "vol6m"
+	PenaltyVol3M = "vol3m"
+)
+
+// Momentum measures selectable with ScoringConfig.Momentum.
+const (
+	MomentumBlend        = "blend"      // Average of R1M, R3M, R6M and R12M
+	MomentumClassic      = "12-1"       // 12-1 momentum alone
+	MomentumBlendClassic = "blend+12-1" // 12-1 momentum averaged in with the blend
+)
+
+// Return horizons that can be blended into the score. Other names refer to
+// configured indicators in Indicators.Values.
+const (
+	HorizonR1M   = "r1m"
+	HorizonR3M   = "r3m"
+	HorizonR6M   = "r6m"
+	HorizonR12M  = "r12m"
+	HorizonR12M1 = "r12_1" // 12-month return skipping the most recent month, stored in Values
+)
+
+// BlendHorizons are the returns averaged by the default momentum blend.
+var BlendHorizons = []string{HorizonR1M, HorizonR3M, HorizonR6M, HorizonR12M}
+
 // Scorer computes composite momentum scores and rankings.
 type Scorer struct {
 	config ScoringConfig
@@ -29,16 +71,159 @@ func NewScorer(config ScoringConfig) *Scorer {
 // ComputeScore calculates the composite momentum score for a symbol.
 // Formula: score = normalized_momentum - λ·volatility
 // Where normalized_momentum is the z-score normalized average of multi-horizon returns.
-func ComputeScore(indicators *Indicators, penaltyLambda float64) float64 {
-	// Calculate average return across all horizons
-	avgReturn := (indicators.R1M + indicators.R3M + indicators.R6M + indicators.R12M) / 4.0
+// horizons names the returns averaged; none means the BlendHorizons.
+// The Scorer also applies the configured horizon weights and penalty term.
+func ComputeScore(indicators *Indicators, penaltyLambda float64, horizons ...string) float64 {
+	if len(horizons) == 0 {
+		horizons = BlendHorizons
+	}
 
 	// Apply volatility penalty (using 6M volatility as primary metric)
-	score := avgReturn - (penaltyLambda * indicators.Vol6M)
+	score := averageReturn(indicators, horizons, nil) - (penaltyLambda * indicators.Vol6M)
 
 	return score
 }
 
+// averageReturn returns the weighted average of the named returns. Horizons
+// without a weight weigh 1; if all weights are 0 the average is 0.
+func averageReturn(indicators *Indicators, horizons []string, weights map[string]float64) float64 {
+	returns := horizonReturns(indicators, horizons)
+	avgReturn, totalWeight := 0.0, 0.0
+	for i, r := range returns {
+		weight := horizonWeight(weights, horizons[i])
+		avgReturn += weight * r
+		totalWeight += weight
+	}
+	if totalWeight == 0 {
+		return 0
+	}
+	return avgReturn / totalWeight
+}
+
+// horizonWeight returns the weight of a horizon, 1 unless weights lists it.
+func horizonWeight(weights map[string]float64, name string) float64 {
+	if weight, ok := weights[name]; ok {
+		return weight
+	}
+	return 1
+}
+
+// PenaltyValue returns the value of a penalty term for a symbol, and false
+// when the symbol has no value for it (e.g. too little history for a risk metric).
+func PenaltyValue(indicators *Indicators, term string) (float64, bool) {
+	switch term {
+	case "", PenaltyVol6M:
+		return indicators.Vol6M, true
+	case PenaltyVol3M:
+		return indicators.Vol3M, true
+	default:
+		value, ok := indicators.Values[term]
+		return value, ok
+	}
+}
+
+// horizonReturns returns the named returns. A configured return the symbol has
+// no value for counts as 0.
+func horizonReturns(indicators *Indicators, horizons []string) []float64 {
+	returns := make([]float64, len(horizons))
+	for i, name := range horizons {
+		switch name {
+		case HorizonR1M:
+			returns[i] = indicators.R1M
+		case HorizonR3M:
+			returns[i] = indicators.R3M
+		case HorizonR6M:
+			returns[i] = indicators.R6M
+		case HorizonR12M:
+			returns[i] = indicators.R12M
+		default:
+			returns[i] = indicators.Values[name]
+		}
+	}
+	return returns
+}
+
+// scoreHorizons returns the returns averaged into the score for the
+// configured momentum measure, followed by the scored configured indicators.
+func (s *Scorer) scoreHorizons() []string {
+	var horizons []string
+	switch s.config.Momentum {
+	case MomentumClassic:
+		horizons = append(horizons, HorizonR12M1)
+	case MomentumBlendClassic:
+		horizons = append(append(horizons, BlendHorizons...), HorizonR12M1)
+	default:
+		horizons = append(horizons, BlendHorizons...)
+	}
+	return append(horizons, s.config.ExtraHorizons...)
+}
+
+// breadthHorizons returns the returns checked by the breadth filter: the blend
+// horizons, 12-1 momentum when it is scored and the scored configured indicators.
+func (s *Scorer) breadthHorizons() []string {
+	horizons := append([]string{}, BlendHorizons...)
+	if s.config.Momentum == MomentumClassic || s.config.Momentum == MomentumBlendClassic {
+		horizons = append(horizons, HorizonR12M1)
+	}
+	return append(horizons, s.config.ExtraHorizons...)
+}
+
+// rawScores returns the blended momentum less the penalty for each symbol,
+// before the final normalization.
+func (s *Scorer) rawScores(filtered []*Indicators) ([]float64, error) {
+	horizons := s.scoreHorizons()
+	scores := make([]float64, len(filtered))
+	if !s.config.ZScoreHorizons {
+		for i, ind := range filtered {
+			penalty, _ := PenaltyValue(ind, s.config.Penalty)
+			scores[i] = averageReturn(ind, horizons, s.config.Weights) - s.config.PenaltyLambda*penalty
+		}
+		return scores, nil
+	}
+
+	// Blend the z-scores of each horizon across the universe
+	totalWeight := 0.0
+	for _, name := range horizons {
+		weight := horizonWeight(s.config.Weights, name)
+		if weight == 0 {
+			continue
+		}
+
+		values := make([]float64, len(filtered))
+		for i, ind := range filtered {
+			values[i] = horizonReturns(ind, []string{name})[0]
+		}
+		normalized, err := ZScoreNormalize(values)
+		if err != nil {
+			return nil, fmt.Errorf("failed to normalize %s: %w", name, err)
+		}
+		for i, z := range normalized {
+			scores[i] += weight * z
+		}
+		totalWeight += weight
+	}
+	if totalWeight > 0 {
+		for i := range scores {
+			scores[i] /= totalWeight
+		}
+	}
+
+	// The penalty term in the same units
+	penalties := make([]float64, len(filtered))
+	for i, ind := range filtered {
+		penalties[i], _ = PenaltyValue(ind, s.config.Penalty)
+	}
+	normalized, err := ZScoreNormalize(penalties)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize penalty: %w", err)
+	}
+	for i, z := range normalized {
+		scores[i] -= s.config.PenaltyLambda * z
+	}
+
+	return scores, nil
+}
+
 // ZScoreNormalize normalizes a slice of values using z-score normalization.
 // Returns the normalized values: (x - mean) / stddev
 func ZScoreNormalize(values []float64) ([]float64, error) {
@@ -85,19 +270,47 @@ func ZScoreNormalize(values []float64) ([]float64, error) {
 // ScoreAndRank computes scores and ranks for all symbols in the universe.
 // Returns ranked symbols with deterministic tie-breaking.
 func (s *Scorer) ScoreAndRank(indicatorsList []*Indicators) ([]*SymbolScore, error) {
+	filtered, err := s.filter(indicatorsList)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate raw scores for all symbols
+	scores, err := s.rawScores(filtered)
+	if err != nil {
+		return nil, err
+	}
+
+	return rankScores(StrategyMomentum, filtered, scores)
+}
+
+// Name returns the name the momentum blend is registered under.
+func (s *Scorer) Name() string {
+	return StrategyMomentum
+}
+
+// filter returns the symbols that pass the breadth and liquidity requirements
+// and have a value for the penalty term.
+func (s *Scorer) filter(indicatorsList []*Indicators) ([]*Indicators, error) {
 	if len(indicatorsList) == 0 {
 		return nil, fmt.Errorf("no indicators provided")
 	}
 
 	// Filter by breadth and liquidity requirements
+	breadth := s.breadthHorizons()
 	filtered := make([]*Indicators, 0, len(indicatorsList))
 	for _, ind := range indicatorsList {
 		// Check breadth filter
-		returns := []float64{ind.R1M, ind.R3M, ind.R6M, ind.R12M}
+		returns := horizonReturns(ind, breadth)
 		if !CheckBreadthFilter(returns, s.config.BreadthMinPositive) {
 			continue
 		}
 
+		// Check the penalty term can be applied
+		if _, ok := PenaltyValue(ind, s.config.Penalty); !ok {
+			continue
+		}
+
 		// Check minimum ADV
 		if ind.ADV < s.config.MinADV {
 			continue
@@ -110,12 +323,12 @@ func (s *Scorer) ScoreAndRank(indicatorsList []*Indicators) ([]*SymbolScore, err
 		return nil, fmt.Errorf("no symbols passed filtering criteria")
 	}
 
-	// Calculate raw scores for all symbols
-	scores := make([]float64, len(filtered))
-	for i, ind := range filtered {
-		scores[i] = ComputeScore(ind, s.config.PenaltyLambda)
-	}
+	return filtered, nil
+}
 
+// rankScores z-score normalizes raw scores across the universe and ranks the
+// symbols by them, recording the strategy that scored them.
+func rankScores(strategy string, filtered []*Indicators, scores []float64) ([]*SymbolScore, error) {
 	// Z-score normalize the scores across the universe
 	normalizedScores, err := ZScoreNormalize(scores)
 	if err != nil {
@@ -130,6 +343,7 @@ func (s *Scorer) ScoreAndRank(indicatorsList []*Indicators) ([]*SymbolScore, err
 			Score:      normalizedScores[i],
 			Volatility: ind.Vol6M,
 			Liquidity:  ind.ADV,
+			Strategy:   strategy,
 			Indicators: *ind,
 		}
 	}
@@ -139,13 +353,17 @@ func (s *Scorer) ScoreAndRank(indicatorsList []*Indicators) ([]*SymbolScore, err
 		return compareSymbolScores(symbolScores[i], symbolScores[j])
 	})
 
+	assignRanks(symbolScores)
+	return symbolScores, nil
+}
+
+// assignRanks numbers sorted symbol scores from 1 (best).
+func assignRanks(symbolScores []*SymbolScore) {
 	// Assign ranks (1-indexed, 1 = best)
 	for i, ss := range symbolScores {
 		symbolScores[i].Indicators.Rank = i + 1
 		symbolScores[i].Indicators.Score = ss.Score
 	}
-
-	return symbolScores, nil
 }
 
 // compareSymbolScores implements deterministic tie-breaking.
@@ -174,24 +392,12 @@ func compareSymbolScores(a, b *SymbolScore) bool {
 }
 
 // ApplyFilters filters symbols based on breadth and liquidity requirements.
+// It returns an empty list rather than an error when no symbol passes.
 func (s *Scorer) ApplyFilters(indicatorsList []*Indicators) []*Indicators {
-	filtered := make([]*Indicators, 0, len(indicatorsList))
-
-	for _, ind := range indicatorsList {
-		// Check breadth filter
-		returns := []float64{ind.R1M, ind.R3M, ind.R6M, ind.R12M}
-		if !CheckBreadthFilter(returns, s.config.BreadthMinPositive) {
-			continue
-		}
-
-		// Check minimum ADV
-		if ind.ADV < s.config.MinADV {
-			continue
-		}
-
-		filtered = append(filtered, ind)
+	filtered, err := s.filter(indicatorsList)
+	if err != nil {
+		return []*Indicators{}
 	}
-
 	return filtered
 }
 
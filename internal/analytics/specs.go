@@ -0,0 +1,71 @@
+package analytics
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Kinds of configured indicators.
+const (
+	KindReturn     = "return"     // Total return over a window
+	KindVolatility = "volatility" // Annualized volatility of daily log returns
+)
+
+// IndicatorSpec declares a named indicator computed in addition to the fixed
+// R1M-R12M returns and Vol3M/Vol6M volatilities.
+type IndicatorSpec struct {
+	Name     string
+	Kind     string
+	Lookback int  // Window in trading days
+	Skip     int  // Most recent trading days left out of a return
+	Score    bool // Blended into the momentum score and breadth filter
+}
+
+// Compute evaluates the indicator over prices sorted by date ascending.
+func (s IndicatorSpec) Compute(prices []PriceBar) (float64, error) {
+	switch s.Kind {
+	case KindReturn:
+		return CalculateWindowReturn(prices, s.Lookback, s.Skip)
+	case KindVolatility:
+		return CalculateVolatility(prices, s.Lookback)
+	default:
+		return 0, fmt.Errorf("unknown indicator kind %q", s.Kind)
+	}
+}
+
+// CalculateWindowReturn computes the total return from lookback bars ago to
+// skip bars ago, i.e. over [t-lookback, t-skip]. A skip of 0 measures to the
+// latest bar; 12-1 momentum is lookback 252 with skip 21.
+func CalculateWindowReturn(prices []PriceBar, lookback, skip int) (float64, error) {
+	if skip < 0 || skip >= lookback {
+		return 0, fmt.Errorf("invalid window: skip %d must be between 0 and lookback %d - 1", skip, lookback)
+	}
+	if len(prices) < lookback+1 {
+		return 0, fmt.Errorf("insufficient data: need %d bars, have %d", lookback+1, len(prices))
+	}
+
+	sorted := make([]PriceBar, len(prices))
+	copy(sorted, prices)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+
+	end := sorted[len(sorted)-1-skip].AdjClose
+	start := sorted[len(sorted)-1-lookback].AdjClose
+	if start == 0 {
+		return 0, fmt.Errorf("past price is zero, cannot calculate return")
+	}
+
+	return end/start - 1, nil
+}
+
+// ScoredNames returns the names of the specs blended into the momentum score.
+func ScoredNames(specs []IndicatorSpec) []string {
+	var names []string
+	for _, s := range specs {
+		if s.Score {
+			names = append(names, s.Name)
+		}
+	}
+	return names
+}
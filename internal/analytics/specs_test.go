@@ -0,0 +1,162 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// risingPrices returns n daily bars with adjusted closes 100, 101, 102, ...
+func risingPrices(n int) []PriceBar {
+	prices := make([]PriceBar, n)
+	for i := range prices {
+		price := 100.0 + float64(i)
+		prices[i] = PriceBar{Date: janDay(1).AddDate(0, 0, i), Close: price, AdjClose: price, Volume: 1000}
+	}
+	return prices
+}
+
+func TestCalculateWindowReturn(t *testing.T) {
+	prices := risingPrices(30) // 100 ... 129
+
+	r, err := CalculateWindowReturn(prices, 10, 0)
+	require.NoError(t, err)
+	assert.InDelta(t, 129.0/119.0-1, r, 1e-9)
+
+	// Skipping the last 5 bars measures from 119 to 124
+	r, err = CalculateWindowReturn(prices, 10, 5)
+	require.NoError(t, err)
+	assert.InDelta(t, 124.0/119.0-1, r, 1e-9)
+
+	_, err = CalculateWindowReturn(prices, 30, 0)
+	assert.Error(t, err)
+
+	_, err = CalculateWindowReturn(prices, 10, 10)
+	assert.Error(t, err)
+}
+
+func TestComputeIndicators_Specs(t *testing.T) {
+	calc := NewIndicatorCalculator(
+		map[string]int{"r1m": 2, "r3m": 4, "r6m": 6, "r12m": 8},
+		map[string]int{"short": 3, "long": 5},
+	)
+	calc.SetSpecs([]IndicatorSpec{
+		{Name: "r9_1", Kind: KindReturn, Lookback: 9, Skip: 1, Score: true},
+		{Name: "vol1w", Kind: KindVolatility, Lookback: 5},
+		{Name: "r2y", Kind: KindReturn, Lookback: 504}, // Not enough history
+	})
+
+	ind, err := calc.ComputeIndicators("SPY", risingPrices(20))
+	require.NoError(t, err)
+
+	assert.InDelta(t, 118.0/110.0-1, ind.Values["r9_1"], 1e-9)
+	assert.Contains(t, ind.Values, "vol1w")
+	assert.NotContains(t, ind.Values, "r2y")
+
+	// A scored indicator is required
+	calc.SetSpecs([]IndicatorSpec{{Name: "r2y", Kind: KindReturn, Lookback: 504, Score: true}})
+	_, err = calc.ComputeIndicators("SPY", risingPrices(20))
+	assert.Error(t, err)
+}
+
+func TestComputeScore_Horizons(t *testing.T) {
+	ind := &Indicators{R1M: 0.1, R3M: 0.1, R6M: 0.1, R12M: 0.1, Values: map[string]float64{"r9_1": 0.6}}
+
+	assert.InDelta(t, 0.1, ComputeScore(ind, 0), 1e-9)
+	assert.InDelta(t, 0.2, ComputeScore(ind, 0, append(BlendHorizons, "r9_1")...), 1e-9)
+	assert.InDelta(t, 0.6, ComputeScore(ind, 0, "r9_1"), 1e-9)
+}
+
+func TestComputeIndicators_Skip(t *testing.T) {
+	lookbacks := map[string]int{"r1m": 2, "r3m": 4, "r6m": 6, "r12m": 8}
+	volWindows := map[string]int{"short": 3, "long": 5}
+
+	ind, err := NewIndicatorCalculator(lookbacks, volWindows).ComputeIndicators("SPY", risingPrices(20))
+	require.NoError(t, err)
+	assert.NotContains(t, ind.Values, HorizonR12M1)
+
+	// 12-1 momentum measures from 8 bars ago (111) to 2 bars ago (117)
+	lookbacks["skip"] = 2
+	ind, err = NewIndicatorCalculator(lookbacks, volWindows).ComputeIndicators("SPY", risingPrices(20))
+	require.NoError(t, err)
+	assert.InDelta(t, 117.0/111.0-1, ind.Values[HorizonR12M1], 1e-9)
+	assert.InDelta(t, 119.0/111.0-1, ind.R12M, 1e-9)
+}
+
+func TestScorer_Momentum(t *testing.T) {
+	// AAA leads the blend, BBB leads on 12-1 momentum
+	aaa := &Indicators{Symbol: "AAA", R1M: 0.3, R3M: 0.3, R6M: 0.3, R12M: 0.3, Values: map[string]float64{HorizonR12M1: 0.1}}
+	bbb := &Indicators{Symbol: "BBB", R1M: 0.1, R3M: 0.1, R6M: 0.1, R12M: 0.1, Values: map[string]float64{HorizonR12M1: 0.5}}
+	ccc := &Indicators{Symbol: "CCC", R1M: 0.2, R3M: 0.2, R6M: 0.2, R12M: 0.2, Values: map[string]float64{HorizonR12M1: -0.1}}
+
+	leader := func(momentum string, minPositive int) []string {
+		scorer := NewScorer(ScoringConfig{Momentum: momentum, BreadthMinPositive: minPositive})
+		ranked, err := scorer.ScoreAndRank([]*Indicators{aaa, bbb, ccc})
+		require.NoError(t, err)
+		symbols := make([]string, len(ranked))
+		for i, rs := range ranked {
+			symbols[i] = rs.Symbol
+		}
+		return symbols
+	}
+
+	assert.Equal(t, []string{"AAA", "CCC", "BBB"}, leader(MomentumBlend, 4))
+	assert.Equal(t, []string{"BBB", "AAA", "CCC"}, leader(MomentumClassic, 0))
+	assert.Equal(t, []string{"AAA", "BBB", "CCC"}, leader(MomentumBlendClassic, 0))
+
+	// CCC's negative 12-1 return only counts against breadth when it is scored
+	assert.Equal(t, []string{"AAA", "BBB"}, leader(MomentumBlendClassic, 5))
+}
+
+func TestScorer_Weights(t *testing.T) {
+	// AAA is a short-term winner, BBB a long-term one
+	aaa := &Indicators{Symbol: "AAA", R1M: 0.4, R3M: 0.2, R6M: 0.0, R12M: 0.0}
+	bbb := &Indicators{Symbol: "BBB", R1M: 0.0, R3M: 0.1, R6M: 0.3, R12M: 0.3}
+
+	leader := func(weights map[string]float64) string {
+		ranked, err := NewScorer(ScoringConfig{Weights: weights}).ScoreAndRank([]*Indicators{aaa, bbb})
+		require.NoError(t, err)
+		return ranked[0].Symbol
+	}
+
+	assert.Equal(t, "BBB", leader(nil))
+	assert.Equal(t, "AAA", leader(map[string]float64{HorizonR1M: 3}))
+	assert.Equal(t, "BBB", leader(map[string]float64{HorizonR1M: 0, HorizonR3M: 0}))
+
+	// Weights are normalized
+	assert.InDelta(t, 0.4, averageReturn(aaa, BlendHorizons, map[string]float64{HorizonR1M: 2, HorizonR3M: 0, HorizonR6M: 0, HorizonR12M: 0}), 1e-12)
+	assert.Equal(t, 0.0, averageReturn(aaa, []string{HorizonR1M}, map[string]float64{HorizonR1M: 0}))
+}
+
+func TestScorer_ZScoreHorizons(t *testing.T) {
+	// R1M spreads far wider than R12M: AAA wins the raw blend on R1M alone,
+	// while BBB and CCC lead on R12M
+	aaa := &Indicators{Symbol: "AAA", R1M: 0.6, R3M: 0, R6M: 0, R12M: 0.10, Vol6M: 0.2}
+	bbb := &Indicators{Symbol: "BBB", R1M: 0.0, R3M: 0, R6M: 0, R12M: 0.20, Vol6M: 0.2}
+	ccc := &Indicators{Symbol: "CCC", R1M: 0.0, R3M: 0, R6M: 0, R12M: 0.20, Vol6M: 0.2}
+	universe := []*Indicators{aaa, bbb, ccc}
+
+	ranked, err := NewScorer(ScoringConfig{}).ScoreAndRank(universe)
+	require.NoError(t, err)
+	assert.Equal(t, "AAA", ranked[0].Symbol)
+
+	// Standardized, AAA's R1M lead counts no more than its R12M deficit
+	ranked, err = NewScorer(ScoringConfig{ZScoreHorizons: true, Weights: map[string]float64{HorizonR12M: 1.5}}).ScoreAndRank(universe)
+	require.NoError(t, err)
+	assert.Equal(t, "AAA", ranked[2].Symbol)
+
+	// The penalty is standardized too: the most volatile symbol drops
+	bbb.Vol6M = 0.4
+	ranked, err = NewScorer(ScoringConfig{ZScoreHorizons: true, PenaltyLambda: 1, Weights: map[string]float64{HorizonR12M: 1.5}}).ScoreAndRank(universe)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CCC", "AAA", "BBB"}, []string{ranked[0].Symbol, ranked[1].Symbol, ranked[2].Symbol})
+}
+
+func TestScoredNames(t *testing.T) {
+	specs := []IndicatorSpec{
+		{Name: "r9m", Kind: KindReturn, Lookback: 189},
+		{Name: "r12_1", Kind: KindReturn, Lookback: 252, Skip: 21, Score: true},
+	}
+	assert.Equal(t, []string{"r12_1"}, ScoredNames(specs))
+}
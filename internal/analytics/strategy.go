@@ -0,0 +1,251 @@
+package analytics
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Strategy scores and ranks the universe from its indicators. Every ranked
+// symbol records the strategy's name, so rankings from different models can
+// be told apart once stored.
+type Strategy interface {
+	Name() string
+	ScoreAndRank(indicatorsList []*Indicators) ([]*SymbolScore, error)
+}
+
+// StrategyFactory builds a strategy from the scoring configuration.
+type StrategyFactory func(config ScoringConfig) Strategy
+
+// Names of the built-in strategies.
+const (
+	StrategyMomentum     = "momentum"      // Weighted return blend less the penalty (the Scorer)
+	StrategyDualMomentum = "dual_momentum" // Momentum, keeping only symbols that beat the cash proxy over 12 months
+	StrategyRiskAdjusted = "risk_adjusted" // Return blend divided by the penalty term
+	StrategyRankSum      = "rank_sum"      // Sum of each horizon's cross-sectional rank
+)
+
+// ErrBelowHurdle is returned by the dual momentum strategy when no symbol beats
+// the cash hurdle, i.e. the whole universe should be out of the market.
+var ErrBelowHurdle = errors.New("no symbols beat the cash hurdle")
+
+// strategies holds the registered strategies by name, guarded by strategiesMu
+// since sweep workers build strategies concurrently.
+var (
+	strategiesMu sync.RWMutex
+	strategies   = map[string]StrategyFactory{
+		StrategyMomentum:     func(config ScoringConfig) Strategy { return NewScorer(config) },
+		StrategyDualMomentum: func(config ScoringConfig) Strategy { return &dualMomentum{Scorer: NewScorer(config)} },
+		StrategyRiskAdjusted: func(config ScoringConfig) Strategy { return &riskAdjusted{Scorer: NewScorer(config)} },
+		StrategyRankSum:      func(config ScoringConfig) Strategy { return &rankSum{Scorer: NewScorer(config)} },
+	}
+)
+
+// RegisterStrategy makes a strategy selectable by name, replacing any strategy
+// registered under it. It is safe to call concurrently with NewStrategy.
+func RegisterStrategy(name string, factory StrategyFactory) {
+	strategiesMu.Lock()
+	defer strategiesMu.Unlock()
+	strategies[name] = factory
+}
+
+// StrategyNames returns the registered strategy names in alphabetical order.
+func StrategyNames() []string {
+	strategiesMu.RLock()
+	defer strategiesMu.RUnlock()
+
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewStrategy builds the strategy named by config.Strategy, the momentum
+// blend if it is empty.
+func NewStrategy(config ScoringConfig) (Strategy, error) {
+	name := config.Strategy
+	if name == "" {
+		name = StrategyMomentum
+	}
+
+	strategiesMu.RLock()
+	factory, ok := strategies[name]
+	strategiesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown scoring strategy %q", name)
+	}
+	return factory(config), nil
+}
+
+// dualMomentum ranks by the momentum blend (relative momentum), then drops the
+// symbols whose 12-month return does not beat the T-bill proxy's (absolute
+// momentum). The proxy itself stays ranked as the place to hide; when it is not
+// in the universe the hurdle is the risk-free rate.
+type dualMomentum struct {
+	*Scorer
+}
+
+// Name returns the name dual momentum is registered under.
+func (d *dualMomentum) Name() string {
+	return StrategyDualMomentum
+}
+
+// ScoreAndRank ranks the symbols that pass the absolute momentum filter.
+func (d *dualMomentum) ScoreAndRank(indicatorsList []*Indicators) ([]*SymbolScore, error) {
+	hurdle := d.config.RiskFreeRate
+	for _, ind := range indicatorsList {
+		if d.isCashProxy(ind.Symbol) {
+			hurdle = ind.R12M
+		}
+	}
+
+	ranked, err := d.Scorer.ScoreAndRank(indicatorsList)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := make([]*SymbolScore, 0, len(ranked))
+	for _, rs := range ranked {
+		if d.isCashProxy(rs.Symbol) || rs.Indicators.R12M > hurdle {
+			rs.Strategy = StrategyDualMomentum
+			kept = append(kept, rs)
+		}
+	}
+	if len(kept) == 0 {
+		return nil, fmt.Errorf("%w of %.2f%%", ErrBelowHurdle, hurdle*100)
+	}
+
+	assignRanks(kept)
+	return kept, nil
+}
+
+// isCashProxy reports whether symbol is the configured cash proxy. Symbols are
+// stored upper-cased, so the proxy matches in any case.
+func (d *dualMomentum) isCashProxy(symbol string) bool {
+	return d.config.CashProxy != "" && strings.EqualFold(symbol, d.config.CashProxy)
+}
+
+// riskAdjusted ranks by the return blend per unit of risk, using the penalty
+// term as the risk measure. PenaltyLambda is not used; symbols whose risk is
+// not positive are not ranked.
+type riskAdjusted struct {
+	*Scorer
+}
+
+// Name returns the name risk-adjusted momentum is registered under.
+func (r *riskAdjusted) Name() string {
+	return StrategyRiskAdjusted
+}
+
+// ScoreAndRank ranks the symbols by return over risk.
+func (r *riskAdjusted) ScoreAndRank(indicatorsList []*Indicators) ([]*SymbolScore, error) {
+	filtered, err := r.filter(indicatorsList)
+	if err != nil {
+		return nil, err
+	}
+
+	horizons := r.scoreHorizons()
+	kept := make([]*Indicators, 0, len(filtered))
+	scores := make([]float64, 0, len(filtered))
+	for _, ind := range filtered {
+		risk, _ := PenaltyValue(ind, r.config.Penalty)
+		if risk <= 0 {
+			continue
+		}
+		kept = append(kept, ind)
+		scores = append(scores, averageReturn(ind, horizons, r.config.Weights)/risk)
+	}
+	if len(kept) == 0 {
+		return nil, fmt.Errorf("no symbols have a positive %s", r.penaltyName())
+	}
+
+	return rankScores(StrategyRiskAdjusted, kept, scores)
+}
+
+// penaltyName returns the configured penalty term, vol6m if unset.
+func (r *riskAdjusted) penaltyName() string {
+	if r.config.Penalty == "" {
+		return PenaltyVol6M
+	}
+	return r.config.Penalty
+}
+
+// rankSum ranks by the weighted sum of each scored horizon's rank across the
+// universe (1 = highest return), plus PenaltyLambda times the rank of the
+// penalty term (1 = lowest risk). Ranks ignore how far apart returns are, so
+// one outlier horizon cannot carry a symbol.
+type rankSum struct {
+	*Scorer
+}
+
+// Name returns the name rank-sum momentum is registered under.
+func (r *rankSum) Name() string {
+	return StrategyRankSum
+}
+
+// ScoreAndRank ranks the symbols by their rank sum, lowest first.
+func (r *rankSum) ScoreAndRank(indicatorsList []*Indicators) ([]*SymbolScore, error) {
+	filtered, err := r.filter(indicatorsList)
+	if err != nil {
+		return nil, err
+	}
+
+	sums := make([]float64, len(filtered))
+	for _, name := range r.scoreHorizons() {
+		weight := horizonWeight(r.config.Weights, name)
+		if weight == 0 {
+			continue
+		}
+
+		values := make([]float64, len(filtered))
+		for i, ind := range filtered {
+			values[i] = horizonReturns(ind, []string{name})[0]
+		}
+		for i, rank := range crossSectionalRanks(values) {
+			sums[i] += weight * rank
+		}
+	}
+
+	if r.config.PenaltyLambda > 0 {
+		risks := make([]float64, len(filtered))
+		for i, ind := range filtered {
+			// Negated so the lowest risk ranks first
+			risk, _ := PenaltyValue(ind, r.config.Penalty)
+			risks[i] = -risk
+		}
+		for i, rank := range crossSectionalRanks(risks) {
+			sums[i] += r.config.PenaltyLambda * rank
+		}
+	}
+
+	// A lower sum is better
+	scores := make([]float64, len(sums))
+	for i, sum := range sums {
+		scores[i] = -sum
+	}
+
+	return rankScores(StrategyRankSum, filtered, scores)
+}
+
+// crossSectionalRanks ranks values from 1 (highest). Tied values share the
+// average of the ranks they span.
+func crossSectionalRanks(values []float64) []float64 {
+	ranks := make([]float64, len(values))
+	for i, v := range values {
+		higher, equal := 0, 0
+		for _, other := range values {
+			switch {
+			case other > v:
+				higher++
+			case other == v:
+				equal++
+			}
+		}
+		ranks[i] = float64(higher) + float64(equal+1)/2
+	}
+	return ranks
+}
@@ -0,0 +1,152 @@
+package analytics
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rankedSymbols returns the symbols of a ranking in rank order.
+func rankedSymbols(ranked []*SymbolScore) []string {
+	symbols := make([]string, len(ranked))
+	for i, rs := range ranked {
+		symbols[i] = rs.Symbol
+	}
+	return symbols
+}
+
+func TestNewStrategy(t *testing.T) {
+	strategy, err := NewStrategy(ScoringConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, StrategyMomentum, strategy.Name())
+
+	for _, name := range []string{StrategyMomentum, StrategyDualMomentum, StrategyRiskAdjusted, StrategyRankSum} {
+		strategy, err := NewStrategy(ScoringConfig{Strategy: name})
+		require.NoError(t, err)
+		assert.Equal(t, name, strategy.Name())
+	}
+
+	_, err = NewStrategy(ScoringConfig{Strategy: "magic"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown scoring strategy "magic"`)
+}
+
+func TestRegisterStrategy(t *testing.T) {
+	// Registering while strategies are built, as sweep workers do
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_, _ = NewStrategy(ScoringConfig{Strategy: StrategyRankSum})
+				StrategyNames()
+			}
+		}()
+	}
+	defer func() {
+		strategiesMu.Lock()
+		delete(strategies, "test_reversed")
+		strategiesMu.Unlock()
+	}()
+	RegisterStrategy("test_reversed", func(config ScoringConfig) Strategy { return NewScorer(config) })
+	wg.Wait()
+
+	assert.Equal(t, []string{StrategyDualMomentum, StrategyMomentum, StrategyRankSum, StrategyRiskAdjusted, "test_reversed"}, StrategyNames())
+
+	_, err := NewStrategy(ScoringConfig{Strategy: "test_reversed"})
+	assert.NoError(t, err)
+}
+
+func TestDualMomentum(t *testing.T) {
+	aaa := &Indicators{Symbol: "AAA", R1M: 0.05, R3M: 0.1, R6M: 0.15, R12M: 0.20}
+	bbb := &Indicators{Symbol: "BBB", R1M: 0.09, R3M: 0.1, R6M: 0.05, R12M: 0.01}
+	bil := &Indicators{Symbol: "BIL", R1M: 0.003, R3M: 0.01, R6M: 0.02, R12M: 0.03}
+	universe := []*Indicators{aaa, bbb, bil}
+
+	// BBB leads on relative momentum but trails the T-bill proxy over 12 months
+	strategy, err := NewStrategy(ScoringConfig{Strategy: StrategyDualMomentum, CashProxy: "BIL"})
+	require.NoError(t, err)
+	ranked, err := strategy.ScoreAndRank(universe)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AAA", "BIL"}, rankedSymbols(ranked))
+	assert.Equal(t, 2, ranked[1].Indicators.Rank)
+	assert.Equal(t, StrategyDualMomentum, ranked[0].Strategy)
+
+	// The proxy matches whatever its case in the config
+	strategy, err = NewStrategy(ScoringConfig{Strategy: StrategyDualMomentum, CashProxy: "bil"})
+	require.NoError(t, err)
+	ranked, err = strategy.ScoreAndRank(universe)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AAA", "BIL"}, rankedSymbols(ranked))
+
+	// Without the proxy in the universe the hurdle is the risk-free rate
+	strategy, err = NewStrategy(ScoringConfig{Strategy: StrategyDualMomentum, CashProxy: "BIL", RiskFreeRate: 0.005})
+	require.NoError(t, err)
+	ranked, err = strategy.ScoreAndRank([]*Indicators{aaa, bbb})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AAA", "BBB"}, rankedSymbols(ranked))
+
+	strategy, err = NewStrategy(ScoringConfig{Strategy: StrategyDualMomentum, RiskFreeRate: 0.5})
+	require.NoError(t, err)
+	_, err = strategy.ScoreAndRank([]*Indicators{aaa, bbb})
+	assert.ErrorIs(t, err, ErrBelowHurdle)
+}
+
+func TestRiskAdjusted(t *testing.T) {
+	// AAA returns twice as much as BBB with four times the volatility
+	aaa := &Indicators{Symbol: "AAA", R1M: 0.2, R3M: 0.2, R6M: 0.2, R12M: 0.2, Vol6M: 0.4}
+	bbb := &Indicators{Symbol: "BBB", R1M: 0.1, R3M: 0.1, R6M: 0.1, R12M: 0.1, Vol6M: 0.1}
+	ccc := &Indicators{Symbol: "CCC", R1M: 0.3, R3M: 0.3, R6M: 0.3, R12M: 0.3}
+	universe := []*Indicators{aaa, bbb, ccc}
+
+	ranked, err := NewScorer(ScoringConfig{}).ScoreAndRank(universe)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CCC", "AAA", "BBB"}, rankedSymbols(ranked))
+
+	// CCC has no volatility to divide by and is not ranked
+	strategy, err := NewStrategy(ScoringConfig{Strategy: StrategyRiskAdjusted})
+	require.NoError(t, err)
+	ranked, err = strategy.ScoreAndRank(universe)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BBB", "AAA"}, rankedSymbols(ranked))
+	assert.Equal(t, StrategyRiskAdjusted, ranked[1].Strategy)
+
+	_, err = strategy.ScoreAndRank([]*Indicators{ccc})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no symbols have a positive vol6m")
+}
+
+func TestRankSum(t *testing.T) {
+	// AAA's one huge month wins the return blend; BBB ranks higher on three of
+	// four horizons
+	aaa := &Indicators{Symbol: "AAA", R1M: 0.50, R3M: 0.01, R6M: 0.01, R12M: 0.01, Vol6M: 0.1}
+	bbb := &Indicators{Symbol: "BBB", R1M: 0.02, R3M: 0.02, R6M: 0.02, R12M: 0.02, Vol6M: 0.3}
+	universe := []*Indicators{aaa, bbb}
+
+	ranked, err := NewScorer(ScoringConfig{}).ScoreAndRank(universe)
+	require.NoError(t, err)
+	assert.Equal(t, "AAA", ranked[0].Symbol)
+
+	strategy, err := NewStrategy(ScoringConfig{Strategy: StrategyRankSum})
+	require.NoError(t, err)
+	ranked, err = strategy.ScoreAndRank(universe)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BBB", "AAA"}, rankedSymbols(ranked))
+	assert.Equal(t, StrategyRankSum, ranked[0].Strategy)
+
+	// Weighting R1M and ranking risk bring AAA back
+	strategy, err = NewStrategy(ScoringConfig{Strategy: StrategyRankSum, PenaltyLambda: 1, Weights: map[string]float64{HorizonR1M: 3}})
+	require.NoError(t, err)
+	ranked, err = strategy.ScoreAndRank(universe)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AAA", "BBB"}, rankedSymbols(ranked))
+}
+
+func TestCrossSectionalRanks(t *testing.T) {
+	assert.Equal(t, []float64{1.5, 4, 1.5, 3}, crossSectionalRanks([]float64{3, 1, 3, 2}))
+	assert.Equal(t, []float64{1}, crossSectionalRanks([]float64{0.5}))
+	assert.Empty(t, crossSectionalRanks(nil))
+}
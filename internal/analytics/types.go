@@ -26,6 +26,7 @@ type Indicators struct {
 	ADV    float64 // Average dollar volume
 	Score  float64 // Composite momentum score
 	Rank   int     // Rank within universe (1 = best)
+	Values map[string]float64 // Configured indicators by name
 }
 
 // SymbolScore represents a symbol's composite score and related metrics for ranking.
@@ -34,5 +35,6 @@ type SymbolScore struct {
 	Score      float64
 	Volatility float64
 	Liquidity  float64 // ADV for tie-breaking
+	Strategy   string  // Scoring strategy that produced Score
 	Indicators Indicators
 }
@@ -0,0 +1,251 @@
+// Package backtest replays the momentum rotation strategy over stored price
+// history: hold the top N ranked symbols in equal weights and rebalance daily
+// or at each month end.
+package backtest
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/cajundata/momorot/internal/analytics"
+)
+
+// Rebalance frequencies.
+const (
+	RebalanceDaily   = "daily"
+	RebalanceMonthly = "monthly" // Last NYSE business day of each month
+)
+
+// Config controls a backtest.
+type Config struct {
+	From             time.Time
+	To               time.Time
+	TopN             int     // Symbols held, in equal weights
+	Rebalance        string  // RebalanceDaily or RebalanceMonthly
+	CostBps          float64 // Transaction cost in basis points of the value traded
+	CashSymbol       string  // Held in place of cash, e.g. BIL; empty holds cash earning nothing
+	AbsoluteMomentum bool    // Rank with dual momentum, leaving out picks that do not beat cash over 12 months
+}
+
+// Ranker ranks the symbols on a past day using only the prices known then.
+// The analytics Orchestrator is the Ranker used by `momo backtest`, so picks
+// come from the same scoring strategy as the live ranking. A ranker returning
+// analytics.ErrBelowHurdle moves the whole portfolio to cash.
+type Ranker interface {
+	Rank(history *analytics.History, day time.Time) ([]*analytics.SymbolScore, error)
+}
+
+// Point is one day of the equity curve.
+type Point struct {
+	Date     time.Time
+	Equity   float64  // Portfolio value, starting at 1
+	Holdings []string // Symbols held at the close, plus "CASH" when part of the portfolio is in cash
+}
+
+// Result summarizes a backtest.
+type Result struct {
+	Curve       []Point
+	Rebalances  int
+	Turnover    float64 // Average one-way turnover per rebalance after the first: 1 replaces the whole portfolio
+	Costs       float64 // Transaction costs paid, as a fraction of the starting equity
+	TotalReturn float64
+	CAGR        float64
+	MaxDrawdown float64 // Largest peak-to-trough decline of the equity curve, as a positive fraction
+	HitRate     float64 // Share of positions held between rebalances that gained
+}
+
+// cashHolding stands for uninvested cash in Point.Holdings.
+const cashHolding = "CASH"
+
+// position is a holding since the last rebalance.
+type position struct {
+	value      float64 // Current value
+	lastClose  float64 // Close the value was last marked at
+	entryClose float64 // Close at the last rebalance, for the hit rate
+}
+
+// Run replays the strategy from cfg.From through cfg.To. The portfolio stays
+// in cash until the first day the ranker can rank the universe, which is the
+// first rebalance whatever the frequency.
+func Run(ranker Ranker, history *analytics.History, cfg Config) (*Result, error) {
+	if cfg.TopN < 1 {
+		return nil, fmt.Errorf("top N must be at least 1")
+	}
+	if cfg.Rebalance != RebalanceDaily && cfg.Rebalance != RebalanceMonthly {
+		return nil, fmt.Errorf("rebalance must be %s or %s", RebalanceDaily, RebalanceMonthly)
+	}
+	from := time.Date(cfg.From.Year(), cfg.From.Month(), cfg.From.Day(), 0, 0, 0, 0, time.UTC)
+	to := time.Date(cfg.To.Year(), cfg.To.Month(), cfg.To.Day(), 0, 0, 0, 0, time.UTC)
+	if to.Before(from) {
+		return nil, fmt.Errorf("backtest range ends before it starts")
+	}
+
+	calendar := analytics.NewCalendar()
+	result := &Result{}
+	positions := make(map[string]*position)
+	cash := 1.0
+	invested := false
+	var lastRebalance time.Time
+	var turnover float64
+	hits, held := 0, 0
+
+	// closeHoldings scores the positions held since the last rebalance
+	closeHoldings := func() {
+		for _, p := range positions {
+			held++
+			if p.lastClose > p.entryClose {
+				hits++
+			}
+		}
+	}
+
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if !calendar.IsBusinessDay(day) {
+			continue
+		}
+
+		// Mark positions to the day's closes; symbols without a bar keep their value
+		for symbol, p := range positions {
+			if price, ok := history.CloseOn(symbol, day); ok && p.lastClose > 0 {
+				p.value *= price / p.lastClose
+				p.lastClose = price
+			}
+		}
+		equity := cash
+		for _, p := range positions {
+			equity += p.value
+		}
+
+		monthEnd := day.Equal(calendar.GetLastBusinessDayOfMonth(day.Year(), day.Month()))
+		if !invested || cfg.Rebalance == RebalanceDaily || monthEnd {
+			ranked, err := ranker.Rank(history, day)
+			if errors.Is(err, analytics.ErrBelowHurdle) {
+				// Nothing beats cash, so every slot goes to cash
+				ranked, err = nil, nil
+			}
+			if err == nil {
+				closeHoldings()
+				targets := targetWeights(ranked, history, day, cfg)
+
+				// Value traded, then costs scale down every target
+				traded := 0.0
+				for symbol, weight := range targets {
+					current := 0.0
+					if p, ok := positions[symbol]; ok {
+						current = p.value
+					}
+					traded += math.Abs(weight*equity - current)
+				}
+				for symbol, p := range positions {
+					if _, ok := targets[symbol]; !ok {
+						traded += p.value
+					}
+				}
+				cost := traded * cfg.CostBps / 10000
+				result.Costs += cost
+				if invested {
+					turnover += traded / (2 * equity)
+				}
+				equity -= cost
+
+				positions = make(map[string]*position, len(targets))
+				cash = equity
+				for symbol, weight := range targets {
+					price, _ := history.CloseOn(symbol, day)
+					positions[symbol] = &position{value: weight * equity, lastClose: price, entryClose: price}
+					cash -= weight * equity
+				}
+
+				invested = true
+				lastRebalance = day
+				result.Rebalances++
+			}
+		}
+
+		if invested {
+			result.Curve = append(result.Curve, Point{Date: day, Equity: equity, Holdings: holdings(positions, cash)})
+		}
+	}
+
+	if !invested {
+		return nil, fmt.Errorf("could not rank the universe on any day from %s to %s",
+			from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+	// Positions bought on the last day were never held
+	if !lastRebalance.Equal(result.Curve[len(result.Curve)-1].Date) {
+		closeHoldings()
+	}
+
+	if result.Rebalances > 1 {
+		result.Turnover = turnover / float64(result.Rebalances-1)
+	}
+	if held > 0 {
+		result.HitRate = float64(hits) / float64(held)
+	}
+	summarize(result)
+
+	return result, nil
+}
+
+// targetWeights returns the weight of each symbol after rebalancing on day:
+// 1/N for each of the top N picks, with the slots of missing picks going to
+// the cash symbol, or to cash when it has no price. Weights not returned are
+// held in cash.
+func targetWeights(ranked []*analytics.SymbolScore, history *analytics.History, day time.Time, cfg Config) map[string]float64 {
+	weight := 1 / float64(cfg.TopN)
+	targets := make(map[string]float64, cfg.TopN)
+
+	cashSlots := cfg.TopN
+	for _, rs := range analytics.GetTopN(ranked, cfg.TopN) {
+		targets[rs.Symbol] += weight
+		cashSlots--
+	}
+
+	if cashSlots > 0 && cfg.CashSymbol != "" {
+		if _, ok := history.CloseOn(cfg.CashSymbol, day); ok {
+			targets[cfg.CashSymbol] += weight * float64(cashSlots)
+		}
+	}
+
+	return targets
+}
+
+// holdings lists the symbols held in alphabetical order, then "CASH" if any
+// cash is left uninvested.
+func holdings(positions map[string]*position, cash float64) []string {
+	symbols := make([]string, 0, len(positions)+1)
+	for symbol := range positions {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	if cash > 1e-9 {
+		symbols = append(symbols, cashHolding)
+	}
+	return symbols
+}
+
+// summarize fills in the return and drawdown statistics of the equity curve,
+// measured from the starting equity of 1 on the first day invested.
+func summarize(result *Result) {
+	first := result.Curve[0]
+	last := result.Curve[len(result.Curve)-1]
+
+	result.TotalReturn = last.Equity - 1
+
+	years := last.Date.Sub(first.Date).Hours() / 24 / 365.25
+	if years > 0 && last.Equity > 0 {
+		result.CAGR = math.Pow(last.Equity, 1/years) - 1
+	}
+
+	peak := 1.0
+	for _, p := range result.Curve {
+		peak = math.Max(peak, p.Equity)
+		if peak > 0 {
+			result.MaxDrawdown = math.Max(result.MaxDrawdown, 1-p.Equity/peak)
+		}
+	}
+}
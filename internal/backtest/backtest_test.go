@@ -0,0 +1,149 @@
+package backtest
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/cajundata/momorot/internal/analytics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func day(month time.Month, d int) time.Time {
+	return time.Date(2024, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+// steadyPrices returns business-day bars for Q1 2024 changing by rate a day.
+func steadyPrices(rate float64) []analytics.PriceBar {
+	var prices []analytics.PriceBar
+	price := 100.0
+	for d := day(time.January, 2); !d.After(day(time.March, 28)); d = d.AddDate(0, 0, 1) {
+		if !analytics.IsBusinessDay(d) {
+			continue
+		}
+		prices = append(prices, analytics.PriceBar{Date: d, Close: price, AdjClose: price, Volume: 1000})
+		price *= 1 + rate
+	}
+	return prices
+}
+
+// fixedRanker ranks symbols in the order pick returns for the day.
+type fixedRanker struct {
+	pick func(day time.Time) ([]string, error)
+}
+
+func (r fixedRanker) Rank(history *analytics.History, day time.Time) ([]*analytics.SymbolScore, error) {
+	symbols, err := r.pick(day)
+	if err != nil {
+		return nil, err
+	}
+	ranked := make([]*analytics.SymbolScore, len(symbols))
+	for i, symbol := range symbols {
+		ranked[i] = &analytics.SymbolScore{Symbol: symbol, Indicators: analytics.Indicators{Symbol: symbol, Rank: i + 1}}
+	}
+	return ranked, nil
+}
+
+func testHistory() *analytics.History {
+	return analytics.NewHistory(map[string][]analytics.PriceBar{
+		"AAA": steadyPrices(0.01),
+		"BBB": steadyPrices(-0.01),
+		"BIL": steadyPrices(0.0001),
+	})
+}
+
+func TestRun_MonthlyBuyAndHold(t *testing.T) {
+	ranker := fixedRanker{pick: func(time.Time) ([]string, error) { return []string{"AAA", "BBB"}, nil }}
+
+	result, err := Run(ranker, testHistory(), Config{
+		From: day(time.January, 1), To: day(time.March, 28),
+		TopN: 1, Rebalance: RebalanceMonthly, CostBps: 10,
+	})
+	require.NoError(t, err)
+
+	// Start on January 2, then January 31, February 29 and March 28
+	assert.Equal(t, 4, result.Rebalances)
+	assert.Equal(t, day(time.January, 2), result.Curve[0].Date)
+	assert.Equal(t, []string{"AAA"}, result.Curve[0].Holdings)
+
+	days := len(result.Curve)
+	assert.InDelta(t, 0.999*math.Pow(1.01, float64(days-1))-1, result.TotalReturn, 1e-9)
+	assert.InDelta(t, 0.001, result.Costs, 1e-12)
+	assert.InDelta(t, 0, result.Turnover, 1e-12)
+	assert.InDelta(t, 0.001, result.MaxDrawdown, 1e-9) // The entry cost
+	assert.Equal(t, 1.0, result.HitRate)
+	assert.Greater(t, result.CAGR, result.TotalReturn)
+}
+
+func TestRun_SwitchAndDaily(t *testing.T) {
+	// AAA until the January rebalance, BBB afterwards
+	ranker := fixedRanker{pick: func(d time.Time) ([]string, error) {
+		if d.Before(day(time.January, 31)) {
+			return []string{"AAA", "BBB"}, nil
+		}
+		return []string{"BBB", "AAA"}, nil
+	}}
+
+	result, err := Run(ranker, testHistory(), Config{
+		From: day(time.January, 2), To: day(time.March, 28),
+		TopN: 1, Rebalance: RebalanceMonthly,
+	})
+	require.NoError(t, err)
+	assert.InDelta(t, 1.0/3, result.Turnover, 1e-9)
+	assert.InDelta(t, 1.0/3, result.HitRate, 1e-9) // AAA gained in January, BBB lost in February and March
+	assert.Equal(t, []string{"BBB"}, result.Curve[len(result.Curve)-1].Holdings)
+	assert.Greater(t, result.MaxDrawdown, 0.2)
+
+	daily, err := Run(ranker, testHistory(), Config{
+		From: day(time.January, 2), To: day(time.March, 28),
+		TopN: 1, Rebalance: RebalanceDaily,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, len(daily.Curve), daily.Rebalances)
+}
+
+func TestRun_CashFallback(t *testing.T) {
+	// Nothing ranks until January 10, then only one symbol
+	ranker := fixedRanker{pick: func(d time.Time) ([]string, error) {
+		if d.Before(day(time.January, 10)) {
+			return nil, fmt.Errorf("insufficient data")
+		}
+		return []string{"BBB"}, nil
+	}}
+
+	cfg := Config{
+		From: day(time.January, 2), To: day(time.March, 28),
+		TopN: 2, Rebalance: RebalanceMonthly,
+	}
+	result, err := Run(ranker, testHistory(), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, day(time.January, 10), result.Curve[0].Date)
+	assert.Equal(t, []string{"BBB", "CASH"}, result.Curve[0].Holdings)
+
+	// The empty slot goes to the cash symbol
+	cfg.CashSymbol = "BIL"
+	result, err = Run(ranker, testHistory(), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BBB", "BIL"}, result.Curve[0].Holdings)
+
+	// Nothing beating cash moves every slot to the cash symbol
+	belowHurdle := fixedRanker{pick: func(d time.Time) ([]string, error) {
+		if d.Before(day(time.January, 10)) {
+			return nil, fmt.Errorf("insufficient data")
+		}
+		return nil, fmt.Errorf("failed to score and rank: %w", analytics.ErrBelowHurdle)
+	}}
+	result, err = Run(belowHurdle, testHistory(), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BIL"}, result.Curve[0].Holdings)
+	assert.Greater(t, result.TotalReturn, 0.0)
+
+	_, err = Run(fixedRanker{pick: func(time.Time) ([]string, error) { return nil, fmt.Errorf("no data") }}, testHistory(), cfg)
+	assert.Error(t, err)
+
+	cfg.Rebalance = "weekly"
+	_, err = Run(ranker, testHistory(), cfg)
+	assert.Error(t, err)
+}
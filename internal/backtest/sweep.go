@@ -0,0 +1,119 @@
+package backtest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cajundata/momorot/internal/analytics"
+)
+
+// Params is one combination of scoring parameters tried by a sweep.
+type Params struct {
+	Lambda  float64            // Penalty factor
+	Weights map[string]float64 // Horizon weights; nil weighs every horizon equally
+	TopN    int
+}
+
+// WeightsLabel describes the horizon weights, e.g. "r1m=1 r12m=2", or "equal".
+func (p Params) WeightsLabel() string {
+	if len(p.Weights) == 0 {
+		return "equal"
+	}
+
+	names := make([]string, 0, len(p.Weights))
+	for name := range p.Weights {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s=%g", name, p.Weights[name])
+	}
+	return strings.Join(parts, " ")
+}
+
+// Grid holds the values swept for each parameter. Every combination is
+// backtested.
+type Grid struct {
+	Lambdas []float64
+	Weights []map[string]float64
+	TopN    []int
+}
+
+// Combinations returns every combination of the grid's values, by lambda,
+// then weights, then top N. An empty list of weights tries equal weights.
+func (g Grid) Combinations() []Params {
+	weights := g.Weights
+	if len(weights) == 0 {
+		weights = []map[string]float64{nil}
+	}
+
+	var combinations []Params
+	for _, lambda := range g.Lambdas {
+		for _, w := range weights {
+			for _, topN := range g.TopN {
+				combinations = append(combinations, Params{Lambda: lambda, Weights: w, TopN: topN})
+			}
+		}
+	}
+	return combinations
+}
+
+// SweepResult is the backtest of one combination, or why it failed.
+type SweepResult struct {
+	Params Params
+	Result *Result
+	Err    error
+}
+
+// Sweep backtests every combination of the grid over history with the rest of
+// cfg unchanged, running up to workers backtests at once. newRanker returns the
+// ranker scoring with a combination's parameters; each ranker is used by one
+// goroutine only. Results are in the order of Grid.Combinations.
+func Sweep(history *analytics.History, cfg Config, grid Grid, newRanker func(Params) Ranker, workers int) []SweepResult {
+	combinations := grid.Combinations()
+	results := make([]SweepResult, len(combinations))
+	if workers < 1 {
+		workers = 1
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				params := combinations[i]
+				runCfg := cfg
+				runCfg.TopN = params.TopN
+
+				result, err := Run(newRanker(params), history, runCfg)
+				results[i] = SweepResult{Params: params, Result: result, Err: err}
+			}
+		}()
+	}
+
+	for i := range combinations {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// SortByCAGR orders sweep results by CAGR, best first, with failed
+// combinations last.
+func SortByCAGR(results []SweepResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i].Result, results[j].Result
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		return a.CAGR > b.CAGR
+	})
+}
@@ -0,0 +1,63 @@
+package backtest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrid_Combinations(t *testing.T) {
+	grid := Grid{
+		Lambdas: []float64{0, 0.5},
+		Weights: []map[string]float64{nil, {"r12m": 2, "r1m": 0.5}},
+		TopN:    []int{1, 3},
+	}
+
+	combinations := grid.Combinations()
+	require.Len(t, combinations, 8)
+	assert.Equal(t, Params{Lambda: 0, TopN: 1}, combinations[0])
+	assert.Equal(t, Params{Lambda: 0, TopN: 3}, combinations[1])
+	assert.Equal(t, 0.5, combinations[7].Lambda)
+	assert.Equal(t, "r12m=2 r1m=0.5", combinations[7].WeightsLabel())
+	assert.Equal(t, "equal", combinations[0].WeightsLabel())
+
+	// No weights tries equal weights
+	assert.Len(t, Grid{Lambdas: []float64{0}, TopN: []int{1}}.Combinations(), 1)
+}
+
+func TestSweep(t *testing.T) {
+	// Lambda 0 picks the winner, any penalty the loser; lambda 9 cannot rank
+	newRanker := func(p Params) Ranker {
+		return fixedRanker{pick: func(time.Time) ([]string, error) {
+			switch p.Lambda {
+			case 0:
+				return []string{"AAA", "BBB"}, nil
+			case 9:
+				return nil, fmt.Errorf("no symbols passed filtering criteria")
+			default:
+				return []string{"BBB", "AAA"}, nil
+			}
+		}}
+	}
+
+	grid := Grid{Lambdas: []float64{0.5, 0, 9}, TopN: []int{1, 2}}
+	cfg := Config{From: day(time.January, 2), To: day(time.March, 28), Rebalance: RebalanceMonthly}
+
+	results := Sweep(testHistory(), cfg, grid, newRanker, 3)
+	require.Len(t, results, 6)
+	for i, params := range grid.Combinations() {
+		assert.Equal(t, params, results[i].Params)
+	}
+	assert.Equal(t, []string{"BBB"}, results[0].Result.Curve[0].Holdings)
+	assert.Equal(t, []string{"AAA", "BBB"}, results[1].Result.Curve[0].Holdings)
+	assert.Error(t, results[4].Err)
+
+	SortByCAGR(results)
+	assert.Equal(t, Params{Lambda: 0, TopN: 1}, results[0].Params)
+	assert.Equal(t, Params{Lambda: 0.5, TopN: 1}, results[3].Params)
+	assert.Nil(t, results[4].Result)
+	assert.Nil(t, results[5].Result)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/spf13/viper"
@@ -17,17 +18,27 @@ type Config struct {
 	Universe     []string           `mapstructure:"universe"`
 	Lookbacks    LookbacksConfig    `mapstructure:"lookbacks"`
 	VolWindows   VolWindowsConfig   `mapstructure:"vol_windows"`
+	Indicators   []IndicatorConfig  `mapstructure:"indicators"`
+	Risk         RiskConfig         `mapstructure:"risk"`
 	Scoring      ScoringConfig      `mapstructure:"scoring"`
 	Data         DataConfig         `mapstructure:"data"`
 	App          AppConfig          `mapstructure:"app"`
 	Fetcher      FetcherConfig      `mapstructure:"fetcher"`
+	Providers    ProvidersConfig    `mapstructure:"providers"`
+	Metadata     MetadataConfig     `mapstructure:"metadata"`
+	LiveQuotes   LiveQuotesConfig   `mapstructure:"live_quotes"`
+	Backtest     BacktestConfig     `mapstructure:"backtest"`
+	Sweep        SweepConfig        `mapstructure:"sweep"`
 }
 
 // AlphaVantageConfig contains Alpha Vantage API settings.
 type AlphaVantageConfig struct {
 	APIKey            string `mapstructure:"api_key"`
 	DailyRequestLimit int    `mapstructure:"daily_request_limit"`
+	RequestsPerMinute int    `mapstructure:"requests_per_minute"` // Burst limit, 0 disables it
 	BaseURL           string `mapstructure:"base_url"`
+	FixtureMode       string `mapstructure:"fixture_mode"` // off, record or replay
+	FixtureDir        string `mapstructure:"fixture_dir"`  // Raw JSON responses for record/replay
 }
 
 // LookbacksConfig defines momentum lookback periods in trading days.
@@ -36,6 +47,7 @@ type LookbacksConfig struct {
 	R3M  int `mapstructure:"r3m"`
 	R6M  int `mapstructure:"r6m"`
 	R12M int `mapstructure:"r12m"`
+	Skip int `mapstructure:"skip"` // Recent days left out of the 12-1 return over [t-r12m, t-skip]; 0 disables it
 }
 
 // VolWindowsConfig defines volatility calculation windows in trading days.
@@ -44,12 +56,37 @@ type VolWindowsConfig struct {
 	Long  int `mapstructure:"long"`
 }
 
+// IndicatorConfig declares a named indicator computed in addition to the
+// fixed lookbacks and volatility windows.
+type IndicatorConfig struct {
+	Name     string `mapstructure:"name"`     // Column name, e.g. r9m or r6_1
+	Kind     string `mapstructure:"kind"`     // return or volatility
+	Lookback int    `mapstructure:"lookback"` // Trading days
+	Skip     int    `mapstructure:"skip"`     // Most recent trading days left out of a return
+	Score    bool   `mapstructure:"score"`    // Blend the return into the momentum score
+}
+
+// RiskConfig controls the risk metrics stored with each symbol's indicators:
+// max drawdown, downside deviation, Sharpe and Sortino ratios, ulcer index and
+// beta against the benchmark.
+type RiskConfig struct {
+	Window       int     `mapstructure:"window"`         // Trading days measured over; 0 disables the metrics
+	RiskFreeRate float64 `mapstructure:"risk_free_rate"` // Annual rate used by Sharpe and Sortino, e.g. 0.04
+	Benchmark    string  `mapstructure:"benchmark"`      // Symbol beta is measured against; empty skips beta
+}
+
 // ScoringConfig contains momentum scoring parameters.
 type ScoringConfig struct {
 	PenaltyLambda       float64 `mapstructure:"penalty_lambda"`
 	MinADVUSD           float64 `mapstructure:"min_adv_usd"`
 	BreadthMinPositive  int     `mapstructure:"breadth_min_positive"`
 	BreadthTotalLookbacks int   `mapstructure:"breadth_total_lookbacks"`
+	Momentum            string  `mapstructure:"momentum"` // blend, 12-1 or blend+12-1
+	Penalty             string  `mapstructure:"penalty"`  // Risk measure scaled by penalty_lambda
+	Weights             map[string]float64 `mapstructure:"weights"` // Per-horizon weights; horizons left out weigh 1
+	ZScoreHorizons      bool    `mapstructure:"zscore_horizons"` // Z-score each horizon before blending
+	Strategy            string  `mapstructure:"strategy"`   // Registered scoring strategy, e.g. momentum or dual_momentum
+	CashProxy           string  `mapstructure:"cash_proxy"` // T-bill proxy dual momentum must beat, e.g. BIL
 }
 
 // DataConfig contains data storage settings.
@@ -78,6 +115,113 @@ type FetcherConfig struct {
 	OnlyFetchDeltas     bool `mapstructure:"only_fetch_deltas"`
 }
 
+// ProvidersConfig selects the price provider used for each symbol.
+type ProvidersConfig struct {
+	Default string             `mapstructure:"default"` // Provider for symbols without an override
+	Symbols map[string]string  `mapstructure:"symbols"` // Per-symbol provider overrides
+	CSV     CSVProviderConfig  `mapstructure:"csv"`
+	HTTP    HTTPProviderConfig `mapstructure:"http"`
+}
+
+// CSVProviderConfig contains settings for CSV drops, used by both the csv
+// provider and `momo import`.
+type CSVProviderConfig struct {
+	Dir          string            `mapstructure:"dir"`
+	Format       string            `mapstructure:"format"`        // Preset: stooq, yahoo or generic
+	Delimiter    string            `mapstructure:"delimiter"`     // Field separator; "tab" for tab-separated files
+	DecimalComma bool              `mapstructure:"decimal_comma"` // Numbers written as 1.234,56
+	Columns      map[string]string `mapstructure:"columns"`       // Header name overrides, e.g. close: "Last Price"
+}
+
+// HTTPProviderConfig contains settings for the in-house HTTP price feed.
+type HTTPProviderConfig struct {
+	BaseURL string `mapstructure:"base_url"`
+	Token   string `mapstructure:"token"`
+}
+
+// MetadataConfig controls where symbol names, asset types, exchanges, sectors
+// and currencies come from and how often they are refreshed.
+type MetadataConfig struct {
+	Source      string `mapstructure:"source"`       // alpha_vantage, csv or none
+	CSVPath     string `mapstructure:"csv_path"`     // Metadata file for the csv source
+	RefreshDays int    `mapstructure:"refresh_days"` // Minimum age before a symbol is looked up again
+}
+
+// LiveQuotesConfig controls the provisional Leaders snapshot built from latest
+// quotes during market hours. Each quoted symbol costs one API request.
+type LiveQuotesConfig struct {
+	Enabled    bool `mapstructure:"enabled"`
+	MaxSymbols int  `mapstructure:"max_symbols"` // Symbols quoted per snapshot, best ranked first
+}
+
+// BacktestConfig holds the defaults of `momo backtest`, which replays holding
+// the top N (app.top_n) in equal weights over stored prices.
+type BacktestConfig struct {
+	Rebalance        string  `mapstructure:"rebalance"`         // daily or monthly
+	CostBps          float64 `mapstructure:"cost_bps"`          // Transaction cost in basis points of the value traded
+	CashSymbol       string  `mapstructure:"cash_symbol"`       // Held in place of cash, e.g. BIL; empty holds cash earning nothing
+	AbsoluteMomentum bool    `mapstructure:"absolute_momentum"` // Rank with dual_momentum, leaving out picks that do not beat cash
+}
+
+// SweepConfig holds the parameter grid of `momo sweep`, which runs the backtest
+// for every combination of the values below.
+type SweepConfig struct {
+	Lambdas []float64            `mapstructure:"lambdas"` // scoring.penalty_lambda values
+	Weights []map[string]float64 `mapstructure:"weights"` // Horizon weight sets; horizons left out weigh 1, none tries equal weights
+	TopN    []int                `mapstructure:"top_n"`   // Symbols held; none tries app.top_n
+	Workers int                  `mapstructure:"workers"` // Backtests run at once
+}
+
+// indicatorNamePattern matches valid indicator names.
+var indicatorNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// reservedIndicatorNames are the fixed indicator columns.
+var reservedIndicatorNames = map[string]bool{
+	"r1m": true, "r3m": true, "r6m": true, "r12m": true, "r12_1": true,
+	"vol3m": true, "vol6m": true, "adv": true, "score": true, "rank": true,
+	"max_drawdown": true, "downside_dev": true, "sharpe": true, "sortino": true, "ulcer": true, "beta": true,
+}
+
+// validPenalties lists the risk measures scoring.penalty accepts. Those other
+// than the volatilities need the risk metrics, and beta needs a benchmark.
+var validPenalties = map[string]bool{
+	"vol6m": true, "vol3m": true, "max_drawdown": true, "downside_dev": true, "ulcer": true, "beta": true,
+}
+
+// validProviders lists the provider names accepted in the providers section.
+var validProviders = map[string]bool{"alpha_vantage": true, "csv": true, "http": true}
+
+// validCSVFormats lists the CSV format presets.
+var validCSVFormats = map[string]bool{"stooq": true, "yahoo": true, "generic": true}
+
+// validCSVColumns lists the columns that can be mapped to a CSV header.
+var validCSVColumns = map[string]bool{
+	"date": true, "open": true, "high": true, "low": true, "close": true,
+	"adj_close": true, "volume": true, "dividend": true, "split": true,
+}
+
+// ProviderFor returns the configured provider name for a symbol.
+func (c *Config) ProviderFor(symbol string) string {
+	// Viper lower-cases map keys, so compare case-insensitively
+	if name, ok := c.Providers.Symbols[strings.ToLower(symbol)]; ok {
+		return name
+	}
+	return c.Providers.Default
+}
+
+// usesProvider reports whether any universe symbol is served by the named provider.
+func (c *Config) usesProvider(name string) bool {
+	if len(c.Universe) == 0 {
+		return c.Providers.Default == name
+	}
+	for _, symbol := range c.Universe {
+		if c.ProviderFor(symbol) == name {
+			return true
+		}
+	}
+	return false
+}
+
 // Load loads the configuration from the specified file path or default locations.
 // It supports environment variable overrides with the MOMOROT_ prefix.
 func Load(configPath string) (*Config, error) {
@@ -140,13 +284,17 @@ func Load(configPath string) (*Config, error) {
 func setDefaults(v *viper.Viper) {
 	// Alpha Vantage defaults
 	v.SetDefault("alpha_vantage.daily_request_limit", 25)
+	v.SetDefault("alpha_vantage.requests_per_minute", 5)
 	v.SetDefault("alpha_vantage.base_url", "https://www.alphavantage.co/query")
+	v.SetDefault("alpha_vantage.fixture_mode", "off")
+	v.SetDefault("alpha_vantage.fixture_dir", "./data/fixtures")
 
 	// Lookback periods (trading days)
 	v.SetDefault("lookbacks.r1m", 21)
 	v.SetDefault("lookbacks.r3m", 63)
 	v.SetDefault("lookbacks.r6m", 126)
 	v.SetDefault("lookbacks.r12m", 252)
+	v.SetDefault("lookbacks.skip", 0)
 
 	// Volatility windows (trading days)
 	v.SetDefault("vol_windows.short", 63)
@@ -157,6 +305,17 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("scoring.min_adv_usd", 5000000.0) // $5M
 	v.SetDefault("scoring.breadth_min_positive", 3)
 	v.SetDefault("scoring.breadth_total_lookbacks", 4)
+	v.SetDefault("scoring.momentum", "blend")
+	v.SetDefault("scoring.penalty", "vol6m")
+	v.SetDefault("scoring.weights", map[string]float64{})
+	v.SetDefault("scoring.zscore_horizons", false)
+	v.SetDefault("scoring.strategy", "momentum")
+	v.SetDefault("scoring.cash_proxy", "")
+
+	// Risk metrics
+	v.SetDefault("risk.window", 126)
+	v.SetDefault("risk.risk_free_rate", 0.0)
+	v.SetDefault("risk.benchmark", "SPY")
 
 	// Data storage
 	v.SetDefault("data.data_dir", "./data")
@@ -177,12 +336,51 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("fetcher.initial_retry_delay", 1)
 	v.SetDefault("fetcher.cache_enabled", true)
 	v.SetDefault("fetcher.only_fetch_deltas", true)
+
+	// Price providers
+	v.SetDefault("providers.default", "alpha_vantage")
+	v.SetDefault("providers.csv.dir", "./data/csv")
+	v.SetDefault("providers.csv.format", "stooq")
+	v.SetDefault("providers.csv.delimiter", ",")
+
+	// Metadata defaults
+	v.SetDefault("metadata.source", "none")
+	v.SetDefault("metadata.csv_path", "./data/metadata.csv")
+	v.SetDefault("metadata.refresh_days", 30)
+
+	// Live quote defaults
+	v.SetDefault("live_quotes.enabled", false)
+	v.SetDefault("live_quotes.max_symbols", 10)
+
+	// Backtest defaults
+	v.SetDefault("backtest.rebalance", "monthly")
+	v.SetDefault("backtest.cost_bps", 10.0)
+	v.SetDefault("backtest.cash_symbol", "")
+	v.SetDefault("backtest.absolute_momentum", false)
+
+	// Sweep defaults
+	v.SetDefault("sweep.lambdas", []float64{0, 0.35, 0.7})
+	v.SetDefault("sweep.weights", []map[string]float64{})
+	v.SetDefault("sweep.top_n", []int{})
+	v.SetDefault("sweep.workers", 4)
 }
 
 // validate checks that all required configuration fields are present and valid.
 func validate(cfg *Config) error {
-	// Validate API key
-	if cfg.AlphaVantage.APIKey == "" || cfg.AlphaVantage.APIKey == "YOUR_API_KEY_HERE" {
+	// Validate fixture mode
+	switch cfg.AlphaVantage.FixtureMode {
+	case "", "off", "record", "replay":
+	default:
+		return fmt.Errorf("alpha_vantage.fixture_mode must be one of: off, record, replay")
+	}
+	if (cfg.AlphaVantage.FixtureMode == "record" || cfg.AlphaVantage.FixtureMode == "replay") && cfg.AlphaVantage.FixtureDir == "" {
+		return fmt.Errorf("alpha_vantage.fixture_dir is required when fixture_mode is %s", cfg.AlphaVantage.FixtureMode)
+	}
+
+	// Validate API key (only needed when Alpha Vantage serves at least one symbol
+	// and responses are not replayed from fixtures)
+	if cfg.usesProvider("alpha_vantage") && cfg.AlphaVantage.FixtureMode != "replay" &&
+		(cfg.AlphaVantage.APIKey == "" || cfg.AlphaVantage.APIKey == "YOUR_API_KEY_HERE") {
 		return fmt.Errorf("alpha_vantage.api_key is required (set via config file or ALPHAVANTAGE_API_KEY env var)")
 	}
 
@@ -195,17 +393,54 @@ func validate(cfg *Config) error {
 	if cfg.AlphaVantage.DailyRequestLimit < 1 {
 		return fmt.Errorf("alpha_vantage.daily_request_limit must be at least 1")
 	}
+	if cfg.AlphaVantage.RequestsPerMinute < 0 {
+		return fmt.Errorf("alpha_vantage.requests_per_minute must be non-negative")
+	}
 
 	// Validate lookback periods
 	if cfg.Lookbacks.R1M < 1 || cfg.Lookbacks.R3M < 1 || cfg.Lookbacks.R6M < 1 || cfg.Lookbacks.R12M < 1 {
 		return fmt.Errorf("all lookback periods must be positive")
 	}
+	if cfg.Lookbacks.Skip < 0 || cfg.Lookbacks.Skip >= cfg.Lookbacks.R12M {
+		return fmt.Errorf("lookbacks.skip must be between 0 and r12m-1")
+	}
 
 	// Validate volatility windows
 	if cfg.VolWindows.Short < 1 || cfg.VolWindows.Long < 1 {
 		return fmt.Errorf("volatility windows must be positive")
 	}
 
+	// Validate configured indicators
+	seen := make(map[string]bool)
+	for i, ind := range cfg.Indicators {
+		if !indicatorNamePattern.MatchString(ind.Name) {
+			return fmt.Errorf("indicators[%d].name %q must be lower case letters, digits and underscores", i, ind.Name)
+		}
+		if reservedIndicatorNames[ind.Name] {
+			return fmt.Errorf("indicators[%d].name %q is a built-in indicator", i, ind.Name)
+		}
+		if seen[ind.Name] {
+			return fmt.Errorf("indicators[%d].name %q is declared twice", i, ind.Name)
+		}
+		seen[ind.Name] = true
+
+		switch ind.Kind {
+		case "return":
+		case "volatility":
+			if ind.Skip != 0 || ind.Score {
+				return fmt.Errorf("indicator %s: skip and score only apply to returns", ind.Name)
+			}
+		default:
+			return fmt.Errorf("indicator %s: kind must be one of: return, volatility", ind.Name)
+		}
+		if ind.Lookback < 1 {
+			return fmt.Errorf("indicator %s: lookback must be positive", ind.Name)
+		}
+		if ind.Skip < 0 || ind.Skip >= ind.Lookback {
+			return fmt.Errorf("indicator %s: skip must be between 0 and lookback-1", ind.Name)
+		}
+	}
+
 	// Validate scoring parameters
 	if cfg.Scoring.PenaltyLambda < 0 || cfg.Scoring.PenaltyLambda > 1 {
 		return fmt.Errorf("scoring.penalty_lambda must be between 0 and 1")
@@ -219,6 +454,38 @@ func validate(cfg *Config) error {
 	if cfg.Scoring.BreadthMinPositive > cfg.Scoring.BreadthTotalLookbacks {
 		return fmt.Errorf("breadth_min_positive cannot exceed breadth_total_lookbacks")
 	}
+	switch cfg.Scoring.Momentum {
+	case "", "blend":
+	case "12-1", "blend+12-1":
+		if cfg.Lookbacks.Skip == 0 {
+			return fmt.Errorf("scoring.momentum %s requires lookbacks.skip, e.g. 21", cfg.Scoring.Momentum)
+		}
+	default:
+		return fmt.Errorf("scoring.momentum must be one of: blend, 12-1, blend+12-1")
+	}
+	if cfg.Scoring.Penalty != "" {
+		if !validPenalties[cfg.Scoring.Penalty] {
+			return fmt.Errorf("scoring.penalty must be one of: vol6m, vol3m, max_drawdown, downside_dev, ulcer, beta")
+		}
+		if cfg.Scoring.Penalty != "vol6m" && cfg.Scoring.Penalty != "vol3m" && cfg.Risk.Window == 0 {
+			return fmt.Errorf("scoring.penalty %s requires risk.window", cfg.Scoring.Penalty)
+		}
+		if cfg.Scoring.Penalty == "beta" && cfg.Risk.Benchmark == "" {
+			return fmt.Errorf("scoring.penalty beta requires risk.benchmark")
+		}
+	}
+
+	if err := cfg.validateWeights("scoring.weights", cfg.Scoring.Weights); err != nil {
+		return err
+	}
+
+	// Validate risk metrics
+	if cfg.Risk.Window < 0 || cfg.Risk.Window == 1 {
+		return fmt.Errorf("risk.window must be 0 (disabled) or at least 2")
+	}
+	if cfg.Risk.RiskFreeRate < 0 || cfg.Risk.RiskFreeRate >= 1 {
+		return fmt.Errorf("risk.risk_free_rate must be between 0 and 1")
+	}
 
 	// Validate log level
 	validLogLevels := map[string]bool{"debug": true, "info": true, "warn": true, "error": true}
@@ -245,6 +512,82 @@ func validate(cfg *Config) error {
 		return fmt.Errorf("fetcher.initial_retry_delay must be at least 1 second")
 	}
 
+	// Validate provider selection
+	if !validProviders[cfg.Providers.Default] {
+		return fmt.Errorf("providers.default must be one of: alpha_vantage, csv, http")
+	}
+	for symbol, name := range cfg.Providers.Symbols {
+		if !validProviders[name] {
+			return fmt.Errorf("providers.symbols.%s must be one of: alpha_vantage, csv, http", symbol)
+		}
+	}
+	if cfg.usesProvider("http") && cfg.Providers.HTTP.BaseURL == "" {
+		return fmt.Errorf("providers.http.base_url is required when the http provider is used")
+	}
+
+	// Validate CSV format settings
+	if !validCSVFormats[cfg.Providers.CSV.Format] {
+		return fmt.Errorf("providers.csv.format must be one of: stooq, yahoo, generic")
+	}
+	if d := cfg.Providers.CSV.Delimiter; d != "tab" && len([]rune(d)) != 1 {
+		return fmt.Errorf("providers.csv.delimiter must be a single character or \"tab\"")
+	}
+	if cfg.Providers.CSV.DecimalComma && cfg.Providers.CSV.Delimiter == "," {
+		return fmt.Errorf("providers.csv.decimal_comma requires a delimiter other than \",\"")
+	}
+	for column := range cfg.Providers.CSV.Columns {
+		if !validCSVColumns[column] {
+			return fmt.Errorf("providers.csv.columns.%s is not a known column", column)
+		}
+	}
+
+	// Validate metadata settings
+	switch cfg.Metadata.Source {
+	case "alpha_vantage", "none":
+	case "csv":
+		if cfg.Metadata.CSVPath == "" {
+			return fmt.Errorf("metadata.csv_path is required when metadata.source is csv")
+		}
+	default:
+		return fmt.Errorf("metadata.source must be one of: alpha_vantage, csv, none")
+	}
+	if cfg.Metadata.RefreshDays < 1 {
+		return fmt.Errorf("metadata.refresh_days must be at least 1")
+	}
+
+	// Validate live quote settings
+	if cfg.LiveQuotes.Enabled && cfg.LiveQuotes.MaxSymbols < 1 {
+		return fmt.Errorf("live_quotes.max_symbols must be at least 1 when live quotes are enabled")
+	}
+
+	// Validate backtest defaults
+	if cfg.Backtest.Rebalance != "daily" && cfg.Backtest.Rebalance != "monthly" {
+		return fmt.Errorf("backtest.rebalance must be either 'daily' or 'monthly'")
+	}
+	if cfg.Backtest.CostBps < 0 {
+		return fmt.Errorf("backtest.cost_bps must be non-negative")
+	}
+
+	// Validate sweep grid
+	for _, lambda := range cfg.Sweep.Lambdas {
+		if lambda < 0 || lambda > 1 {
+			return fmt.Errorf("sweep.lambdas must be between 0 and 1")
+		}
+	}
+	for _, topN := range cfg.Sweep.TopN {
+		if topN < 1 {
+			return fmt.Errorf("sweep.top_n values must be at least 1")
+		}
+	}
+	for i, weights := range cfg.Sweep.Weights {
+		if err := cfg.validateWeights(fmt.Sprintf("sweep.weights[%d]", i), weights); err != nil {
+			return err
+		}
+	}
+	if cfg.Sweep.Workers < 1 {
+		return fmt.Errorf("sweep.workers must be at least 1")
+	}
+
 	return nil
 }
 
@@ -257,3 +600,62 @@ func (c *Config) DBPath() string {
 func (c *Config) GetLookbackPeriods() []int {
 	return []int{c.Lookbacks.R1M, c.Lookbacks.R3M, c.Lookbacks.R6M, c.Lookbacks.R12M}
 }
+
+// IndicatorNames returns the names of the indicators stored alongside the
+// fixed columns: r12_1 when lookbacks.skip is set, then the configured ones.
+func (c *Config) IndicatorNames() []string {
+	var names []string
+	if c.Lookbacks.Skip > 0 {
+		names = append(names, "r12_1")
+	}
+	for _, ind := range c.Indicators {
+		names = append(names, ind.Name)
+	}
+	return names
+}
+
+// scoredHorizons returns the names of the returns that can be blended into the
+// score: the built-in horizons, r12_1 when lookbacks.skip is set and the
+// configured indicators marked score.
+func (c *Config) scoredHorizons() map[string]bool {
+	names := map[string]bool{"r1m": true, "r3m": true, "r6m": true, "r12m": true}
+	if c.Lookbacks.Skip > 0 {
+		names["r12_1"] = true
+	}
+	for _, ind := range c.Indicators {
+		if ind.Score {
+			names[ind.Name] = true
+		}
+	}
+	return names
+}
+
+// validateWeights checks that a set of horizon weights names scored horizons
+// and that no weight is negative.
+func (c *Config) validateWeights(key string, weights map[string]float64) error {
+	scored := c.scoredHorizons()
+	for name, weight := range weights {
+		if !scored[name] {
+			return fmt.Errorf("%s.%s is not a scored horizon", key, name)
+		}
+		if weight < 0 {
+			return fmt.Errorf("%s.%s must be non-negative", key, name)
+		}
+	}
+	return nil
+}
+
+// LongestLookback returns the history in trading days indicators need: the
+// 12-month lookback, or a longer configured indicator's or risk window.
+func (c *Config) LongestLookback() int {
+	longest := c.Lookbacks.R12M
+	if c.Risk.Window > longest {
+		longest = c.Risk.Window
+	}
+	for _, ind := range c.Indicators {
+		if ind.Lookback > longest {
+			longest = ind.Lookback
+		}
+	}
+	return longest
+}
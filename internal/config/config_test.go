@@ -333,6 +333,7 @@ universe:
 
 	// Verify defaults were applied
 	assert.Equal(t, 25, cfg.AlphaVantage.DailyRequestLimit)
+	assert.Equal(t, 5, cfg.AlphaVantage.RequestsPerMinute)
 	assert.Equal(t, "https://www.alphavantage.co/query", cfg.AlphaVantage.BaseURL)
 	assert.Equal(t, 21, cfg.Lookbacks.R1M)
 	assert.Equal(t, 63, cfg.Lookbacks.R3M)
@@ -345,6 +346,8 @@ universe:
 	assert.Equal(t, 5, cfg.App.TopN)
 	assert.Equal(t, "info", cfg.App.LogLevel)
 	assert.Equal(t, 5, cfg.Fetcher.MaxWorkers)
+	assert.Equal(t, "none", cfg.Metadata.Source)
+	assert.Equal(t, 30, cfg.Metadata.RefreshDays)
 }
 
 func TestDBPath(t *testing.T) {
@@ -392,3 +395,653 @@ func TestLoad_ConfigFileNotFound(t *testing.T) {
 	// But it demonstrates that missing config file is handled gracefully
 	assert.Error(t, err) // Will fail on universe validation
 }
+
+func TestLoad_ProviderOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configContent := `
+alpha_vantage:
+  api_key: "test_key"
+
+universe:
+  - "SPY"
+  - "XLRE"
+
+providers:
+  symbols:
+    XLRE: "csv"
+  csv:
+    dir: "/tmp/stooq"
+`
+
+	err := os.WriteFile(configPath, []byte(configContent), 0644)
+	require.NoError(t, err)
+
+	cfg, err := Load(configPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, "alpha_vantage", cfg.Providers.Default)
+	assert.Equal(t, "/tmp/stooq", cfg.Providers.CSV.Dir)
+	assert.Equal(t, "alpha_vantage", cfg.ProviderFor("SPY"))
+	assert.Equal(t, "csv", cfg.ProviderFor("XLRE"))
+}
+
+func TestLoad_CSVProviderWithoutAPIKey(t *testing.T) {
+	oldAPIKey := os.Getenv("ALPHAVANTAGE_API_KEY")
+	os.Unsetenv("ALPHAVANTAGE_API_KEY")
+	defer func() {
+		if oldAPIKey != "" {
+			os.Setenv("ALPHAVANTAGE_API_KEY", oldAPIKey)
+		}
+	}()
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configContent := `
+universe:
+  - "SPY"
+
+providers:
+  default: "csv"
+`
+
+	err := os.WriteFile(configPath, []byte(configContent), 0644)
+	require.NoError(t, err)
+
+	// No API key is needed when Alpha Vantage serves no symbols
+	cfg, err := Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "csv", cfg.ProviderFor("SPY"))
+}
+
+func TestLoad_InvalidProvider(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configContent := `
+alpha_vantage:
+  api_key: "test_key"
+
+universe:
+  - "SPY"
+
+providers:
+  default: "bloomberg"
+`
+
+	err := os.WriteFile(configPath, []byte(configContent), 0644)
+	require.NoError(t, err)
+
+	_, err = Load(configPath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "providers.default must be one of")
+}
+
+func TestLoad_HTTPProviderRequiresBaseURL(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configContent := `
+alpha_vantage:
+  api_key: "test_key"
+
+universe:
+  - "SPY"
+
+providers:
+  symbols:
+    SPY: "http"
+`
+
+	err := os.WriteFile(configPath, []byte(configContent), 0644)
+	require.NoError(t, err)
+
+	_, err = Load(configPath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "providers.http.base_url is required")
+}
+
+func TestLoad_CSVFormatSettings(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configContent := `
+alpha_vantage:
+  api_key: "test_key"
+
+universe:
+  - "SPY"
+
+providers:
+  csv:
+    format: "generic"
+    delimiter: ";"
+    decimal_comma: true
+    columns:
+      close: "Schlusskurs"
+`
+
+	err := os.WriteFile(configPath, []byte(configContent), 0644)
+	require.NoError(t, err)
+
+	cfg, err := Load(configPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, "generic", cfg.Providers.CSV.Format)
+	assert.Equal(t, ";", cfg.Providers.CSV.Delimiter)
+	assert.True(t, cfg.Providers.CSV.DecimalComma)
+	assert.Equal(t, "Schlusskurs", cfg.Providers.CSV.Columns["close"])
+}
+
+func TestLoad_InvalidCSVFormat(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configContent := `
+alpha_vantage:
+  api_key: "test_key"
+
+universe:
+  - "SPY"
+
+providers:
+  csv:
+    format: "bloomberg"
+`
+
+	err := os.WriteFile(configPath, []byte(configContent), 0644)
+	require.NoError(t, err)
+
+	_, err = Load(configPath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "providers.csv.format must be one of")
+}
+
+func TestLoad_FixtureReplayWithoutAPIKey(t *testing.T) {
+	oldAPIKey := os.Getenv("ALPHAVANTAGE_API_KEY")
+	os.Unsetenv("ALPHAVANTAGE_API_KEY")
+	defer func() {
+		if oldAPIKey != "" {
+			os.Setenv("ALPHAVANTAGE_API_KEY", oldAPIKey)
+		}
+	}()
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configContent := `
+alpha_vantage:
+  fixture_mode: "replay"
+  fixture_dir: "./testdata/av"
+
+universe:
+  - "SPY"
+`
+
+	err := os.WriteFile(configPath, []byte(configContent), 0644)
+	require.NoError(t, err)
+
+	// Replayed responses need no API key
+	cfg, err := Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "replay", cfg.AlphaVantage.FixtureMode)
+	assert.Equal(t, "./testdata/av", cfg.AlphaVantage.FixtureDir)
+}
+
+func TestLoad_InvalidFixtureMode(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configContent := `
+alpha_vantage:
+  api_key: "test_key"
+  fixture_mode: "mock"
+
+universe:
+  - "SPY"
+`
+
+	err := os.WriteFile(configPath, []byte(configContent), 0644)
+	require.NoError(t, err)
+
+	_, err = Load(configPath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "alpha_vantage.fixture_mode must be one of")
+}
+
+func TestLoad_InvalidMetadataSource(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configContent := `
+alpha_vantage:
+  api_key: "test_key"
+
+universe:
+  - "SPY"
+
+metadata:
+  source: "bloomberg"
+`
+
+	err := os.WriteFile(configPath, []byte(configContent), 0644)
+	require.NoError(t, err)
+
+	_, err = Load(configPath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "metadata.source must be one of")
+}
+
+func TestLoad_LiveQuotes(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	configContent := `
+alpha_vantage:
+  api_key: "test_key"
+
+universe:
+  - "SPY"
+`
+
+	err := os.WriteFile(configPath, []byte(configContent), 0644)
+	require.NoError(t, err)
+
+	cfg, err := Load(configPath)
+	require.NoError(t, err)
+	assert.False(t, cfg.LiveQuotes.Enabled)
+	assert.Equal(t, 10, cfg.LiveQuotes.MaxSymbols)
+
+	configContent += `
+live_quotes:
+  enabled: true
+  max_symbols: 0
+`
+	err = os.WriteFile(configPath, []byte(configContent), 0644)
+	require.NoError(t, err)
+
+	_, err = Load(configPath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "live_quotes.max_symbols must be at least 1")
+}
+
+func TestLoad_Indicators(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	base := `
+alpha_vantage:
+  api_key: "test_key"
+
+universe:
+  - "SPY"
+`
+
+	configContent := base + `
+indicators:
+  - name: r9m
+    kind: return
+    lookback: 189
+  - name: r15_1
+    kind: return
+    lookback: 300
+    skip: 21
+    score: true
+  - name: vol1m
+    kind: volatility
+    lookback: 21
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+
+	cfg, err := Load(configPath)
+	require.NoError(t, err)
+	require.Len(t, cfg.Indicators, 3)
+	assert.Equal(t, IndicatorConfig{Name: "r15_1", Kind: "return", Lookback: 300, Skip: 21, Score: true}, cfg.Indicators[1])
+	assert.Equal(t, 300, cfg.LongestLookback())
+
+	tests := []struct {
+		name       string
+		indicators string
+		wantErr    string
+	}{
+		{"reserved name", "  - {name: r1m, kind: return, lookback: 10}", "built-in indicator"},
+		{"reserved 12-1", "  - {name: r12_1, kind: return, lookback: 252, skip: 21}", "built-in indicator"},
+		{"bad name", "  - {name: R9M, kind: return, lookback: 10}", "lower case"},
+		{"duplicate", "  - {name: r9m, kind: return, lookback: 10}\n  - {name: r9m, kind: return, lookback: 20}", "declared twice"},
+		{"unknown kind", "  - {name: rsi, kind: oscillator, lookback: 14}", "kind must be one of"},
+		{"skip too long", "  - {name: r1_1, kind: return, lookback: 21, skip: 21}", "skip must be between"},
+		{"scored volatility", "  - {name: vol1m, kind: volatility, lookback: 21, score: true}", "only apply to returns"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := base + "\nindicators:\n" + tt.indicators + "\n"
+			require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+
+			_, err := Load(configPath)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLoad_SkipMomentum(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	base := `
+alpha_vantage:
+  api_key: "test_key"
+
+universe:
+  - "SPY"
+`
+
+	// Defaults: no skip, plain blend
+	require.NoError(t, os.WriteFile(configPath, []byte(base), 0644))
+	cfg, err := Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, 0, cfg.Lookbacks.Skip)
+	assert.Equal(t, "blend", cfg.Scoring.Momentum)
+	assert.Empty(t, cfg.IndicatorNames())
+
+	configContent := base + `
+lookbacks:
+  skip: 21
+
+scoring:
+  momentum: "blend+12-1"
+
+indicators:
+  - name: r9m
+    kind: return
+    lookback: 189
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+	cfg, err = Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, 21, cfg.Lookbacks.Skip)
+	assert.Equal(t, "blend+12-1", cfg.Scoring.Momentum)
+	assert.Equal(t, []string{"r12_1", "r9m"}, cfg.IndicatorNames())
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"12-1 without skip", "scoring:\n  momentum: \"12-1\"", "requires lookbacks.skip"},
+		{"unknown momentum", "scoring:\n  momentum: \"dual\"", "scoring.momentum must be one of"},
+		{"skip too long", "lookbacks:\n  skip: 252", "lookbacks.skip must be between"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, os.WriteFile(configPath, []byte(base+"\n"+tt.content+"\n"), 0644))
+
+			_, err := Load(configPath)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLoad_Risk(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	base := `
+alpha_vantage:
+  api_key: "test_key"
+
+universe:
+  - "SPY"
+`
+
+	require.NoError(t, os.WriteFile(configPath, []byte(base), 0644))
+	cfg, err := Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, RiskConfig{Window: 126, RiskFreeRate: 0, Benchmark: "SPY"}, cfg.Risk)
+	assert.Equal(t, "vol6m", cfg.Scoring.Penalty)
+
+	configContent := base + `
+risk:
+  window: 300
+  risk_free_rate: 0.04
+  benchmark: "QQQ"
+
+scoring:
+  penalty: "ulcer"
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+	cfg, err = Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, RiskConfig{Window: 300, RiskFreeRate: 0.04, Benchmark: "QQQ"}, cfg.Risk)
+	assert.Equal(t, "ulcer", cfg.Scoring.Penalty)
+	assert.Equal(t, 300, cfg.LongestLookback())
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"unknown penalty", "scoring:\n  penalty: \"sharpe\"", "scoring.penalty must be one of"},
+		{"penalty without risk", "risk:\n  window: 0\nscoring:\n  penalty: \"max_drawdown\"", "requires risk.window"},
+		{"beta without benchmark", "risk:\n  benchmark: \"\"\nscoring:\n  penalty: \"beta\"", "requires risk.benchmark"},
+		{"negative window", "risk:\n  window: -5", "risk.window must be"},
+		{"bad risk-free rate", "risk:\n  risk_free_rate: 4", "risk.risk_free_rate must be"},
+		{"reserved name", "indicators:\n  - {name: sharpe, kind: return, lookback: 10}", "built-in indicator"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, os.WriteFile(configPath, []byte(base+"\n"+tt.content+"\n"), 0644))
+
+			_, err := Load(configPath)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLoad_Backtest(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	base := `
+alpha_vantage:
+  api_key: "test_key"
+
+universe:
+  - "SPY"
+`
+
+	require.NoError(t, os.WriteFile(configPath, []byte(base), 0644))
+	cfg, err := Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, BacktestConfig{Rebalance: "monthly", CostBps: 10}, cfg.Backtest)
+
+	configContent := base + `
+backtest:
+  rebalance: "daily"
+  cost_bps: 2.5
+  cash_symbol: "BIL"
+  absolute_momentum: true
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+	cfg, err = Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, BacktestConfig{Rebalance: "daily", CostBps: 2.5, CashSymbol: "BIL", AbsoluteMomentum: true}, cfg.Backtest)
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"unknown rebalance", "backtest:\n  rebalance: \"weekly\"", "backtest.rebalance must be"},
+		{"negative cost", "backtest:\n  cost_bps: -1", "backtest.cost_bps must be"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, os.WriteFile(configPath, []byte(base+"\n"+tt.content+"\n"), 0644))
+
+			_, err := Load(configPath)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLoad_Sweep(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	base := `
+alpha_vantage:
+  api_key: "test_key"
+
+universe:
+  - "SPY"
+`
+
+	require.NoError(t, os.WriteFile(configPath, []byte(base), 0644))
+	cfg, err := Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{0, 0.35, 0.7}, cfg.Sweep.Lambdas)
+	assert.Empty(t, cfg.Sweep.Weights)
+	assert.Empty(t, cfg.Sweep.TopN)
+	assert.Equal(t, 4, cfg.Sweep.Workers)
+
+	configContent := base + `
+lookbacks:
+  skip: 21
+
+sweep:
+  lambdas: [0.1, 0.5]
+  weights:
+    - {}
+    - {r1m: 0, r12_1: 2}
+  top_n: [2, 4]
+  workers: 2
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+	cfg, err = Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{0.1, 0.5}, cfg.Sweep.Lambdas)
+	require.Len(t, cfg.Sweep.Weights, 2)
+	assert.Empty(t, cfg.Sweep.Weights[0])
+	assert.Equal(t, map[string]float64{"r1m": 0, "r12_1": 2}, cfg.Sweep.Weights[1])
+	assert.Equal(t, []int{2, 4}, cfg.Sweep.TopN)
+	assert.Equal(t, 2, cfg.Sweep.Workers)
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"negative lambda", "sweep:\n  lambdas: [-0.1]", "sweep.lambdas must be between 0 and 1"},
+		{"lambda above one", "sweep:\n  lambdas: [0.5, 1.5]", "sweep.lambdas must be between 0 and 1"},
+		{"zero top n", "sweep:\n  top_n: [0]", "sweep.top_n values must be"},
+		{"unscored horizon", "sweep:\n  weights:\n    - {r12_1: 1}", "not a scored horizon"},
+		{"negative weight", "sweep:\n  weights:\n    - {r3m: -1}", "must be non-negative"},
+		{"no workers", "sweep:\n  workers: 0", "sweep.workers must be"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, os.WriteFile(configPath, []byte(base+"\n"+tt.content+"\n"), 0644))
+
+			_, err := Load(configPath)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLoad_ScoringWeights(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	base := `
+alpha_vantage:
+  api_key: "test_key"
+
+universe:
+  - "SPY"
+`
+
+	require.NoError(t, os.WriteFile(configPath, []byte(base), 0644))
+	cfg, err := Load(configPath)
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Scoring.Weights)
+	assert.False(t, cfg.Scoring.ZScoreHorizons)
+
+	configContent := base + `
+indicators:
+  - {name: r9m, kind: return, lookback: 189, score: true}
+
+scoring:
+  penalty: "vol3m"
+  weights: {r1m: 0.5, r12m: 2, r9m: 1.5}
+  zscore_horizons: true
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+	cfg, err = Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"r1m": 0.5, "r12m": 2, "r9m": 1.5}, cfg.Scoring.Weights)
+	assert.True(t, cfg.Scoring.ZScoreHorizons)
+	assert.Equal(t, "vol3m", cfg.Scoring.Penalty)
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"unknown horizon", "scoring:\n  weights: {r2m: 1}", "scoring.weights.r2m is not a scored horizon"},
+		{"unscored indicator", "indicators:\n  - {name: r9m, kind: return, lookback: 189}\nscoring:\n  weights: {r9m: 1}", "not a scored horizon"},
+		{"negative weight", "scoring:\n  weights: {r6m: -0.5}", "scoring.weights.r6m must be non-negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, os.WriteFile(configPath, []byte(base+"\n"+tt.content+"\n"), 0644))
+
+			_, err := Load(configPath)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLoad_Strategy(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	base := `
+alpha_vantage:
+  api_key: "test_key"
+
+universe:
+  - "SPY"
+  - "BIL"
+`
+
+	require.NoError(t, os.WriteFile(configPath, []byte(base), 0644))
+	cfg, err := Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "momentum", cfg.Scoring.Strategy)
+	assert.Empty(t, cfg.Scoring.CashProxy)
+
+	configContent := base + `
+scoring:
+  strategy: "dual_momentum"
+  cash_proxy: "BIL"
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+	cfg, err = Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "dual_momentum", cfg.Scoring.Strategy)
+	assert.Equal(t, "BIL", cfg.Scoring.CashProxy)
+}
@@ -29,18 +29,8 @@ func (db *DB) Migrate() error {
 		return fmt.Errorf("failed to get current version: %w", err)
 	}
 
-	// Define all migrations
-	migrations := []Migration{
-		{
-			Version:     1,
-			Description: "Initial schema with symbols, prices, indicators, runs, and fetch_log",
-			Up:          schemaSQL,
-			Down:        dropAllTables,
-		},
-	}
-
 	// Apply pending migrations
-	for _, migration := range migrations {
+	for _, migration := range allMigrations() {
 		if migration.Version <= currentVersion {
 			continue // Already applied
 		}
@@ -53,6 +43,72 @@ func (db *DB) Migrate() error {
 	return nil
 }
 
+// allMigrations returns every schema migration in version order
+func allMigrations() []Migration {
+	return []Migration{
+		{
+			Version:     1,
+			Description: "Initial schema with symbols, prices, indicators, runs, and fetch_log",
+			Up:          schemaSQL,
+			Down:        dropAllTables,
+		},
+		{
+			Version:     2,
+			Description: "API quota usage per provider and UTC day",
+			Up:          createAPIQuota,
+			Down:        dropAPIQuota,
+		},
+		{
+			Version:     3,
+			Description: "Corporate actions (dividends and splits) per symbol and date",
+			Up:          createCorporateActions,
+			Down:        dropCorporateActions,
+		},
+		{
+			Version:     4,
+			Description: "Symbol metadata (exchange, sector, currency)",
+			Up:          addSymbolMetadata,
+			Down:        dropSymbolMetadata,
+		},
+		{
+			Version:     5,
+			Description: "Pending fetches carried over between refreshes",
+			Up:          createPendingFetches,
+			Down:        dropPendingFetches,
+		},
+		{
+			Version:     6,
+			Description: "Run kind (refresh or import)",
+			Up:          addRunKind,
+			Down:        dropRunKind,
+		},
+		{
+			Version:     7,
+			Description: "Data quality findings per symbol",
+			Up:          createQualityFindings,
+			Down:        dropQualityFindings,
+		},
+		{
+			Version:     8,
+			Description: "Configured indicator values in long format",
+			Up:          createIndicatorValues,
+			Down:        dropIndicatorValues,
+		},
+		{
+			Version:     9,
+			Description: "Scoring strategy on runs and indicators",
+			Up:          addStrategy,
+			Down:        dropStrategy,
+		},
+		{
+			Version:     10,
+			Description: "Gap backfill flag on fetch_log",
+			Up:          addFetchLogBackfill,
+			Down:        dropFetchLogBackfill,
+		},
+	}
+}
+
 // initMigrationsTable creates the migrations tracking table
 func (db *DB) initMigrationsTable() error {
 	query := `
@@ -114,17 +170,8 @@ func (db *DB) Rollback() error {
 	}
 
 	// Find the migration to rollback
-	migrations := []Migration{
-		{
-			Version:     1,
-			Description: "Initial schema",
-			Up:          schemaSQL,
-			Down:        dropAllTables,
-		},
-	}
-
 	var targetMigration *Migration
-	for _, m := range migrations {
+	for _, m := range allMigrations() {
 		if m.Version == currentVersion {
 			targetMigration = &m
 			break
@@ -200,3 +247,248 @@ DROP TABLE IF EXISTS indicators;
 DROP TABLE IF EXISTS prices;
 DROP TABLE IF EXISTS symbols;
 `
+
+// createAPIQuota is the up migration for version 2
+const createAPIQuota = `
+CREATE TABLE IF NOT EXISTS api_quota(
+  provider TEXT NOT NULL,                   -- Provider name, e.g. alpha_vantage
+  day      TEXT NOT NULL,                   -- UTC day, ISO yyyy-mm-dd
+  requests INTEGER NOT NULL DEFAULT 0,      -- Requests made against the quota
+  updated_at TEXT NOT NULL DEFAULT (datetime('now')),
+  PRIMARY KEY(provider, day)
+) STRICT;
+`
+
+// dropAPIQuota is the down migration for version 2
+const dropAPIQuota = `
+DROP TABLE IF EXISTS api_quota;
+`
+
+// createCorporateActions is the up migration for version 3
+const createCorporateActions = `
+CREATE TABLE IF NOT EXISTS corporate_actions(
+  symbol   TEXT NOT NULL REFERENCES symbols(symbol) ON DELETE CASCADE,
+  date     TEXT NOT NULL,                   -- Ex-date, ISO yyyy-mm-dd
+  dividend REAL NOT NULL DEFAULT 0,         -- Cash dividend per share
+  split_coefficient REAL NOT NULL DEFAULT 1, -- New shares per old share (2.0 = 2-for-1)
+  source   TEXT,                            -- Provider the action came from
+  created_at TEXT NOT NULL DEFAULT (datetime('now')),
+  PRIMARY KEY(symbol, date)
+) STRICT;
+`
+
+// dropCorporateActions is the down migration for version 3
+const dropCorporateActions = `
+DROP TABLE IF EXISTS corporate_actions;
+`
+
+// addSymbolMetadata is the up migration for version 4
+const addSymbolMetadata = `
+ALTER TABLE symbols ADD COLUMN exchange TEXT;
+ALTER TABLE symbols ADD COLUMN sector TEXT;
+ALTER TABLE symbols ADD COLUMN currency TEXT;
+ALTER TABLE symbols ADD COLUMN metadata_updated_at TEXT; -- Last metadata refresh, NULL if never
+`
+
+// dropSymbolMetadata is the down migration for version 4
+const dropSymbolMetadata = `
+ALTER TABLE symbols DROP COLUMN metadata_updated_at;
+ALTER TABLE symbols DROP COLUMN currency;
+ALTER TABLE symbols DROP COLUMN sector;
+ALTER TABLE symbols DROP COLUMN exchange;
+`
+
+// createPendingFetches is the up migration for version 5
+const createPendingFetches = `
+-- Symbols a refresh could not fetch (quota exhausted or canceled); the next
+-- refresh fetches them first
+CREATE TABLE IF NOT EXISTS pending_fetches(
+  symbol    TEXT PRIMARY KEY REFERENCES symbols(symbol) ON DELETE CASCADE,
+  reason    TEXT,
+  queued_at TEXT NOT NULL DEFAULT (datetime('now'))
+) STRICT;
+`
+
+// dropPendingFetches is the down migration for version 5
+const dropPendingFetches = `
+DROP TABLE IF EXISTS pending_fetches;
+`
+
+// addRunKind is the up migration for version 6. Runs recorded before it stay
+// 'refresh': nothing stored tells an earlier import from a refresh, since both
+// log their fetches the same way and notes are free text.
+const addRunKind = `
+ALTER TABLE runs ADD COLUMN kind TEXT NOT NULL DEFAULT 'refresh'; -- refresh or import
+`
+
+// dropRunKind is the down migration for version 6
+const dropRunKind = `
+ALTER TABLE runs DROP COLUMN kind;
+`
+
+// createQualityFindings is the up migration for version 7
+const createQualityFindings = `
+-- Problems found by the data quality pass over a symbol's stored prices; each
+-- pass replaces the symbol's previous findings
+CREATE TABLE IF NOT EXISTS quality_findings(
+  symbol     TEXT NOT NULL REFERENCES symbols(symbol) ON DELETE CASCADE,
+  date       TEXT NOT NULL,                 -- Affected bar, or first missing day of a gap
+  check_name TEXT NOT NULL,                 -- missing_days, bad_price, high_low, ohlc_range, split_jump, jump, volume_gap
+  severity   TEXT NOT NULL CHECK(severity IN ('WARN','ERROR')),
+  detail     TEXT,
+  run_id     INTEGER REFERENCES runs(run_id) ON DELETE SET NULL, -- Run that found it, NULL outside a run
+  created_at TEXT NOT NULL DEFAULT (datetime('now')),
+  PRIMARY KEY(symbol, date, check_name)
+) STRICT;
+
+CREATE INDEX IF NOT EXISTS idx_quality_findings_run
+  ON quality_findings(run_id);
+`
+
+// dropQualityFindings is the down migration for version 7
+const dropQualityFindings = `
+DROP INDEX IF EXISTS idx_quality_findings_run;
+DROP TABLE IF EXISTS quality_findings;
+`
+
+// createIndicatorValues is the up migration for version 8
+const createIndicatorValues = `
+-- Configured indicators (indicators section of the config), one row per
+-- symbol, date and indicator name
+CREATE TABLE IF NOT EXISTS indicator_values(
+  symbol TEXT NOT NULL,
+  date   TEXT NOT NULL,
+  name   TEXT NOT NULL,                     -- Indicator name from the config, e.g. r12_1
+  value  REAL NOT NULL,
+  PRIMARY KEY(symbol, date, name),
+  FOREIGN KEY(symbol, date) REFERENCES indicators(symbol, date) ON DELETE CASCADE
+) STRICT;
+
+CREATE INDEX IF NOT EXISTS idx_indicator_values_date
+  ON indicator_values(date, name);
+`
+
+// dropIndicatorValues is the down migration for version 8
+const dropIndicatorValues = `
+DROP INDEX IF EXISTS idx_indicator_values_date;
+DROP TABLE IF EXISTS indicator_values;
+`
+
+// addStrategy is the up migration for version 9. Rankings are keyed by the
+// strategy that computed them, so each strategy keeps its own history and
+// switching scoring.strategy never mixes or discards rankings. SQLite cannot
+// change a primary key in place, so indicators and the indicator_values that
+// reference them are rebuilt; rows stored before it become momentum's.
+const addStrategy = `
+CREATE TABLE indicators_v9(
+  symbol TEXT NOT NULL,
+  date   TEXT NOT NULL,
+  strategy TEXT NOT NULL DEFAULT 'momentum', -- Scoring strategy behind score and rank
+  r_1m   REAL,
+  r_3m   REAL,
+  r_6m   REAL,
+  r_12m  REAL,
+  vol_3m REAL,
+  vol_6m REAL,
+  adv    REAL,
+  score  REAL,
+  rank   INTEGER,
+  created_at TEXT NOT NULL DEFAULT (datetime('now')),
+  PRIMARY KEY(symbol, date, strategy),
+  FOREIGN KEY(symbol, date) REFERENCES prices(symbol, date) ON DELETE CASCADE
+) STRICT;
+
+INSERT INTO indicators_v9 (symbol, date, r_1m, r_3m, r_6m, r_12m, vol_3m, vol_6m, adv, score, rank, created_at)
+  SELECT symbol, date, r_1m, r_3m, r_6m, r_12m, vol_3m, vol_6m, adv, score, rank, created_at
+  FROM indicators;
+
+CREATE TABLE indicator_values_v9(
+  symbol   TEXT NOT NULL,
+  date     TEXT NOT NULL,
+  strategy TEXT NOT NULL DEFAULT 'momentum',
+  name     TEXT NOT NULL,
+  value    REAL NOT NULL,
+  PRIMARY KEY(symbol, date, strategy, name),
+  FOREIGN KEY(symbol, date, strategy) REFERENCES indicators_v9(symbol, date, strategy) ON DELETE CASCADE
+) STRICT;
+
+INSERT INTO indicator_values_v9 (symbol, date, name, value)
+  SELECT symbol, date, name, value FROM indicator_values;
+
+-- Renaming the new tables points indicator_values' foreign key at indicators
+DROP TABLE indicator_values;
+DROP TABLE indicators;
+ALTER TABLE indicators_v9 RENAME TO indicators;
+ALTER TABLE indicator_values_v9 RENAME TO indicator_values;
+
+CREATE INDEX IF NOT EXISTS idx_indicators_date_rank
+  ON indicators(strategy, date DESC, rank);
+CREATE INDEX IF NOT EXISTS idx_indicator_values_date
+  ON indicator_values(date, name);
+
+ALTER TABLE runs ADD COLUMN strategy TEXT; -- Scoring strategy of the rankings computed, NULL if none
+`
+
+// dropStrategy is the down migration for version 9. Only one ranking per
+// symbol and date fits the earlier key: momentum's where it has one.
+const dropStrategy = `
+ALTER TABLE runs DROP COLUMN strategy;
+
+CREATE TABLE indicators_v8(
+  symbol TEXT NOT NULL,
+  date   TEXT NOT NULL,
+  r_1m   REAL,
+  r_3m   REAL,
+  r_6m   REAL,
+  r_12m  REAL,
+  vol_3m REAL,
+  vol_6m REAL,
+  adv    REAL,
+  score  REAL,
+  rank   INTEGER,
+  created_at TEXT NOT NULL DEFAULT (datetime('now')),
+  PRIMARY KEY(symbol, date),
+  FOREIGN KEY(symbol, date) REFERENCES prices(symbol, date) ON DELETE CASCADE
+) STRICT;
+
+INSERT OR IGNORE INTO indicators_v8 (symbol, date, r_1m, r_3m, r_6m, r_12m, vol_3m, vol_6m, adv, score, rank, created_at)
+  SELECT symbol, date, r_1m, r_3m, r_6m, r_12m, vol_3m, vol_6m, adv, score, rank, created_at
+  FROM indicators
+  ORDER BY strategy <> 'momentum';
+
+CREATE TABLE indicator_values_v8(
+  symbol TEXT NOT NULL,
+  date   TEXT NOT NULL,
+  name   TEXT NOT NULL,
+  value  REAL NOT NULL,
+  PRIMARY KEY(symbol, date, name),
+  FOREIGN KEY(symbol, date) REFERENCES indicators_v8(symbol, date) ON DELETE CASCADE
+) STRICT;
+
+-- Values do not depend on the strategy, so any copy will do
+INSERT OR IGNORE INTO indicator_values_v8 (symbol, date, name, value)
+  SELECT symbol, date, name, value FROM indicator_values;
+
+DROP TABLE indicator_values;
+DROP TABLE indicators;
+ALTER TABLE indicators_v8 RENAME TO indicators;
+ALTER TABLE indicator_values_v8 RENAME TO indicator_values;
+
+CREATE INDEX IF NOT EXISTS idx_indicators_date_rank
+  ON indicators(date DESC, rank);
+CREATE INDEX IF NOT EXISTS idx_indicator_values_date
+  ON indicator_values(date, name);
+`
+
+// addFetchLogBackfill is the up migration for version 10. Backfills were
+// previously only told apart by their message, which marks the existing ones.
+const addFetchLogBackfill = `
+ALTER TABLE fetch_log ADD COLUMN backfill INTEGER NOT NULL DEFAULT 0; -- 1 if the fetch filled a gap, from_dt/to_dt holding its range
+
+UPDATE fetch_log SET backfill = 1 WHERE ok = 1 AND msg LIKE 'backfill%';
+`
+
+// dropFetchLogBackfill is the down migration for version 10
+const dropFetchLogBackfill = `
+ALTER TABLE fetch_log DROP COLUMN backfill;
+`
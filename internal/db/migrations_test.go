@@ -24,15 +24,15 @@ func TestMigrate(t *testing.T) {
 	var count int
 	err = db.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&count)
 	require.NoError(t, err)
-	assert.Equal(t, 1, count) // One migration applied
+	assert.Equal(t, len(allMigrations()), count) // All migrations applied
 
-	// Verify current version is 1
+	// Verify current version is the latest
 	version, err := db.getCurrentVersion()
 	require.NoError(t, err)
-	assert.Equal(t, 1, version)
+	assert.Equal(t, len(allMigrations()), version)
 
 	// Verify all tables were created
-	tables := []string{"symbols", "prices", "indicators", "runs", "fetch_log"}
+	tables := []string{"symbols", "prices", "indicators", "runs", "fetch_log", "api_quota", "corporate_actions", "pending_fetches", "quality_findings", "indicator_values"}
 	for _, table := range tables {
 		err = db.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&count)
 		assert.NoError(t, err, "Table %s should exist", table)
@@ -54,11 +54,11 @@ func TestMigrateIdempotent(t *testing.T) {
 	err = db.Migrate()
 	require.NoError(t, err)
 
-	// Should still have only one migration record
+	// Should still have one record per migration
 	var count int
 	err = db.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&count)
 	require.NoError(t, err)
-	assert.Equal(t, 1, count)
+	assert.Equal(t, len(allMigrations()), count)
 }
 
 func TestGetAppliedMigrations(t *testing.T) {
@@ -74,7 +74,7 @@ func TestGetAppliedMigrations(t *testing.T) {
 
 	migrations, err := db.GetAppliedMigrations()
 	require.NoError(t, err)
-	require.Len(t, migrations, 1)
+	require.Len(t, migrations, len(allMigrations()))
 
 	assert.Equal(t, 1, migrations[0].Version)
 	assert.Contains(t, migrations[0].Description, "Initial schema")
@@ -98,9 +98,11 @@ func TestRollback(t *testing.T) {
 	err = db.QueryRow("SELECT COUNT(*) FROM symbols").Scan(&count)
 	require.NoError(t, err)
 
-	// Rollback
-	err = db.Rollback()
-	require.NoError(t, err)
+	// Roll back every migration
+	for range allMigrations() {
+		err = db.Rollback()
+		require.NoError(t, err)
+	}
 
 	// Verify tables are gone
 	err = db.QueryRow("SELECT COUNT(*) FROM symbols").Scan(&count)
@@ -158,3 +160,49 @@ func TestSchemaIndexes(t *testing.T) {
 		assert.Equal(t, 1, count, "Index %s should exist", idx)
 	}
 }
+
+func TestMigrateStrategyKey(t *testing.T) {
+	db, err := New(Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	require.NoError(t, err)
+	defer db.Close()
+
+	// A database at version 8 with a stored ranking
+	require.NoError(t, db.initMigrationsTable())
+	for _, m := range allMigrations()[:8] {
+		require.NoError(t, db.applyMigration(m))
+	}
+	for _, stmt := range []string{
+		`INSERT INTO symbols (symbol) VALUES ('SPY')`,
+		`INSERT INTO prices (symbol, date, open, high, low, close) VALUES ('SPY', '2025-10-03', 1, 1, 1, 1)`,
+		`INSERT INTO indicators (symbol, date, score, rank) VALUES ('SPY', '2025-10-03', 0.5, 1)`,
+		`INSERT INTO indicator_values (symbol, date, name, value) VALUES ('SPY', '2025-10-03', 'beta', 1)`,
+	} {
+		_, err := db.Exec(stmt)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, db.Migrate())
+
+	// The existing ranking became momentum's, and another strategy's fits beside it
+	var strategy string
+	require.NoError(t, db.QueryRow(`SELECT strategy FROM indicator_values WHERE name = 'beta'`).Scan(&strategy))
+	assert.Equal(t, "momentum", strategy)
+	_, err = db.Exec(`INSERT INTO indicators (symbol, date, strategy, rank) VALUES ('SPY', '2025-10-03', 'rank_sum', 1)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO indicator_values (symbol, date, strategy, name, value) VALUES ('SPY', '2025-10-03', 'rank_sum', 'beta', 1)`)
+	require.NoError(t, err)
+
+	// Values go with their own strategy's indicators
+	_, err = db.Exec(`DELETE FROM indicators WHERE strategy = 'rank_sum'`)
+	require.NoError(t, err)
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM indicator_values`).Scan(&count))
+	assert.Equal(t, 1, count)
+
+	// Rolling back keeps one ranking per symbol and date
+	require.NoError(t, db.Rollback())
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM indicators`).Scan(&count))
+	assert.Equal(t, 1, count)
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM indicator_values`).Scan(&count))
+	assert.Equal(t, 1, count)
+}
@@ -11,9 +11,14 @@ type Symbol struct {
 	Symbol    string
 	Name      string
 	AssetType string
+	Exchange  string // Empty until metadata has been fetched
+	Sector    string
+	Currency  string
 	Active    bool
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	MetadataUpdatedAt *time.Time // Last metadata refresh, nil if never
 }
 
 // Price represents a single day's OHLCV data
@@ -42,18 +47,32 @@ type Indicator struct {
 	ADV       *float64 // Average dollar volume
 	Score     *float64 // Composite momentum score
 	Rank      *int     // Rank within universe
+	Values    map[string]float64 // Configured indicators by name, from indicator_values
+	Strategy  string             // Scoring strategy that produced Score and Rank; empty stores DefaultStrategy
 	CreatedAt time.Time
 }
 
+// DefaultStrategy is the scoring strategy of indicators stored or looked up
+// without one, including those stored before strategies were recorded
+const DefaultStrategy = "momentum"
+
+// Run kinds
+const (
+	RunKindRefresh = "refresh"
+	RunKindImport  = "import"
+)
+
 // Run represents a data refresh/computation run
 type Run struct {
 	RunID            int64
+	Kind             string // refresh or import
 	StartedAt        time.Time
 	FinishedAt       *time.Time
 	Status           string // RUNNING, OK, ERROR
 	SymbolsProcessed int
 	SymbolsFailed    int
 	Notes            *string
+	Strategy         *string // Scoring strategy of the rankings the run computed, nil if none
 }
 
 // FetchLog represents a log entry for a symbol fetch
@@ -65,9 +84,38 @@ type FetchLog struct {
 	Rows      int
 	OK        bool
 	Message   *string
+	Backfill  bool // The fetch filled a gap; FromDate and ToDate hold the gap's range
 	FetchedAt time.Time
 }
 
+// CorporateAction represents a dividend and/or split on a symbol's ex-date
+type CorporateAction struct {
+	Symbol           string
+	Date             string  // ISO format: yyyy-mm-dd
+	Dividend         float64 // Cash dividend per share, 0 if none
+	SplitCoefficient float64 // New shares per old share, 1 if none
+	Source           *string // Provider the action came from
+	CreatedAt        time.Time
+}
+
+// PendingFetch is a symbol carried over to the next refresh
+type PendingFetch struct {
+	Symbol   string
+	Reason   string
+	QueuedAt time.Time
+}
+
+// QualityFinding is a data quality problem found in a symbol's stored prices
+type QualityFinding struct {
+	Symbol    string
+	Date      string // ISO format: yyyy-mm-dd
+	Check     string // missing_days, bad_price, high_low, ohlc_range, split_jump, jump, volume_gap
+	Severity  string // WARN or ERROR
+	Detail    string
+	RunID     *int64 // Run that found it, nil outside a run
+	CreatedAt time.Time
+}
+
 // SymbolRepository provides data access for symbols
 type SymbolRepository struct {
 	db *DB
@@ -92,36 +140,51 @@ func (r *SymbolRepository) Create(s *Symbol) error {
 	return err
 }
 
-// Get retrieves a symbol by its ticker
-func (r *SymbolRepository) Get(symbol string) (*Symbol, error) {
-	query := `
-		SELECT symbol, name, asset_type, active, created_at, updated_at
-		FROM symbols
-		WHERE symbol = ?
-	`
+// symbolColumns is the column list scanned by scanSymbol
+const symbolColumns = `symbol, name, asset_type, COALESCE(exchange, ''), COALESCE(sector, ''),
+	COALESCE(currency, ''), active, created_at, updated_at, metadata_updated_at`
+
+// scanSymbol scans a row selected with symbolColumns
+func scanSymbol(scan func(dest ...any) error) (*Symbol, error) {
 	var s Symbol
 	var active int
 	var createdAt, updatedAt string
-	err := r.db.QueryRow(query, symbol).Scan(
-		&s.Symbol, &s.Name, &s.AssetType, &active, &createdAt, &updatedAt,
-	)
+	var metadataUpdatedAt sql.NullString
+	err := scan(&s.Symbol, &s.Name, &s.AssetType, &s.Exchange, &s.Sector, &s.Currency,
+		&active, &createdAt, &updatedAt, &metadataUpdatedAt)
 	if err != nil {
 		return nil, err
 	}
 	s.Active = active == 1
 	s.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
 	s.UpdatedAt, _ = time.Parse("2006-01-02 15:04:05", updatedAt)
+	if metadataUpdatedAt.Valid {
+		if t, err := time.Parse("2006-01-02 15:04:05", metadataUpdatedAt.String); err == nil {
+			s.MetadataUpdatedAt = &t
+		}
+	}
 	return &s, nil
 }
 
+// Get retrieves a symbol by its ticker
+func (r *SymbolRepository) Get(symbol string) (*Symbol, error) {
+	query := `SELECT ` + symbolColumns + ` FROM symbols WHERE symbol = ?`
+	return scanSymbol(r.db.QueryRow(query, symbol).Scan)
+}
+
 // ListActive returns all active symbols
 func (r *SymbolRepository) ListActive() ([]Symbol, error) {
-	query := `
-		SELECT symbol, name, asset_type, active, created_at, updated_at
-		FROM symbols
-		WHERE active = 1
-		ORDER BY symbol
-	`
+	return r.list(`WHERE active = 1`)
+}
+
+// ListAll returns all symbols, active or not
+func (r *SymbolRepository) ListAll() ([]Symbol, error) {
+	return r.list(``)
+}
+
+// list returns the symbols matching an optional WHERE clause, ordered by symbol
+func (r *SymbolRepository) list(where string) ([]Symbol, error) {
+	query := `SELECT ` + symbolColumns + ` FROM symbols ` + where + ` ORDER BY symbol`
 	rows, err := r.db.Query(query)
 	if err != nil {
 		return nil, err
@@ -130,16 +193,11 @@ func (r *SymbolRepository) ListActive() ([]Symbol, error) {
 
 	var symbols []Symbol
 	for rows.Next() {
-		var s Symbol
-		var active int
-		var createdAt, updatedAt string
-		if err := rows.Scan(&s.Symbol, &s.Name, &s.AssetType, &active, &createdAt, &updatedAt); err != nil {
+		s, err := scanSymbol(rows.Scan)
+		if err != nil {
 			return nil, err
 		}
-		s.Active = active == 1
-		s.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
-		s.UpdatedAt, _ = time.Parse("2006-01-02 15:04:05", updatedAt)
-		symbols = append(symbols, s)
+		symbols = append(symbols, *s)
 	}
 	return symbols, rows.Err()
 }
@@ -159,6 +217,19 @@ func (r *SymbolRepository) Update(s *Symbol) error {
 	return err
 }
 
+// UpdateMetadata stores a symbol's name, asset type, exchange, sector and
+// currency and marks its metadata as refreshed now
+func (r *SymbolRepository) UpdateMetadata(s *Symbol) error {
+	query := `
+		UPDATE symbols
+		SET name = ?, asset_type = ?, exchange = NULLIF(?, ''), sector = NULLIF(?, ''),
+			currency = NULLIF(?, ''), metadata_updated_at = datetime('now'), updated_at = datetime('now')
+		WHERE symbol = ?
+	`
+	_, err := r.db.Exec(query, s.Name, s.AssetType, s.Exchange, s.Sector, s.Currency, s.Symbol)
+	return err
+}
+
 // PriceRepository provides data access for prices
 type PriceRepository struct {
 	db *DB
@@ -216,6 +287,34 @@ func (r *PriceRepository) UpsertBatch(prices []Price) error {
 	return tx.Commit()
 }
 
+// UpdateAdjCloses overwrites adj_close for existing price rows in one transaction.
+// Only Symbol, Date and AdjClose of each price are used.
+func (r *PriceRepository) UpdateAdjCloses(prices []Price) error {
+	if len(prices) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`UPDATE prices SET adj_close = ? WHERE symbol = ? AND date = ?`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, p := range prices {
+		if _, err := stmt.Exec(p.AdjClose, p.Symbol, p.Date); err != nil {
+			return fmt.Errorf("failed to update adj_close for %s on %s: %w", p.Symbol, p.Date, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
 // GetLatestDate returns the most recent date for which we have price data for a symbol
 func (r *PriceRepository) GetLatestDate(symbol string) (string, error) {
 	query := `SELECT MAX(date) FROM prices WHERE symbol = ?`
@@ -230,6 +329,33 @@ func (r *PriceRepository) GetLatestDate(symbol string) (string, error) {
 	return date.String, nil
 }
 
+// Count returns the number of price rows stored for a symbol
+func (r *PriceRepository) Count(symbol string) (int, error) {
+	query := `SELECT COUNT(*) FROM prices WHERE symbol = ?`
+	var count int
+	err := r.db.QueryRow(query, symbol).Scan(&count)
+	return count, err
+}
+
+// ListDates returns every date with a stored price for a symbol, oldest first
+func (r *PriceRepository) ListDates(symbol string) ([]string, error) {
+	rows, err := r.db.Query(`SELECT date FROM prices WHERE symbol = ? ORDER BY date ASC`, symbol)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var dates []string
+	for rows.Next() {
+		var date string
+		if err := rows.Scan(&date); err != nil {
+			return nil, err
+		}
+		dates = append(dates, date)
+	}
+	return dates, rows.Err()
+}
+
 // GetRange retrieves price data for a symbol within a date range
 func (r *PriceRepository) GetRange(symbol, startDate, endDate string) ([]Price, error) {
 	query := `
@@ -267,7 +393,9 @@ func NewIndicatorRepository(db *DB) *IndicatorRepository {
 	return &IndicatorRepository{db: db}
 }
 
-// UpsertBatch efficiently inserts or replaces multiple indicator records
+// UpsertBatch efficiently inserts or replaces multiple indicator records.
+// Each strategy's rankings are stored separately, so another strategy's
+// rows for the same symbols and dates are left alone.
 func (r *IndicatorRepository) UpsertBatch(indicators []Indicator) error {
 	if len(indicators) == 0 {
 		return nil
@@ -279,10 +407,48 @@ func (r *IndicatorRepository) UpsertBatch(indicators []Indicator) error {
 	}
 	defer tx.Rollback()
 
+	if err := upsertIndicators(tx, indicators); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ReplaceDate replaces the indicators strategy stored for a date, so symbols
+// left out of indicators no longer appear in that day's ranking. Rankings of
+// other strategies are untouched.
+func (r *IndicatorRepository) ReplaceDate(date, strategy string, indicators []Indicator) error {
+	strategy = strategyOrDefault(strategy)
+	for _, ind := range indicators {
+		if other := strategyOrDefault(ind.Strategy); other != strategy {
+			return fmt.Errorf("cannot replace the %s ranking for %s with indicators of strategy %s", strategy, date, other)
+		}
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Values go with their indicators (ON DELETE CASCADE)
+	if _, err := tx.Exec(`DELETE FROM indicators WHERE date = ? AND strategy = ?`, date, strategy); err != nil {
+		return fmt.Errorf("failed to clear indicators for %s: %w", date, err)
+	}
+
+	if err := upsertIndicators(tx, indicators); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// upsertIndicators inserts or replaces indicator records and their values within tx
+func upsertIndicators(tx *sql.Tx, indicators []Indicator) error {
 	stmt, err := tx.Prepare(`
-		INSERT INTO indicators (symbol, date, r_1m, r_3m, r_6m, r_12m, vol_3m, vol_6m, adv, score, rank)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-		ON CONFLICT(symbol, date) DO UPDATE SET
+		INSERT INTO indicators (symbol, date, strategy, r_1m, r_3m, r_6m, r_12m, vol_3m, vol_6m, adv, score, rank)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(symbol, date, strategy) DO UPDATE SET
 			r_1m = excluded.r_1m,
 			r_3m = excluded.r_3m,
 			r_6m = excluded.r_6m,
@@ -298,32 +464,154 @@ func (r *IndicatorRepository) UpsertBatch(indicators []Indicator) error {
 	}
 	defer stmt.Close()
 
+	deleteValues, err := tx.Prepare(`DELETE FROM indicator_values WHERE symbol = ? AND date = ? AND strategy = ?`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer deleteValues.Close()
+
+	insertValue, err := tx.Prepare(`INSERT INTO indicator_values (symbol, date, strategy, name, value) VALUES (?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer insertValue.Close()
+
 	for _, ind := range indicators {
-		if _, err := stmt.Exec(ind.Symbol, ind.Date, ind.R1M, ind.R3M, ind.R6M, ind.R12M,
+		strategy := strategyOrDefault(ind.Strategy)
+		if _, err := stmt.Exec(ind.Symbol, ind.Date, strategy, ind.R1M, ind.R3M, ind.R6M, ind.R12M,
 			ind.Vol3M, ind.Vol6M, ind.ADV, ind.Score, ind.Rank); err != nil {
 			return fmt.Errorf("failed to insert indicator for %s on %s: %w", ind.Symbol, ind.Date, err)
 		}
+
+		// Values replace the ones from the previous computation, so indicators
+		// dropped from the config do not linger
+		if _, err := deleteValues.Exec(ind.Symbol, ind.Date, strategy); err != nil {
+			return fmt.Errorf("failed to clear indicator values for %s on %s: %w", ind.Symbol, ind.Date, err)
+		}
+		for name, value := range ind.Values {
+			if _, err := insertValue.Exec(ind.Symbol, ind.Date, strategy, name, value); err != nil {
+				return fmt.Errorf("failed to insert %s for %s on %s: %w", name, ind.Symbol, ind.Date, err)
+			}
+		}
 	}
 
-	return tx.Commit()
+	return nil
 }
 
-// GetTopN returns the top N ranked symbols for a given date
-func (r *IndicatorRepository) GetTopN(date string, n int) ([]Indicator, error) {
+// strategyOrDefault returns strategy, or DefaultStrategy if it is empty
+func strategyOrDefault(strategy string) string {
+	if strategy == "" {
+		return DefaultStrategy
+	}
+	return strategy
+}
+
+// GetTopN returns the top N symbols strategy ranked for a given date
+func (r *IndicatorRepository) GetTopN(date, strategy string, n int) ([]Indicator, error) {
 	query := `
-		SELECT symbol, date, r_1m, r_3m, r_6m, r_12m, vol_3m, vol_6m, adv, score, rank, created_at
+		SELECT symbol, date, r_1m, r_3m, r_6m, r_12m, vol_3m, vol_6m, adv, score, rank, strategy, created_at
 		FROM indicators
-		WHERE date = ? AND rank IS NOT NULL
+		WHERE date = ? AND strategy = ? AND rank IS NOT NULL
 		ORDER BY rank ASC
 		LIMIT ?
 	`
-	rows, err := r.db.Query(query, date, n)
+	rows, err := r.db.Query(query, date, strategyOrDefault(strategy), n)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	return r.scanIndicators(rows)
+	indicators, err := r.scanIndicators(rows)
+	if err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if err := r.LoadValues(indicators); err != nil {
+		return nil, err
+	}
+	return indicators, nil
+}
+
+// LoadValues fills in the configured indicator values of each indicator,
+// as stored with its strategy's ranking
+func (r *IndicatorRepository) LoadValues(indicators []Indicator) error {
+	// Indicators by date and strategy, then symbol
+	type ranking struct{ date, strategy string }
+	byDate := make(map[ranking]map[string]*Indicator)
+	var dates []ranking
+	for i := range indicators {
+		ind := &indicators[i]
+		key := ranking{ind.Date, strategyOrDefault(ind.Strategy)}
+		if byDate[key] == nil {
+			byDate[key] = make(map[string]*Indicator)
+			dates = append(dates, key)
+		}
+		byDate[key][ind.Symbol] = ind
+	}
+
+	for _, key := range dates {
+		rows, err := r.db.Query(`SELECT symbol, name, value FROM indicator_values WHERE date = ? AND strategy = ?`, key.date, key.strategy)
+		if err != nil {
+			return fmt.Errorf("failed to query indicator values for %s: %w", key.date, err)
+		}
+		for rows.Next() {
+			var symbol, name string
+			var value float64
+			if err := rows.Scan(&symbol, &name, &value); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan indicator value: %w", err)
+			}
+			ind := byDate[key][symbol]
+			if ind == nil {
+				continue
+			}
+			if ind.Values == nil {
+				ind.Values = make(map[string]float64)
+			}
+			ind.Values[name] = value
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return fmt.Errorf("error iterating indicator values: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// LatestRanks returns each symbol's rank on the most recent date strategy
+// ranked it
+func (r *IndicatorRepository) LatestRanks(strategy string) (map[string]int, error) {
+	query := `
+		SELECT i.symbol, i.rank
+		FROM indicators i
+		JOIN (
+			SELECT symbol, MAX(date) AS date
+			FROM indicators
+			WHERE strategy = ? AND rank IS NOT NULL
+			GROUP BY symbol
+		) latest ON latest.symbol = i.symbol AND latest.date = i.date
+		WHERE i.strategy = ?
+	`
+	strategy = strategyOrDefault(strategy)
+	rows, err := r.db.Query(query, strategy, strategy)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ranks := make(map[string]int)
+	for rows.Next() {
+		var symbol string
+		var rank int
+		if err := rows.Scan(&symbol, &rank); err != nil {
+			return nil, err
+		}
+		ranks[symbol] = rank
+	}
+	return ranks, rows.Err()
 }
 
 // scanIndicators is a helper to scan indicator rows
@@ -333,7 +621,7 @@ func (r *IndicatorRepository) scanIndicators(rows *sql.Rows) ([]Indicator, error
 		var ind Indicator
 		var createdAt string
 		if err := rows.Scan(&ind.Symbol, &ind.Date, &ind.R1M, &ind.R3M, &ind.R6M, &ind.R12M,
-			&ind.Vol3M, &ind.Vol6M, &ind.ADV, &ind.Score, &ind.Rank, &createdAt); err != nil {
+			&ind.Vol3M, &ind.Vol6M, &ind.ADV, &ind.Score, &ind.Rank, &ind.Strategy, &createdAt); err != nil {
 			return nil, err
 		}
 		ind.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
@@ -352,10 +640,21 @@ func NewRunRepository(db *DB) *RunRepository {
 	return &RunRepository{db: db}
 }
 
-// Create starts a new run and returns its ID
+// Create starts a new refresh run and returns its ID
 func (r *RunRepository) Create(notes string) (int64, error) {
-	query := `INSERT INTO runs (status, notes) VALUES ('RUNNING', ?)`
-	result, err := r.db.Exec(query, sql.NullString{String: notes, Valid: notes != ""})
+	return r.CreateKind(RunKindRefresh, notes)
+}
+
+// SetStrategy records the scoring strategy of the rankings a run computed
+func (r *RunRepository) SetStrategy(runID int64, strategy string) error {
+	_, err := r.db.Exec(`UPDATE runs SET strategy = ? WHERE run_id = ?`, strategy, runID)
+	return err
+}
+
+// CreateKind starts a new run of the given kind and returns its ID
+func (r *RunRepository) CreateKind(kind, notes string) (int64, error) {
+	query := `INSERT INTO runs (kind, status, notes) VALUES (?, 'RUNNING', ?)`
+	result, err := r.db.Exec(query, kind, sql.NullString{String: notes, Valid: notes != ""})
 	if err != nil {
 		return 0, err
 	}
@@ -376,19 +675,46 @@ func (r *RunRepository) Finish(runID int64, status string, symbolsProcessed, sym
 	return err
 }
 
-// GetLatest returns the most recent run
-func (r *RunRepository) GetLatest() (*Run, error) {
+// Reopen marks a finished or interrupted run as RUNNING again and appends note
+func (r *RunRepository) Reopen(runID int64, note string) error {
+	query := `
+		UPDATE runs
+		SET finished_at = NULL,
+			status = 'RUNNING',
+			notes = CASE WHEN COALESCE(notes, '') = '' THEN ? ELSE notes || '; ' || ? END
+		WHERE run_id = ?
+	`
+	_, err := r.db.Exec(query, note, note, runID)
+	return err
+}
+
+// MarkStale marks runs still RUNNING that started before the given time as
+// ERROR, appending note. Returns the number of runs marked.
+func (r *RunRepository) MarkStale(startedBefore time.Time, note string) (int64, error) {
 	query := `
-		SELECT run_id, started_at, finished_at, status, symbols_processed, symbols_failed, notes
-		FROM runs
-		ORDER BY run_id DESC
-		LIMIT 1
+		UPDATE runs
+		SET finished_at = datetime('now'),
+			status = 'ERROR',
+			notes = CASE WHEN COALESCE(notes, '') = '' THEN ? ELSE notes || '; ' || ? END
+		WHERE status = 'RUNNING' AND started_at < ?
 	`
+	result, err := r.db.Exec(query, note, note, startedBefore.UTC().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// runColumns is the column list scanned by scanRun
+const runColumns = `run_id, kind, started_at, finished_at, status, symbols_processed, symbols_failed, notes, strategy`
+
+// scanRun scans a row selected with runColumns
+func scanRun(scan func(dest ...any) error) (*Run, error) {
 	var run Run
-	var startedAt, finishedAt, notes sql.NullString
-	err := r.db.QueryRow(query).Scan(
-		&run.RunID, &startedAt, &finishedAt, &run.Status,
-		&run.SymbolsProcessed, &run.SymbolsFailed, &notes,
+	var startedAt, finishedAt, notes, strategy sql.NullString
+	err := scan(
+		&run.RunID, &run.Kind, &startedAt, &finishedAt, &run.Status,
+		&run.SymbolsProcessed, &run.SymbolsFailed, &notes, &strategy,
 	)
 	if err != nil {
 		return nil, err
@@ -402,10 +728,25 @@ func (r *RunRepository) GetLatest() (*Run, error) {
 	if notes.Valid {
 		run.Notes = &notes.String
 	}
+	if strategy.Valid {
+		run.Strategy = &strategy.String
+	}
 
 	return &run, nil
 }
 
+// GetLatest returns the most recent run
+func (r *RunRepository) GetLatest() (*Run, error) {
+	query := `SELECT ` + runColumns + ` FROM runs ORDER BY run_id DESC LIMIT 1`
+	return scanRun(r.db.QueryRow(query).Scan)
+}
+
+// GetLatestKind returns the most recent run of the given kind
+func (r *RunRepository) GetLatestKind(kind string) (*Run, error) {
+	query := `SELECT ` + runColumns + ` FROM runs WHERE kind = ? ORDER BY run_id DESC LIMIT 1`
+	return scanRun(r.db.QueryRow(query, kind).Scan)
+}
+
 // FetchLogRepository provides data access for fetch logs
 type FetchLogRepository struct {
 	db *DB
@@ -416,24 +757,37 @@ func NewFetchLogRepository(db *DB) *FetchLogRepository {
 	return &FetchLogRepository{db: db}
 }
 
-// Log records a fetch attempt
+// Log records a fetch attempt, replacing an earlier attempt for the same
+// symbol in the run (a resumed run retries its failed symbols)
 func (r *FetchLogRepository) Log(entry *FetchLog) error {
 	query := `
-		INSERT INTO fetch_log (run_id, symbol, from_dt, to_dt, rows, ok, msg)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO fetch_log (run_id, symbol, from_dt, to_dt, rows, ok, msg, backfill)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(run_id, symbol) DO UPDATE SET
+			from_dt = excluded.from_dt,
+			to_dt = excluded.to_dt,
+			rows = excluded.rows,
+			ok = excluded.ok,
+			msg = excluded.msg,
+			backfill = excluded.backfill,
+			fetched_at = datetime('now')
 	`
 	ok := 0
 	if entry.OK {
 		ok = 1
 	}
-	_, err := r.db.Exec(query, entry.RunID, entry.Symbol, entry.FromDate, entry.ToDate, entry.Rows, ok, entry.Message)
+	backfill := 0
+	if entry.Backfill {
+		backfill = 1
+	}
+	_, err := r.db.Exec(query, entry.RunID, entry.Symbol, entry.FromDate, entry.ToDate, entry.Rows, ok, entry.Message, backfill)
 	return err
 }
 
 // GetFailures returns all failed fetch attempts for a run
 func (r *FetchLogRepository) GetFailures(runID int64) ([]FetchLog, error) {
 	query := `
-		SELECT run_id, symbol, from_dt, to_dt, rows, ok, msg, fetched_at
+		SELECT run_id, symbol, from_dt, to_dt, rows, ok, msg, backfill, fetched_at
 		FROM fetch_log
 		WHERE run_id = ? AND ok = 0
 		ORDER BY symbol
@@ -444,17 +798,368 @@ func (r *FetchLogRepository) GetFailures(runID int64) ([]FetchLog, error) {
 	}
 	defer rows.Close()
 
+	return scanFetchLogs(rows)
+}
+
+// GetBackfills returns the successful gap backfills logged for a symbol,
+// newest first. Their from_dt/to_dt hold the backfilled range.
+func (r *FetchLogRepository) GetBackfills(symbol string) ([]FetchLog, error) {
+	query := `
+		SELECT run_id, symbol, from_dt, to_dt, rows, ok, msg, backfill, fetched_at
+		FROM fetch_log
+		WHERE symbol = ? AND ok = 1 AND backfill = 1
+		ORDER BY fetched_at DESC, run_id DESC
+	`
+	rows, err := r.db.Query(query, symbol)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanFetchLogs(rows)
+}
+
+// scanFetchLogs is a helper to scan fetch_log rows
+func scanFetchLogs(rows *sql.Rows) ([]FetchLog, error) {
 	var logs []FetchLog
 	for rows.Next() {
 		var log FetchLog
-		var ok int
+		var ok, backfill int
 		var fetchedAt string
-		if err := rows.Scan(&log.RunID, &log.Symbol, &log.FromDate, &log.ToDate, &log.Rows, &ok, &log.Message, &fetchedAt); err != nil {
+		if err := rows.Scan(&log.RunID, &log.Symbol, &log.FromDate, &log.ToDate, &log.Rows, &ok, &log.Message, &backfill, &fetchedAt); err != nil {
 			return nil, err
 		}
 		log.OK = ok == 1
+		log.Backfill = backfill == 1
 		log.FetchedAt, _ = time.Parse("2006-01-02 15:04:05", fetchedAt)
 		logs = append(logs, log)
 	}
 	return logs, rows.Err()
 }
+
+// GetSucceeded returns the symbols fetched successfully in a run
+func (r *FetchLogRepository) GetSucceeded(runID int64) ([]string, error) {
+	rows, err := r.db.Query(`SELECT symbol FROM fetch_log WHERE run_id = ? AND ok = 1 ORDER BY symbol`, runID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var symbols []string
+	for rows.Next() {
+		var symbol string
+		if err := rows.Scan(&symbol); err != nil {
+			return nil, err
+		}
+		symbols = append(symbols, symbol)
+	}
+	return symbols, rows.Err()
+}
+
+// CountByStatus returns the number of successful and failed fetches in a run
+func (r *FetchLogRepository) CountByStatus(runID int64) (succeeded, failed int, err error) {
+	query := `SELECT COALESCE(SUM(ok), 0), COALESCE(SUM(1 - ok), 0) FROM fetch_log WHERE run_id = ?`
+	err = r.db.QueryRow(query, runID).Scan(&succeeded, &failed)
+	return succeeded, failed, err
+}
+
+// QuotaRepository provides data access for API quota usage
+type QuotaRepository struct {
+	db *DB
+}
+
+// NewQuotaRepository creates a new quota repository
+func NewQuotaRepository(db *DB) *QuotaRepository {
+	return &QuotaRepository{db: db}
+}
+
+// RequestsUsed returns the number of requests recorded for a provider on a UTC day (yyyy-mm-dd)
+func (r *QuotaRepository) RequestsUsed(provider, day string) (int, error) {
+	query := `SELECT requests FROM api_quota WHERE provider = ? AND day = ?`
+	var requests int
+	err := r.db.QueryRow(query, provider, day).Scan(&requests)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return requests, nil
+}
+
+// RecordRequest increments the request count for a provider on a UTC day (yyyy-mm-dd)
+func (r *QuotaRepository) RecordRequest(provider, day string) error {
+	query := `
+		INSERT INTO api_quota (provider, day, requests)
+		VALUES (?, ?, 1)
+		ON CONFLICT(provider, day) DO UPDATE SET
+			requests = requests + 1,
+			updated_at = datetime('now')
+	`
+	_, err := r.db.Exec(query, provider, day)
+	return err
+}
+
+// ReleaseRequest hands back a request recorded for a provider on a UTC day
+// (yyyy-mm-dd) that never reached the provider
+func (r *QuotaRepository) ReleaseRequest(provider, day string) error {
+	query := `
+		UPDATE api_quota
+		SET requests = requests - 1,
+			updated_at = datetime('now')
+		WHERE provider = ? AND day = ? AND requests > 0
+	`
+	_, err := r.db.Exec(query, provider, day)
+	return err
+}
+
+// CorporateActionRepository provides data access for dividends and splits
+type CorporateActionRepository struct {
+	db *DB
+}
+
+// NewCorporateActionRepository creates a new corporate action repository
+func NewCorporateActionRepository(db *DB) *CorporateActionRepository {
+	return &CorporateActionRepository{db: db}
+}
+
+// UpsertBatch inserts or updates corporate actions in one transaction.
+// Returns the number of actions that were new or changed.
+func (r *CorporateActionRepository) UpsertBatch(actions []CorporateAction) (int, error) {
+	if len(actions) == 0 {
+		return 0, nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO corporate_actions (symbol, date, dividend, split_coefficient, source)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(symbol, date) DO UPDATE SET
+			dividend = excluded.dividend,
+			split_coefficient = excluded.split_coefficient,
+			source = excluded.source
+		WHERE dividend != excluded.dividend OR split_coefficient != excluded.split_coefficient
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	changed := 0
+	for _, a := range actions {
+		res, err := stmt.Exec(a.Symbol, a.Date, a.Dividend, a.SplitCoefficient, a.Source)
+		if err != nil {
+			return 0, fmt.Errorf("failed to upsert corporate action for %s on %s: %w", a.Symbol, a.Date, err)
+		}
+		if n, err := res.RowsAffected(); err == nil {
+			changed += int(n)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return changed, nil
+}
+
+// ListForSymbol returns all corporate actions for a symbol, oldest first
+func (r *CorporateActionRepository) ListForSymbol(symbol string) ([]CorporateAction, error) {
+	query := `
+		SELECT symbol, date, dividend, split_coefficient, source, created_at
+		FROM corporate_actions
+		WHERE symbol = ?
+		ORDER BY date ASC
+	`
+	rows, err := r.db.Query(query, symbol)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var actions []CorporateAction
+	for rows.Next() {
+		var a CorporateAction
+		var createdAt string
+		if err := rows.Scan(&a.Symbol, &a.Date, &a.Dividend, &a.SplitCoefficient, &a.Source, &createdAt); err != nil {
+			return nil, err
+		}
+		a.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+		actions = append(actions, a)
+	}
+	return actions, rows.Err()
+}
+
+// PendingFetchRepository provides data access for fetches carried over between refreshes
+type PendingFetchRepository struct {
+	db *DB
+}
+
+// NewPendingFetchRepository creates a new pending fetch repository
+func NewPendingFetchRepository(db *DB) *PendingFetchRepository {
+	return &PendingFetchRepository{db: db}
+}
+
+// List returns the pending fetches, oldest first
+func (r *PendingFetchRepository) List() ([]PendingFetch, error) {
+	query := `
+		SELECT symbol, COALESCE(reason, ''), queued_at
+		FROM pending_fetches
+		ORDER BY queued_at ASC, symbol ASC
+	`
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pending []PendingFetch
+	for rows.Next() {
+		var p PendingFetch
+		var queuedAt string
+		if err := rows.Scan(&p.Symbol, &p.Reason, &queuedAt); err != nil {
+			return nil, err
+		}
+		p.QueuedAt, _ = time.Parse("2006-01-02 15:04:05", queuedAt)
+		pending = append(pending, p)
+	}
+	return pending, rows.Err()
+}
+
+// Replace sets the pending fetches to exactly the given entries in one
+// transaction. Symbols that were already pending keep their queued_at time.
+func (r *PendingFetchRepository) Replace(pending []PendingFetch) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	keep := make(map[string]bool, len(pending))
+	for _, p := range pending {
+		keep[p.Symbol] = true
+	}
+
+	// Drop symbols that are no longer pending
+	rows, err := tx.Query(`SELECT symbol FROM pending_fetches`)
+	if err != nil {
+		return fmt.Errorf("failed to query pending fetches: %w", err)
+	}
+	var stale []string
+	for rows.Next() {
+		var symbol string
+		if err := rows.Scan(&symbol); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan pending fetch: %w", err)
+		}
+		if !keep[symbol] {
+			stale = append(stale, symbol)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating pending fetches: %w", err)
+	}
+
+	for _, symbol := range stale {
+		if _, err := tx.Exec(`DELETE FROM pending_fetches WHERE symbol = ?`, symbol); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", symbol, err)
+		}
+	}
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO pending_fetches (symbol, reason)
+		VALUES (?, NULLIF(?, ''))
+		ON CONFLICT(symbol) DO UPDATE SET reason = excluded.reason
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, p := range pending {
+		if _, err := stmt.Exec(p.Symbol, p.Reason); err != nil {
+			return fmt.Errorf("failed to queue %s: %w", p.Symbol, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// QualityFindingRepository provides data access for data quality findings
+type QualityFindingRepository struct {
+	db *DB
+}
+
+// NewQualityFindingRepository creates a new quality finding repository
+func NewQualityFindingRepository(db *DB) *QualityFindingRepository {
+	return &QualityFindingRepository{db: db}
+}
+
+// ReplaceForSymbol replaces all findings for a symbol in one transaction
+func (r *QualityFindingRepository) ReplaceForSymbol(symbol string, findings []QualityFinding) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM quality_findings WHERE symbol = ?`, symbol); err != nil {
+		return fmt.Errorf("failed to clear findings for %s: %w", symbol, err)
+	}
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO quality_findings (symbol, date, check_name, severity, detail, run_id)
+		VALUES (?, ?, ?, ?, NULLIF(?, ''), ?)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, f := range findings {
+		if _, err := stmt.Exec(symbol, f.Date, f.Check, f.Severity, f.Detail, f.RunID); err != nil {
+			return fmt.Errorf("failed to insert %s finding for %s on %s: %w", f.Check, symbol, f.Date, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListForSymbol returns the findings for a symbol, newest first
+func (r *QualityFindingRepository) ListForSymbol(symbol string) ([]QualityFinding, error) {
+	return r.list(`WHERE symbol = ? ORDER BY date DESC, check_name ASC`, symbol)
+}
+
+// ListForRun returns the findings recorded by a run, by symbol and date
+func (r *QualityFindingRepository) ListForRun(runID int64) ([]QualityFinding, error) {
+	return r.list(`WHERE run_id = ? ORDER BY symbol ASC, date ASC, check_name ASC`, runID)
+}
+
+// list returns the findings selected by the given WHERE and ORDER BY clauses
+func (r *QualityFindingRepository) list(clauses string, args ...any) ([]QualityFinding, error) {
+	rows, err := r.db.Query(`
+		SELECT symbol, date, check_name, severity, COALESCE(detail, ''), run_id, created_at
+		FROM quality_findings
+	`+clauses, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var findings []QualityFinding
+	for rows.Next() {
+		var f QualityFinding
+		var createdAt string
+		if err := rows.Scan(&f.Symbol, &f.Date, &f.Check, &f.Severity, &f.Detail, &f.RunID, &createdAt); err != nil {
+			return nil, err
+		}
+		f.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+		findings = append(findings, f)
+	}
+	return findings, rows.Err()
+}
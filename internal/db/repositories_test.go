@@ -3,6 +3,7 @@ package db
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -102,6 +103,40 @@ func TestSymbolRepository_Update(t *testing.T) {
 	assert.False(t, retrieved.Active)
 }
 
+func TestSymbolRepository_UpdateMetadata(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSymbolRepository(db)
+	require.NoError(t, repo.Create(&Symbol{Symbol: "AAPL", Name: "AAPL", AssetType: "ETF", Active: true}))
+
+	retrieved, err := repo.Get("AAPL")
+	require.NoError(t, err)
+	assert.Empty(t, retrieved.Exchange)
+	assert.Nil(t, retrieved.MetadataUpdatedAt)
+
+	err = repo.UpdateMetadata(&Symbol{
+		Symbol:    "AAPL",
+		Name:      "Apple Inc",
+		AssetType: "STOCK",
+		Exchange:  "NASDAQ",
+		Sector:    "TECHNOLOGY",
+		Currency:  "USD",
+	})
+	require.NoError(t, err)
+
+	retrieved, err = repo.Get("AAPL")
+	require.NoError(t, err)
+	assert.Equal(t, "Apple Inc", retrieved.Name)
+	assert.Equal(t, "STOCK", retrieved.AssetType)
+	assert.Equal(t, "NASDAQ", retrieved.Exchange)
+	assert.Equal(t, "TECHNOLOGY", retrieved.Sector)
+	assert.Equal(t, "USD", retrieved.Currency)
+	require.NotNil(t, retrieved.MetadataUpdatedAt)
+	assert.WithinDuration(t, time.Now(), *retrieved.MetadataUpdatedAt, time.Minute)
+	assert.True(t, retrieved.Active, "metadata updates leave the active flag alone")
+}
+
 func TestPriceRepository_Create(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
@@ -220,6 +255,15 @@ func TestPriceRepository_GetLatestDate(t *testing.T) {
 	latestDate, err = priceRepo.GetLatestDate("QQQ")
 	require.NoError(t, err)
 	assert.Empty(t, latestDate)
+
+	// Count rows
+	count, err := priceRepo.Count("SPY")
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	count, err = priceRepo.Count("QQQ")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
 }
 
 func TestRunRepository_CreateAndFinish(t *testing.T) {
@@ -247,6 +291,67 @@ func TestRunRepository_CreateAndFinish(t *testing.T) {
 	assert.NotNil(t, run.FinishedAt)
 }
 
+func TestRunRepository_SetStrategy(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewRunRepository(db)
+
+	runID, err := repo.Create("Test run")
+	require.NoError(t, err)
+
+	run, err := repo.GetLatest()
+	require.NoError(t, err)
+	assert.Nil(t, run.Strategy)
+
+	require.NoError(t, repo.SetStrategy(runID, "dual_momentum"))
+
+	run, err = repo.GetLatest()
+	require.NoError(t, err)
+	require.NotNil(t, run.Strategy)
+	assert.Equal(t, "dual_momentum", *run.Strategy)
+}
+
+func TestRunRepository_MarkStaleAndReopen(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewRunRepository(db)
+
+	staleID, err := repo.Create("CLI refresh")
+	require.NoError(t, err)
+	_, err = db.Exec(`UPDATE runs SET started_at = datetime('now', '-2 hours') WHERE run_id = ?`, staleID)
+	require.NoError(t, err)
+
+	importID, err := repo.CreateKind(RunKindImport, "CSV import (1 files)")
+	require.NoError(t, err)
+
+	// Only the run that started before the cutoff is marked
+	n, err := repo.MarkStale(time.Now().Add(-time.Hour), "interrupted")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	run, err := repo.GetLatestKind(RunKindRefresh)
+	require.NoError(t, err)
+	assert.Equal(t, staleID, run.RunID)
+	assert.Equal(t, "ERROR", run.Status)
+	assert.Equal(t, "CLI refresh; interrupted", *run.Notes)
+	assert.NotNil(t, run.FinishedAt)
+
+	latest, err := repo.GetLatest()
+	require.NoError(t, err)
+	assert.Equal(t, importID, latest.RunID)
+	assert.Equal(t, RunKindImport, latest.Kind)
+	assert.Equal(t, "RUNNING", latest.Status)
+
+	require.NoError(t, repo.Reopen(staleID, "resumed"))
+	run, err = repo.GetLatestKind(RunKindRefresh)
+	require.NoError(t, err)
+	assert.Equal(t, "RUNNING", run.Status)
+	assert.Nil(t, run.FinishedAt)
+	assert.Equal(t, "CLI refresh; interrupted; resumed", *run.Notes)
+}
+
 func TestFetchLogRepository_Log(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
@@ -294,6 +399,63 @@ func TestFetchLogRepository_Log(t *testing.T) {
 	assert.False(t, failures[0].OK)
 }
 
+func TestFetchLogRepository_LogRetryReplacesAttempt(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	runID, err := NewRunRepository(db).Create("Test run")
+	require.NoError(t, err)
+	logRepo := NewFetchLogRepository(db)
+
+	errMsg := "daily API quota exceeded"
+	require.NoError(t, logRepo.Log(&FetchLog{RunID: runID, Symbol: "SPY", OK: true, Rows: 10}))
+	require.NoError(t, logRepo.Log(&FetchLog{RunID: runID, Symbol: "QQQ", OK: false, Message: &errMsg}))
+
+	succeeded, failed, err := logRepo.CountByStatus(runID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, succeeded)
+	assert.Equal(t, 1, failed)
+
+	// A resumed run retries QQQ in the same run
+	require.NoError(t, logRepo.Log(&FetchLog{RunID: runID, Symbol: "QQQ", OK: true, Rows: 5}))
+
+	symbols, err := logRepo.GetSucceeded(runID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"QQQ", "SPY"}, symbols)
+
+	failures, err := logRepo.GetFailures(runID)
+	require.NoError(t, err)
+	assert.Empty(t, failures)
+}
+
+func TestFetchLogRepository_GetBackfills(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	runRepo := NewRunRepository(db)
+	logRepo := NewFetchLogRepository(db)
+
+	from, to := "2025-10-03", "2025-10-06"
+	note := "backfill: filled 2 of 2 missing days"
+	first, err := runRepo.Create("first")
+	require.NoError(t, err)
+	require.NoError(t, logRepo.Log(&FetchLog{RunID: first, Symbol: "SPY", FromDate: &from, ToDate: &to, OK: true, Message: &note, Backfill: true}))
+
+	// Only flagged rows count, whatever their message says
+	second, err := runRepo.Create("second")
+	require.NoError(t, err)
+	require.NoError(t, logRepo.Log(&FetchLog{RunID: second, Symbol: "SPY", OK: true, Message: &note}))
+	require.NoError(t, logRepo.Log(&FetchLog{RunID: second, Symbol: "QQQ", FromDate: &from, ToDate: &to, OK: true, Backfill: true}))
+
+	backfills, err := logRepo.GetBackfills("SPY")
+	require.NoError(t, err)
+	require.Len(t, backfills, 1)
+	assert.Equal(t, first, backfills[0].RunID)
+	assert.True(t, backfills[0].Backfill)
+	assert.Equal(t, from, *backfills[0].FromDate)
+	assert.Equal(t, to, *backfills[0].ToDate)
+}
+
 func TestIndicatorRepository_UpsertBatch(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
@@ -339,10 +501,332 @@ func TestIndicatorRepository_UpsertBatch(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify insert
-	top, err := indRepo.GetTopN("2025-10-04", 5)
+	top, err := indRepo.GetTopN("2025-10-04", DefaultStrategy, 5)
 	require.NoError(t, err)
 	assert.Len(t, top, 1)
 	assert.Equal(t, "SPY", top[0].Symbol)
 	assert.NotNil(t, top[0].R1M)
 	assert.Equal(t, 0.05, *top[0].R1M)
 }
+
+func TestIndicatorRepository_UpsertBatchStrategy(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	symRepo := NewSymbolRepository(db)
+	priceRepo := NewPriceRepository(db)
+	for _, symbol := range []string{"SPY", "QQQ"} {
+		require.NoError(t, symRepo.Create(&Symbol{Symbol: symbol, AssetType: "ETF", Active: true}))
+		for _, date := range []string{"2025-10-03", "2025-10-04"} {
+			require.NoError(t, priceRepo.Create(&Price{Symbol: symbol, Date: date, Open: 100, High: 101, Low: 99, Close: 100}))
+		}
+	}
+
+	indRepo := NewIndicatorRepository(db)
+	rank1, rank2 := 1, 2
+	require.NoError(t, indRepo.UpsertBatch([]Indicator{
+		{Symbol: "SPY", Date: "2025-10-03", Rank: &rank1, Values: map[string]float64{"beta": 1}},
+		{Symbol: "SPY", Date: "2025-10-04", Rank: &rank1},
+		{Symbol: "QQQ", Date: "2025-10-04", Rank: &rank2},
+	}))
+
+	top, err := indRepo.GetTopN("2025-10-04", DefaultStrategy, 5)
+	require.NoError(t, err)
+	require.Len(t, top, 2)
+	assert.Equal(t, DefaultStrategy, top[0].Strategy)
+
+	// Another strategy's ranking is stored next to the first, not over it
+	require.NoError(t, indRepo.UpsertBatch([]Indicator{
+		{Symbol: "QQQ", Date: "2025-10-04", Rank: &rank1, Strategy: "rank_sum"},
+	}))
+
+	top, err = indRepo.GetTopN("2025-10-04", "rank_sum", 5)
+	require.NoError(t, err)
+	require.Len(t, top, 1)
+	assert.Equal(t, "QQQ", top[0].Symbol)
+	assert.Equal(t, "rank_sum", top[0].Strategy)
+
+	top, err = indRepo.GetTopN("2025-10-04", DefaultStrategy, 5)
+	require.NoError(t, err)
+	require.Len(t, top, 2)
+	assert.Equal(t, "SPY", top[0].Symbol)
+
+	// Earlier days of one strategy never stand in for the other's
+	ranks, err := indRepo.LatestRanks("rank_sum")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"QQQ": 1}, ranks)
+	ranks, err = indRepo.LatestRanks(DefaultStrategy)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"SPY": 1, "QQQ": 2}, ranks)
+
+	// Replacing one strategy's day leaves the other's, values included
+	require.NoError(t, indRepo.ReplaceDate("2025-10-03", "rank_sum", []Indicator{
+		{Symbol: "QQQ", Date: "2025-10-03", Rank: &rank1, Strategy: "rank_sum"},
+	}))
+	top, err = indRepo.GetTopN("2025-10-03", DefaultStrategy, 5)
+	require.NoError(t, err)
+	require.Len(t, top, 1)
+	assert.Equal(t, map[string]float64{"beta": 1}, top[0].Values)
+
+	// Indicators must belong to the strategy whose day they replace
+	err = indRepo.ReplaceDate("2025-10-04", "rank_sum", []Indicator{
+		{Symbol: "SPY", Date: "2025-10-04", Rank: &rank1},
+	})
+	assert.Error(t, err)
+}
+
+func TestIndicatorRepository_Values(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	require.NoError(t, NewSymbolRepository(db).Create(&Symbol{Symbol: "SPY", Name: "S&P 500", AssetType: "ETF", Active: true}))
+	require.NoError(t, NewPriceRepository(db).Create(&Price{Symbol: "SPY", Date: "2025-10-04", Open: 100, High: 101, Low: 99, Close: 100}))
+
+	indRepo := NewIndicatorRepository(db)
+	rank := 1
+	require.NoError(t, indRepo.UpsertBatch([]Indicator{{
+		Symbol: "SPY", Date: "2025-10-04", Rank: &rank,
+		Values: map[string]float64{"r9m": 0.08, "r12_1": 0.15},
+	}}))
+
+	top, err := indRepo.GetTopN("2025-10-04", DefaultStrategy, 5)
+	require.NoError(t, err)
+	require.Len(t, top, 1)
+	assert.Equal(t, map[string]float64{"r9m": 0.08, "r12_1": 0.15}, top[0].Values)
+
+	// Recomputing replaces the previous values
+	require.NoError(t, indRepo.UpsertBatch([]Indicator{{
+		Symbol: "SPY", Date: "2025-10-04", Rank: &rank,
+		Values: map[string]float64{"r12_1": 0.2},
+	}}))
+
+	top, err = indRepo.GetTopN("2025-10-04", DefaultStrategy, 5)
+	require.NoError(t, err)
+	require.Len(t, top, 1)
+	assert.Equal(t, map[string]float64{"r12_1": 0.2}, top[0].Values)
+}
+
+func TestIndicatorRepository_ReplaceDate(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	for _, symbol := range []string{"SPY", "QQQ"} {
+		require.NoError(t, NewSymbolRepository(db).Create(&Symbol{Symbol: symbol, Name: symbol, AssetType: "ETF", Active: true}))
+		for _, date := range []string{"2025-10-03", "2025-10-06"} {
+			require.NoError(t, NewPriceRepository(db).Create(&Price{Symbol: symbol, Date: date, Open: 100, High: 101, Low: 99, Close: 100}))
+		}
+	}
+
+	indRepo := NewIndicatorRepository(db)
+	rank1, rank2 := 1, 2
+	require.NoError(t, indRepo.UpsertBatch([]Indicator{
+		{Symbol: "SPY", Date: "2025-10-03", Rank: &rank1, Values: map[string]float64{"beta": 1}},
+		{Symbol: "QQQ", Date: "2025-10-03", Rank: &rank2},
+		{Symbol: "QQQ", Date: "2025-10-06", Rank: &rank1},
+	}))
+
+	// SPY drops out of the ranking for 2025-10-03
+	require.NoError(t, indRepo.ReplaceDate("2025-10-03", DefaultStrategy, []Indicator{{Symbol: "QQQ", Date: "2025-10-03", Rank: &rank1}}))
+
+	top, err := indRepo.GetTopN("2025-10-03", DefaultStrategy, 5)
+	require.NoError(t, err)
+	require.Len(t, top, 1)
+	assert.Equal(t, "QQQ", top[0].Symbol)
+
+	var values int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM indicator_values").Scan(&values))
+	assert.Equal(t, 0, values)
+
+	// Other dates are untouched
+	top, err = indRepo.GetTopN("2025-10-06", DefaultStrategy, 5)
+	require.NoError(t, err)
+	assert.Len(t, top, 1)
+}
+
+func TestQuotaRepository_RecordRequest(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewQuotaRepository(db)
+
+	// No usage recorded yet
+	used, err := repo.RequestsUsed("alpha_vantage", "2025-10-08")
+	require.NoError(t, err)
+	assert.Equal(t, 0, used)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, repo.RecordRequest("alpha_vantage", "2025-10-08"))
+	}
+	require.NoError(t, repo.RecordRequest("alpha_vantage", "2025-10-09"))
+	require.NoError(t, repo.RecordRequest("http", "2025-10-08"))
+
+	used, err = repo.RequestsUsed("alpha_vantage", "2025-10-08")
+	require.NoError(t, err)
+	assert.Equal(t, 3, used)
+
+	// Usage is tracked per provider and per day
+	used, err = repo.RequestsUsed("alpha_vantage", "2025-10-09")
+	require.NoError(t, err)
+	assert.Equal(t, 1, used)
+
+	used, err = repo.RequestsUsed("http", "2025-10-08")
+	require.NoError(t, err)
+	assert.Equal(t, 1, used)
+}
+
+func TestQuotaRepository_ReleaseRequest(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewQuotaRepository(db)
+	require.NoError(t, repo.RecordRequest("alpha_vantage", "2025-10-08"))
+	require.NoError(t, repo.ReleaseRequest("alpha_vantage", "2025-10-08"))
+
+	// Usage never drops below zero, and releasing an unknown day is a no-op
+	require.NoError(t, repo.ReleaseRequest("alpha_vantage", "2025-10-08"))
+	require.NoError(t, repo.ReleaseRequest("alpha_vantage", "2025-10-09"))
+
+	used, err := repo.RequestsUsed("alpha_vantage", "2025-10-08")
+	require.NoError(t, err)
+	assert.Equal(t, 0, used)
+}
+
+func TestCorporateActionRepository_UpsertBatch(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	require.NoError(t, NewSymbolRepository(db).Create(&Symbol{Symbol: "SPY", Name: "SPDR S&P 500", AssetType: "ETF", Active: true}))
+
+	repo := NewCorporateActionRepository(db)
+	source := "alpha_vantage"
+	actions := []CorporateAction{
+		{Symbol: "SPY", Date: "2025-09-19", Dividend: 1.82, SplitCoefficient: 1, Source: &source},
+		{Symbol: "SPY", Date: "2025-06-20", Dividend: 1.76, SplitCoefficient: 1, Source: &source},
+	}
+
+	changed, err := repo.UpsertBatch(actions)
+	require.NoError(t, err)
+	assert.Equal(t, 2, changed)
+
+	// Re-storing identical actions changes nothing
+	changed, err = repo.UpsertBatch(actions)
+	require.NoError(t, err)
+	assert.Equal(t, 0, changed)
+
+	// A corrected split is reported as a change
+	actions[1].SplitCoefficient = 2
+	changed, err = repo.UpsertBatch(actions)
+	require.NoError(t, err)
+	assert.Equal(t, 1, changed)
+
+	list, err := repo.ListForSymbol("SPY")
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "2025-06-20", list[0].Date)
+	assert.Equal(t, 2.0, list[0].SplitCoefficient)
+	assert.Equal(t, 1.82, list[1].Dividend)
+	require.NotNil(t, list[1].Source)
+	assert.Equal(t, "alpha_vantage", *list[1].Source)
+}
+
+func TestPendingFetchRepository_Replace(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	symbolRepo := NewSymbolRepository(db)
+	for _, symbol := range []string{"SPY", "QQQ", "IWM"} {
+		require.NoError(t, symbolRepo.Create(&Symbol{Symbol: symbol, Name: symbol, AssetType: "ETF", Active: true}))
+	}
+
+	repo := NewPendingFetchRepository(db)
+	require.NoError(t, repo.Replace([]PendingFetch{{Symbol: "SPY", Reason: "quota"}, {Symbol: "QQQ", Reason: "quota"}}))
+
+	pending, err := repo.List()
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, "quota", pending[0].Reason)
+
+	// QQQ was fetched; IWM is newly deferred
+	require.NoError(t, repo.Replace([]PendingFetch{{Symbol: "SPY", Reason: "canceled"}, {Symbol: "IWM", Reason: "quota"}}))
+
+	pending, err = repo.List()
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	symbols := []string{pending[0].Symbol, pending[1].Symbol}
+	assert.ElementsMatch(t, []string{"SPY", "IWM"}, symbols)
+
+	require.NoError(t, repo.Replace(nil))
+	pending, err = repo.List()
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestIndicatorRepository_LatestRanks(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	require.NoError(t, NewSymbolRepository(db).Create(&Symbol{Symbol: "SPY", Name: "SPY", AssetType: "ETF", Active: true}))
+	require.NoError(t, NewSymbolRepository(db).Create(&Symbol{Symbol: "QQQ", Name: "QQQ", AssetType: "ETF", Active: true}))
+	priceRepo := NewPriceRepository(db)
+	for _, p := range []Price{
+		{Symbol: "SPY", Date: "2024-01-02", Open: 1, High: 1, Low: 1, Close: 1},
+		{Symbol: "SPY", Date: "2024-01-03", Open: 1, High: 1, Low: 1, Close: 1},
+		{Symbol: "QQQ", Date: "2024-01-02", Open: 1, High: 1, Low: 1, Close: 1},
+	} {
+		require.NoError(t, priceRepo.Create(&p))
+	}
+
+	rank := func(n int) *int { return &n }
+	require.NoError(t, NewIndicatorRepository(db).UpsertBatch([]Indicator{
+		{Symbol: "SPY", Date: "2024-01-02", Rank: rank(2)},
+		{Symbol: "SPY", Date: "2024-01-03", Rank: rank(1)},
+		{Symbol: "QQQ", Date: "2024-01-02", Rank: rank(3)},
+	}))
+
+	ranks, err := NewIndicatorRepository(db).LatestRanks(DefaultStrategy)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"SPY": 1, "QQQ": 3}, ranks)
+}
+
+func TestQualityFindingRepository_ReplaceForSymbol(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	require.NoError(t, NewSymbolRepository(db).Create(&Symbol{Symbol: "SPY", Name: "SPY", AssetType: "ETF", Active: true}))
+	require.NoError(t, NewSymbolRepository(db).Create(&Symbol{Symbol: "QQQ", Name: "QQQ", AssetType: "ETF", Active: true}))
+	runID, err := NewRunRepository(db).Create("test")
+	require.NoError(t, err)
+
+	repo := NewQualityFindingRepository(db)
+	require.NoError(t, repo.ReplaceForSymbol("SPY", []QualityFinding{
+		{Date: "2024-01-03", Check: "missing_days", Severity: "WARN", Detail: "1 day missing (2024-01-03)", RunID: &runID},
+		{Date: "2024-01-05", Check: "high_low", Severity: "ERROR", Detail: "high 95.00 below low 99.00", RunID: &runID},
+	}))
+	require.NoError(t, repo.ReplaceForSymbol("QQQ", []QualityFinding{
+		{Date: "2024-01-04", Check: "jump", Severity: "WARN"},
+	}))
+
+	findings, err := repo.ListForSymbol("SPY")
+	require.NoError(t, err)
+	require.Len(t, findings, 2)
+	assert.Equal(t, "2024-01-05", findings[0].Date) // Newest first
+	assert.Equal(t, "high_low", findings[0].Check)
+	require.NotNil(t, findings[0].RunID)
+	assert.Equal(t, runID, *findings[0].RunID)
+
+	byRun, err := repo.ListForRun(runID)
+	require.NoError(t, err)
+	assert.Len(t, byRun, 2) // QQQ was validated outside the run
+
+	// A clean pass clears the symbol's findings
+	require.NoError(t, repo.ReplaceForSymbol("SPY", nil))
+	findings, err = repo.ListForSymbol("SPY")
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+
+	findings, err = repo.ListForSymbol("QQQ")
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	assert.Nil(t, findings[0].RunID)
+	assert.Empty(t, findings[0].Detail)
+}
@@ -0,0 +1,115 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cajundata/momorot/internal/backtest"
+)
+
+// ExportBacktest exports a backtest's equity curve to a CSV file.
+// Filename format: backtest-YYYYMMDD.csv
+func (e *Exporter) ExportBacktest(result *backtest.Result) (string, error) {
+	if err := e.ensureExportDir(); err != nil {
+		return "", fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	// Create output file
+	dateStr := time.Now().Format("20060102")
+	filename := filepath.Join(e.exportDir, fmt.Sprintf("backtest-%s.csv", dateStr))
+	file, err := os.Create(filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	// Write header
+	header := []string{"Date", "Equity", "Drawdown", "Holdings"}
+	if err := writer.Write(header); err != nil {
+		return "", fmt.Errorf("failed to write header: %w", err)
+	}
+
+	// Write data rows
+	peak := 1.0
+	for _, p := range result.Curve {
+		if p.Equity > peak {
+			peak = p.Equity
+		}
+		drawdown := 1 - p.Equity/peak
+
+		row := []string{
+			p.Date.Format("2006-01-02"),
+			fmt.Sprintf("%.6f", p.Equity),
+			formatPercent(&drawdown),
+			strings.Join(p.Holdings, " "),
+		}
+		if err := writer.Write(row); err != nil {
+			return "", fmt.Errorf("failed to write row: %w", err)
+		}
+	}
+
+	return filename, nil
+}
+
+// ExportSweep exports a parameter sweep's comparison table to a CSV file, one
+// row per combination in the order given.
+// Filename format: sweep-YYYYMMDD.csv
+func (e *Exporter) ExportSweep(results []backtest.SweepResult) (string, error) {
+	if err := e.ensureExportDir(); err != nil {
+		return "", fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	// Create output file
+	dateStr := time.Now().Format("20060102")
+	filename := filepath.Join(e.exportDir, fmt.Sprintf("sweep-%s.csv", dateStr))
+	file, err := os.Create(filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	// Write header
+	header := []string{"Lambda", "Weights", "TopN", "TotalReturn", "CAGR", "MaxDrawdown", "Turnover", "HitRate", "Costs", "Rebalances", "Error"}
+	if err := writer.Write(header); err != nil {
+		return "", fmt.Errorf("failed to write header: %w", err)
+	}
+
+	// Write data rows
+	for _, r := range results {
+		row := []string{
+			fmt.Sprintf("%g", r.Params.Lambda),
+			r.Params.WeightsLabel(),
+			fmt.Sprintf("%d", r.Params.TopN),
+		}
+		if r.Err != nil {
+			row = append(row, "", "", "", "", "", "", "", r.Err.Error())
+		} else {
+			res := r.Result
+			row = append(row,
+				formatPercent(&res.TotalReturn),
+				formatPercent(&res.CAGR),
+				formatPercent(&res.MaxDrawdown),
+				formatPercent(&res.Turnover),
+				formatPercent(&res.HitRate),
+				formatPercent(&res.Costs),
+				fmt.Sprintf("%d", res.Rebalances),
+				"",
+			)
+		}
+		if err := writer.Write(row); err != nil {
+			return "", fmt.Errorf("failed to write row: %w", err)
+		}
+	}
+
+	return filename, nil
+}
@@ -0,0 +1,65 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/cajundata/momorot/internal/backtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportBacktest(t *testing.T) {
+	exporter := New(setupTestDB(t), t.TempDir())
+
+	day := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	result := &backtest.Result{Curve: []backtest.Point{
+		{Date: day, Equity: 1.0, Holdings: []string{"QQQ", "SPY"}},
+		{Date: day.AddDate(0, 0, 1), Equity: 1.2, Holdings: []string{"QQQ", "SPY"}},
+		{Date: day.AddDate(0, 0, 2), Equity: 0.9, Holdings: []string{"SPY", "CASH"}},
+	}}
+
+	filename, err := exporter.ExportBacktest(result)
+	require.NoError(t, err)
+	assert.Contains(t, filename, "backtest-")
+
+	file, err := os.Open(filename)
+	require.NoError(t, err)
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	assert.Equal(t, []string{"Date", "Equity", "Drawdown", "Holdings"}, records[0])
+	assert.Equal(t, []string{"2024-01-02", "1.000000", "0.00%", "QQQ SPY"}, records[1])
+	assert.Equal(t, []string{"2024-01-04", "0.900000", "25.00%", "SPY CASH"}, records[3])
+}
+
+func TestExportSweep(t *testing.T) {
+	exporter := New(setupTestDB(t), t.TempDir())
+
+	results := []backtest.SweepResult{
+		{
+			Params: backtest.Params{Lambda: 0.35, Weights: map[string]float64{"r12m": 2}, TopN: 3},
+			Result: &backtest.Result{Rebalances: 12, TotalReturn: 0.25, CAGR: 0.1, MaxDrawdown: 0.15, Turnover: 0.4, HitRate: 0.6, Costs: 0.002},
+		},
+		{Params: backtest.Params{Lambda: 0.7, TopN: 5}, Err: fmt.Errorf("no data")},
+	}
+
+	filename, err := exporter.ExportSweep(results)
+	require.NoError(t, err)
+	assert.Contains(t, filename, "sweep-")
+
+	file, err := os.Open(filename)
+	require.NoError(t, err)
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"0.35", "r12m=2", "3", "25.00%", "10.00%", "15.00%", "40.00%", "60.00%", "0.20%", "12", ""}, records[1])
+	assert.Equal(t, []string{"0.7", "equal", "5", "", "", "", "", "", "", "", "no data"}, records[2])
+}
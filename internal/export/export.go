@@ -1,10 +1,12 @@
 package export
 
 import (
+	"database/sql"
 	"encoding/csv"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/cajundata/momorot/internal/db"
@@ -14,6 +16,7 @@ import (
 type Exporter struct {
 	database  *db.DB
 	exportDir string
+	strategy  string // Scoring strategy whose rankings are exported
 }
 
 // New creates a new Exporter instance.
@@ -21,9 +24,15 @@ func New(database *db.DB, exportDir string) *Exporter {
 	return &Exporter{
 		database:  database,
 		exportDir: exportDir,
+		strategy:  db.DefaultStrategy,
 	}
 }
 
+// SetStrategy exports the rankings stored by the named scoring strategy.
+func (e *Exporter) SetStrategy(strategy string) {
+	e.strategy = strategy
+}
+
 // ensureExportDir creates the export directory if it doesn't exist.
 func (e *Exporter) ensureExportDir() error {
 	return os.MkdirAll(e.exportDir, 0755)
@@ -41,6 +50,12 @@ func (e *Exporter) ExportLeaders(topN int, date string) (string, error) {
 		date = time.Now().Format("2006-01-02")
 	}
 
+	// Configured indicators are read first; the connection is busy while rows are open
+	names, values, err := e.indicatorValuesOn(date)
+	if err != nil {
+		return "", err
+	}
+
 	// Query top N leaders for the given date
 	query := `
 		SELECT
@@ -58,12 +73,12 @@ func (e *Exporter) ExportLeaders(topN int, date string) (string, error) {
 			i.adv
 		FROM indicators i
 		JOIN symbols s ON i.symbol = s.symbol
-		WHERE i.date = ? AND i.rank IS NOT NULL
+		WHERE i.date = ? AND i.strategy = ? AND i.rank IS NOT NULL
 		ORDER BY i.rank ASC
 		LIMIT ?
 	`
 
-	rows, err := e.database.Query(query, date, topN)
+	rows, err := e.database.Query(query, date, e.strategy, topN)
 	if err != nil {
 		return "", fmt.Errorf("failed to query leaders: %w", err)
 	}
@@ -86,6 +101,7 @@ func (e *Exporter) ExportLeaders(topN int, date string) (string, error) {
 		"Rank", "Symbol", "Name", "Asset Type", "Score",
 		"R1M", "R3M", "R6M", "R12M", "Vol3M", "Vol6M", "ADV",
 	}
+	header = append(header, indicatorHeaders(names)...)
 	if err := writer.Write(header); err != nil {
 		return "", fmt.Errorf("failed to write header: %w", err)
 	}
@@ -119,6 +135,7 @@ func (e *Exporter) ExportLeaders(topN int, date string) (string, error) {
 			formatPercent(vol6m),
 			formatFloat(adv, 0),
 		}
+		row = append(row, indicatorCells(names, values[symbol])...)
 
 		if err := writer.Write(row); err != nil {
 			return "", fmt.Errorf("failed to write row: %w", err)
@@ -145,6 +162,12 @@ func (e *Exporter) ExportFullRankings(date string) (string, error) {
 		date = time.Now().Format("2006-01-02")
 	}
 
+	// Configured indicators are read first; the connection is busy while rows are open
+	names, values, err := e.indicatorValuesOn(date)
+	if err != nil {
+		return "", err
+	}
+
 	// Query all rankings for the given date
 	query := `
 		SELECT
@@ -162,11 +185,11 @@ func (e *Exporter) ExportFullRankings(date string) (string, error) {
 			i.adv
 		FROM indicators i
 		JOIN symbols s ON i.symbol = s.symbol
-		WHERE i.date = ? AND i.rank IS NOT NULL
+		WHERE i.date = ? AND i.strategy = ? AND i.rank IS NOT NULL
 		ORDER BY i.rank ASC
 	`
 
-	rows, err := e.database.Query(query, date)
+	rows, err := e.database.Query(query, date, e.strategy)
 	if err != nil {
 		return "", fmt.Errorf("failed to query rankings: %w", err)
 	}
@@ -189,6 +212,7 @@ func (e *Exporter) ExportFullRankings(date string) (string, error) {
 		"Rank", "Symbol", "Name", "Asset Type", "Score",
 		"R1M", "R3M", "R6M", "R12M", "Vol3M", "Vol6M", "ADV",
 	}
+	header = append(header, indicatorHeaders(names)...)
 	if err := writer.Write(header); err != nil {
 		return "", fmt.Errorf("failed to write header: %w", err)
 	}
@@ -222,6 +246,7 @@ func (e *Exporter) ExportFullRankings(date string) (string, error) {
 			formatPercent(vol6m),
 			formatFloat(adv, 0),
 		}
+		row = append(row, indicatorCells(names, values[symbol])...)
 
 		if err := writer.Write(row); err != nil {
 			return "", fmt.Errorf("failed to write row: %w", err)
@@ -328,6 +353,12 @@ func (e *Exporter) ExportSymbolDetail(symbol string) (string, error) {
 		return "", fmt.Errorf("failed to create export directory: %w", err)
 	}
 
+	// Configured indicators are read first; the connection is busy while rows are open
+	names, values, err := e.indicatorValuesFor(symbol)
+	if err != nil {
+		return "", err
+	}
+
 	// Query price history and indicators for the symbol
 	query := `
 		SELECT
@@ -346,15 +377,18 @@ func (e *Exporter) ExportSymbolDetail(symbol string) (string, error) {
 			i.vol_6m,
 			i.adv,
 			i.score,
-			i.rank
+			i.rank,
+			ca.dividend,
+			ca.split_coefficient
 		FROM prices p
-		LEFT JOIN indicators i ON p.symbol = i.symbol AND p.date = i.date
+		LEFT JOIN indicators i ON p.symbol = i.symbol AND p.date = i.date AND i.strategy = ?
+		LEFT JOIN corporate_actions ca ON p.symbol = ca.symbol AND p.date = ca.date
 		WHERE p.symbol = ?
 		ORDER BY p.date DESC
 		LIMIT 365
 	`
 
-	rows, err := e.database.Query(query, symbol)
+	rows, err := e.database.Query(query, e.strategy, symbol)
 	if err != nil {
 		return "", fmt.Errorf("failed to query symbol detail: %w", err)
 	}
@@ -376,7 +410,9 @@ func (e *Exporter) ExportSymbolDetail(symbol string) (string, error) {
 	header := []string{
 		"Date", "Open", "High", "Low", "Close", "AdjClose", "Volume",
 		"R1M", "R3M", "R6M", "R12M", "Vol3M", "Vol6M", "ADV", "Score", "Rank",
+		"Dividend", "Split",
 	}
+	header = append(header, indicatorHeaders(names)...)
 	if err := writer.Write(header); err != nil {
 		return "", fmt.Errorf("failed to write header: %w", err)
 	}
@@ -388,10 +424,12 @@ func (e *Exporter) ExportSymbolDetail(symbol string) (string, error) {
 		var volume *int64
 		var r1m, r3m, r6m, r12m, vol3m, vol6m, adv, score *float64
 		var rank *int
+		var dividend, split *float64
 
 		err := rows.Scan(
 			&date, &open, &high, &low, &close, &adjClose, &volume,
 			&r1m, &r3m, &r6m, &r12m, &vol3m, &vol6m, &adv, &score, &rank,
+			&dividend, &split,
 		)
 		if err != nil {
 			return "", fmt.Errorf("failed to scan row: %w", err)
@@ -414,7 +452,10 @@ func (e *Exporter) ExportSymbolDetail(symbol string) (string, error) {
 			formatFloat(adv, 0),
 			formatFloat(score, 3),
 			formatInt(rank),
+			formatFloat(dividend, 4),
+			formatFloat(split, 4),
 		}
+		row = append(row, indicatorCells(names, values[date])...)
 
 		if err := writer.Write(row); err != nil {
 			return "", fmt.Errorf("failed to write row: %w", err)
@@ -428,6 +469,89 @@ func (e *Exporter) ExportSymbolDetail(symbol string) (string, error) {
 	return filename, nil
 }
 
+// indicatorValuesOn loads the configured indicator values stored for date.
+// Returns the indicator names in alphabetical order and the values by symbol
+// and name.
+func (e *Exporter) indicatorValuesOn(date string) ([]string, map[string]map[string]float64, error) {
+	rows, err := e.database.Query(`
+		SELECT symbol, name, value
+		FROM indicator_values
+		WHERE date = ? AND strategy = ?
+		ORDER BY name
+	`, date, e.strategy)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query indicator values: %w", err)
+	}
+	defer rows.Close()
+
+	return scanIndicatorValues(rows)
+}
+
+// indicatorValuesFor loads the configured indicator values stored for symbol.
+// Returns the indicator names in alphabetical order and the values by date and
+// name.
+func (e *Exporter) indicatorValuesFor(symbol string) ([]string, map[string]map[string]float64, error) {
+	rows, err := e.database.Query(`
+		SELECT date, name, value
+		FROM indicator_values
+		WHERE symbol = ? AND strategy = ?
+		ORDER BY name
+	`, symbol, e.strategy)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query indicator values: %w", err)
+	}
+	defer rows.Close()
+
+	return scanIndicatorValues(rows)
+}
+
+// scanIndicatorValues collects (key, name, value) rows sorted by name.
+func scanIndicatorValues(rows *sql.Rows) ([]string, map[string]map[string]float64, error) {
+	var names []string
+	seen := make(map[string]bool)
+	values := make(map[string]map[string]float64)
+	for rows.Next() {
+		var key, name string
+		var value float64
+		if err := rows.Scan(&key, &name, &value); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan indicator value: %w", err)
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+		if values[key] == nil {
+			values[key] = make(map[string]float64)
+		}
+		values[key][name] = value
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error iterating indicator values: %w", err)
+	}
+
+	return names, values, nil
+}
+
+// indicatorHeaders returns the column headers for configured indicators.
+func indicatorHeaders(names []string) []string {
+	headers := make([]string, len(names))
+	for i, name := range names {
+		headers[i] = strings.ToUpper(name)
+	}
+	return headers
+}
+
+// indicatorCells formats a row's configured indicator values, empty when missing.
+func indicatorCells(names []string, values map[string]float64) []string {
+	cells := make([]string, len(names))
+	for i, name := range names {
+		if v, ok := values[name]; ok {
+			cells[i] = formatPercent(&v)
+		}
+	}
+	return cells
+}
+
 // Helper functions for formatting values
 
 func formatFloat(val *float64, precision int) string {
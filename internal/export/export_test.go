@@ -319,10 +319,12 @@ func TestExportSymbolDetail(t *testing.T) {
 	require.NoError(t, err)
 
 	// Check header
-	assert.Equal(t, 16, len(records[0]), "Header should have 16 columns")
+	assert.Equal(t, 18, len(records[0]), "Header should have 18 columns")
 	assert.Equal(t, "Date", records[0][0])
 	assert.Equal(t, "Close", records[0][4])
 	assert.Equal(t, "R1M", records[0][7])
+	assert.Equal(t, "Dividend", records[0][16])
+	assert.Equal(t, "Split", records[0][17])
 
 	// Check data rows (at least 1 price entry)
 	assert.GreaterOrEqual(t, len(records), 2, "Should have header + at least 1 data row")
@@ -331,6 +333,73 @@ func TestExportSymbolDetail(t *testing.T) {
 	assert.Equal(t, "2025-10-08", records[1][0]) // Date
 	assert.Equal(t, "450.00", records[1][4])      // Close
 	assert.Equal(t, "15.00%", records[1][7])      // R1M (0.15 * 100)
+	assert.Equal(t, "", records[1][16])           // No dividend on this date
+}
+
+func TestExportSymbolDetailCorporateActions(t *testing.T) {
+	database := setupTestDB(t)
+
+	setupTestData(t, database)
+
+	actionRepo := db.NewCorporateActionRepository(database)
+	_, err := actionRepo.UpsertBatch([]db.CorporateAction{
+		{Symbol: "SPY", Date: "2025-10-08", Dividend: 1.75, SplitCoefficient: 1},
+	})
+	require.NoError(t, err)
+
+	exporter := New(database, t.TempDir())
+	filename, err := exporter.ExportSymbolDetail("SPY")
+	require.NoError(t, err)
+
+	file, err := os.Open(filename)
+	require.NoError(t, err)
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(records), 2)
+
+	assert.Equal(t, "2025-10-08", records[1][0])
+	assert.Equal(t, "1.7500", records[1][16])
+	assert.Equal(t, "1.0000", records[1][17])
+}
+
+func TestExportConfiguredIndicators(t *testing.T) {
+	database := setupTestDB(t)
+	setupTestData(t, database)
+
+	// Configured indicators for SPY and QQQ; IWM has too little history for r12_1
+	rank1, rank2, rank3 := 1, 2, 3
+	require.NoError(t, db.NewIndicatorRepository(database).UpsertBatch([]db.Indicator{
+		{Symbol: "SPY", Date: "2025-10-08", Rank: &rank1, Values: map[string]float64{"r9m": 0.3, "r12_1": 0.4}},
+		{Symbol: "QQQ", Date: "2025-10-08", Rank: &rank2, Values: map[string]float64{"r9m": 0.2, "r12_1": 0.1}},
+		{Symbol: "IWM", Date: "2025-10-08", Rank: &rank3, Values: map[string]float64{"r9m": 0.1}},
+	}))
+
+	exporter := New(database, t.TempDir())
+
+	readCSV := func(filename string) [][]string {
+		file, err := os.Open(filename)
+		require.NoError(t, err)
+		defer file.Close()
+		records, err := csv.NewReader(file).ReadAll()
+		require.NoError(t, err)
+		return records
+	}
+
+	filename, err := exporter.ExportFullRankings("2025-10-08")
+	require.NoError(t, err)
+	records := readCSV(filename)
+	require.Len(t, records, 4)
+	assert.Equal(t, []string{"R12_1", "R9M"}, records[0][12:])
+	assert.Equal(t, []string{"40.00%", "30.00%"}, records[1][12:])
+	assert.Equal(t, []string{"", "10.00%"}, records[3][12:])
+
+	filename, err = exporter.ExportSymbolDetail("QQQ")
+	require.NoError(t, err)
+	records = readCSV(filename)
+	assert.Equal(t, []string{"R12_1", "R9M"}, records[0][18:])
+	assert.Equal(t, []string{"10.00%", "20.00%"}, records[1][18:])
 }
 
 func TestEnsureExportDir(t *testing.T) {
@@ -3,23 +3,36 @@
 package fetch
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
 )
 
+// defaultThrottleBackoff is how long requests pause after Alpha Vantage
+// answers with a rate limit Note; long enough for the per-minute window to pass.
+const defaultThrottleBackoff = time.Minute
+
+// ErrThrottled is wrapped by errors for requests the API kept refusing with a
+// rate limit Note or Information message after all retries.
+var ErrThrottled = errors.New("request throttled by API")
+
 // AlphaVantageClient handles API interactions with Alpha Vantage.
 type AlphaVantageClient struct {
 	apiKey     string
 	baseURL    string
 	httpClient *retryablehttp.Client
 	rateLimiter *RateLimiter
+	throttleBackoff time.Duration
+	fixtureMode     string
 }
 
 // NewAlphaVantageClient creates a new Alpha Vantage API client with rate limiting.
@@ -31,15 +44,17 @@ func NewAlphaVantageClient(apiKey, baseURL string, dailyLimit int, timeout time.
 	client.RetryWaitMax = 10 * time.Second
 	client.HTTPClient.Timeout = timeout
 	client.Logger = nil // Disable default logging
+	client.RequestLogHook = notifyRetry
 
 	// Create rate limiter (25 requests per day for free tier)
 	rateLimiter := NewRateLimiter(dailyLimit)
 
 	return &AlphaVantageClient{
-		apiKey:      apiKey,
-		baseURL:     baseURL,
-		httpClient:  client,
-		rateLimiter: rateLimiter,
+		apiKey:          apiKey,
+		baseURL:         baseURL,
+		httpClient:      client,
+		rateLimiter:     rateLimiter,
+		throttleBackoff: defaultThrottleBackoff,
 	}
 }
 
@@ -49,6 +64,7 @@ type DailyAdjusted struct {
 	TimeSeries map[string]DailyOHLCV  `json:"Time Series (Daily)"`
 	ErrorMessage string                `json:"Error Message,omitempty"`
 	Note         string                `json:"Note,omitempty"`
+	Information  string                `json:"Information,omitempty"`
 }
 
 // DailyMetaData contains metadata from the API response.
@@ -75,63 +91,122 @@ type DailyOHLCV struct {
 // FetchDailyAdjusted fetches daily adjusted OHLCV data for a symbol.
 // outputSize can be "compact" (100 days) or "full" (20+ years).
 func (c *AlphaVantageClient) FetchDailyAdjusted(symbol, outputSize string) (*DailyAdjusted, error) {
-	// Check rate limit before making request
-	if err := c.rateLimiter.Wait(); err != nil {
-		return nil, fmt.Errorf("rate limit exceeded: %w", err)
-	}
+	return c.FetchDailyAdjustedContext(context.Background(), symbol, outputSize)
+}
 
-	// Build URL
+// FetchDailyAdjustedContext is like FetchDailyAdjusted but aborts the request,
+// including any pending retries, when ctx is canceled.
+// It waits for the rate limiter before every request. When the API answers
+// with a rate limit Note or Information message, requests are paused for the
+// throttle backoff and retried up to the client's retry limit.
+func (c *AlphaVantageClient) FetchDailyAdjustedContext(ctx context.Context, symbol, outputSize string) (*DailyAdjusted, error) {
 	params := url.Values{}
 	params.Add("function", "TIME_SERIES_DAILY_ADJUSTED")
 	params.Add("symbol", symbol)
 	params.Add("outputsize", outputSize)
-	params.Add("apikey", c.apiKey)
 
-	fullURL := fmt.Sprintf("%s?%s", c.baseURL, params.Encode())
+	var data DailyAdjusted
+	err := c.query(ctx, params, symbol, func(body io.Reader) error {
+		data = DailyAdjusted{}
+		if err := json.NewDecoder(body).Decode(&data); err != nil {
+			return fmt.Errorf("failed to decode response for %s: %w", symbol, err)
+		}
+
+		// Check for API errors
+		if data.ErrorMessage != "" {
+			return fmt.Errorf("API error for %s: %s", symbol, data.ErrorMessage)
+		}
+		if err := throttleError(symbol, data.Note, data.Information, len(data.TimeSeries) > 0); err != nil {
+			return err
+		}
+
+		// Verify we got data
+		if len(data.TimeSeries) == 0 {
+			return fmt.Errorf("no data returned for %s", symbol)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+// query makes an API request and hands the response body to parse.
+// It waits for the rate limiter before every request. When parse reports a
+// rate limit Note or Information message (ErrThrottled), requests are paused
+// for the throttle backoff and retried up to the client's retry limit.
+func (c *AlphaVantageClient) query(ctx context.Context, params url.Values, symbol string, parse func(io.Reader) error) error {
+	for attempt := 0; ; attempt++ {
+		// Wait for the per-minute and daily limits before making the request.
+		// Replayed fixtures cost nothing and are served immediately.
+		if c.fixtureMode == FixtureModeReplay {
+			return c.request(ctx, params, symbol, parse)
+		}
+		if err := c.rateLimiter.WaitContext(ctx); err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			return fmt.Errorf("rate limit exceeded: %w", err)
+		}
+
+		err := c.request(ctx, params, symbol, parse)
+		if !errors.Is(err, ErrThrottled) || attempt >= c.httpClient.RetryMax {
+			return err
+		}
+
+		c.rateLimiter.Throttle(c.throttleBackoff)
+		notifyRetryContext(ctx, attempt+1)
+	}
+}
+
+// request makes a single API request.
+func (c *AlphaVantageClient) request(ctx context.Context, params url.Values, symbol string, parse func(io.Reader) error) error {
+	// Build URL; the key is added to a copy so callers' params stay key-free
+	query := url.Values{}
+	for key, values := range params {
+		query[key] = values
+	}
+	query.Set("apikey", c.apiKey)
+
+	fullURL := fmt.Sprintf("%s?%s", c.baseURL, query.Encode())
 
 	// Make request
-	req, err := retryablehttp.NewRequest("GET", fullURL, nil)
+	req, err := retryablehttp.NewRequestWithContext(ctx, "GET", fullURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch data for %s: %w", symbol, err)
+		// The daily slot was reserved by the rate limiter, but nothing was answered
+		if c.fixtureMode != FixtureModeReplay {
+			c.rateLimiter.Release()
+		}
+		return fmt.Errorf("failed to fetch data for %s: %w", symbol, err)
 	}
 	defer resp.Body.Close()
 
-	// Record the request
-	c.rateLimiter.RecordRequest()
-
 	// Check HTTP status
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
 	}
 
-	// Parse response
-	var data DailyAdjusted
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, fmt.Errorf("failed to decode response for %s: %w", symbol, err)
-	}
-
-	// Check for API errors
-	if data.ErrorMessage != "" {
-		return nil, fmt.Errorf("API error for %s: %s", symbol, data.ErrorMessage)
-	}
+	return parse(resp.Body)
+}
 
-	// Check for rate limit note
-	if data.Note != "" {
-		return nil, fmt.Errorf("API rate limit note for %s: %s", symbol, data.Note)
+// throttleError checks a response for rate limit messages; both a Note and an
+// Information message without data mean "slow down" rather than a bad request.
+func throttleError(symbol, note, information string, hasData bool) error {
+	if note != "" {
+		return fmt.Errorf("API rate limit note for %s: %s: %w", symbol, note, ErrThrottled)
 	}
-
-	// Verify we got data
-	if len(data.TimeSeries) == 0 {
-		return nil, fmt.Errorf("no data returned for %s", symbol)
+	if information != "" && !hasData {
+		return fmt.Errorf("API rate limit information for %s: %s: %w", symbol, information, ErrThrottled)
 	}
-
-	return &data, nil
+	return nil
 }
 
 // ParseOHLCV converts API string values to float64.
@@ -179,12 +254,325 @@ func ParseOHLCV(d DailyOHLCV) (open, high, low, close, adjClose, volume, dividen
 	return open, high, low, close, adjClose, volume, dividend, split, nil
 }
 
+// compactWindow is the span of calendar days that a "compact" response (the latest
+// 100 trading days) is guaranteed to cover, with a margin for holidays.
+const compactWindow = 130 * 24 * time.Hour
+
+// OutputSizeFor returns the outputsize parameter needed to cover history starting at from.
+// A zero from, or one older than the compact window, requires a "full" download.
+func OutputSizeFor(from time.Time) string {
+	if from.IsZero() || time.Since(from) > compactWindow {
+		return "full"
+	}
+	return "compact"
+}
+
+// ParseDailyAdjusted converts a TIME_SERIES_DAILY_ADJUSTED response into bars sorted by date.
+func ParseDailyAdjusted(data *DailyAdjusted) ([]Bar, error) {
+	bars := make([]Bar, 0, len(data.TimeSeries))
+	for dateStr, d := range data.TimeSeries {
+		date, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse date %q: %w", dateStr, err)
+		}
+
+		open, high, low, close, adjClose, volume, dividend, split, err := ParseOHLCV(d)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bar on %s: %w", dateStr, err)
+		}
+
+		bars = append(bars, Bar{
+			Date:             date,
+			Open:             open,
+			High:             high,
+			Low:              low,
+			Close:            close,
+			AdjClose:         adjClose,
+			Volume:           volume,
+			Dividend:         dividend,
+			SplitCoefficient: split,
+		})
+	}
+
+	return filterBars(bars, time.Time{}, time.Time{}), nil
+}
+
+// Name implements Provider.
+func (c *AlphaVantageClient) Name() string {
+	return ProviderAlphaVantage
+}
+
+// FetchDaily implements Provider using TIME_SERIES_DAILY_ADJUSTED.
+// The outputsize is chosen from the requested start date (see OutputSizeFor).
+func (c *AlphaVantageClient) FetchDaily(ctx context.Context, symbol string, from, to time.Time) ([]Bar, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	data, err := c.FetchDailyAdjustedContext(ctx, symbol, OutputSizeFor(from))
+	if err != nil {
+		return nil, err
+	}
+
+	bars, err := ParseDailyAdjusted(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse data for %s: %w", symbol, err)
+	}
+
+	bars = filterBars(bars, from, to)
+	if len(bars) == 0 {
+		return nil, errNoBars(c.Name(), symbol, from, to)
+	}
+
+	return bars, nil
+}
+
+// Quota implements Provider. Replayed fixtures are not rate limited.
+func (c *AlphaVantageClient) Quota() *RateLimiterStatus {
+	if c.fixtureMode == FixtureModeReplay {
+		return nil
+	}
+	return c.rateLimiter.GetStatus()
+}
+
+// Capabilities implements Provider.
+func (c *AlphaVantageClient) Capabilities() Capabilities {
+	return Capabilities{
+		AdjustedClose:    true,
+		CorporateActions: true,
+		FullHistory:      true,
+		RateLimited:      true,
+	}
+}
+
 // GetRateLimiterStatus returns the current status of the rate limiter.
 func (c *AlphaVantageClient) GetRateLimiterStatus() *RateLimiterStatus {
 	return c.rateLimiter.GetStatus()
 }
 
+// SetFixtures switches the client to a fixture mode. In record mode successful
+// API responses are saved as raw JSON files in dir; in replay mode responses are
+// served from dir without network access, an API key or quota usage.
+func (c *AlphaVantageClient) SetFixtures(mode, dir string) error {
+	if !ValidFixtureMode(mode) {
+		return fmt.Errorf("unknown fixture mode %q (expected off, record or replay)", mode)
+	}
+	if mode == "" || mode == FixtureModeOff {
+		c.fixtureMode = FixtureModeOff
+		return nil
+	}
+	if dir == "" {
+		return fmt.Errorf("a fixture directory is required in %s mode", mode)
+	}
+
+	c.fixtureMode = mode
+	c.httpClient.HTTPClient.Transport = newFixtureTransport(mode, dir, c.httpClient.HTTPClient.Transport)
+	if mode == FixtureModeReplay {
+		// Replays are deterministic; a missing fixture will not appear on retry
+		c.httpClient.RetryMax = 0
+	}
+
+	return nil
+}
+
+// SetRequestsPerMinute limits requests to perMinute per minute in addition to
+// the daily quota. A value of 0 disables the per-minute limit.
+func (c *AlphaVantageClient) SetRequestsPerMinute(perMinute int) {
+	c.rateLimiter.SetPerMinuteLimit(perMinute)
+}
+
+// SetQuotaStore persists the client's daily quota usage in store so that it
+// survives process restarts and is shared with other processes.
+func (c *AlphaVantageClient) SetQuotaStore(store QuotaStore) error {
+	return c.rateLimiter.AttachStore(c.Name(), store)
+}
+
 // ResetRateLimiter manually resets the rate limiter (for testing or admin purposes).
 func (c *AlphaVantageClient) ResetRateLimiter() {
 	c.rateLimiter.Reset()
 }
+
+// CompanyOverview represents the OVERVIEW response structure (fields used only).
+// ETFs and other funds are not covered and come back as an empty object.
+type CompanyOverview struct {
+	Symbol       string `json:"Symbol"`
+	AssetType    string `json:"AssetType"`
+	Name         string `json:"Name"`
+	Exchange     string `json:"Exchange"`
+	Currency     string `json:"Currency"`
+	Sector       string `json:"Sector"`
+	ErrorMessage string `json:"Error Message,omitempty"`
+	Note         string `json:"Note,omitempty"`
+	Information  string `json:"Information,omitempty"`
+}
+
+// SymbolSearch represents the SYMBOL_SEARCH response structure.
+type SymbolSearch struct {
+	BestMatches  []SymbolMatch `json:"bestMatches"`
+	ErrorMessage string        `json:"Error Message,omitempty"`
+	Note         string        `json:"Note,omitempty"`
+	Information  string        `json:"Information,omitempty"`
+}
+
+// SymbolMatch is a single SYMBOL_SEARCH result.
+type SymbolMatch struct {
+	Symbol   string `json:"1. symbol"`
+	Name     string `json:"2. name"`
+	Type     string `json:"3. type"`
+	Region   string `json:"4. region"`
+	Currency string `json:"8. currency"`
+}
+
+// FetchOverview fetches company details for symbol from the OVERVIEW endpoint.
+func (c *AlphaVantageClient) FetchOverview(ctx context.Context, symbol string) (*CompanyOverview, error) {
+	params := url.Values{}
+	params.Add("function", "OVERVIEW")
+	params.Add("symbol", symbol)
+
+	var data CompanyOverview
+	err := c.query(ctx, params, symbol, func(body io.Reader) error {
+		data = CompanyOverview{}
+		if err := json.NewDecoder(body).Decode(&data); err != nil {
+			return fmt.Errorf("failed to decode overview for %s: %w", symbol, err)
+		}
+		if data.ErrorMessage != "" {
+			return fmt.Errorf("API error for %s: %s", symbol, data.ErrorMessage)
+		}
+		return throttleError(symbol, data.Note, data.Information, data.Symbol != "")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+// SearchSymbol looks up keywords with the SYMBOL_SEARCH endpoint.
+func (c *AlphaVantageClient) SearchSymbol(ctx context.Context, keywords string) (*SymbolSearch, error) {
+	params := url.Values{}
+	params.Add("function", "SYMBOL_SEARCH")
+	params.Add("keywords", keywords)
+
+	var data SymbolSearch
+	err := c.query(ctx, params, keywords, func(body io.Reader) error {
+		data = SymbolSearch{}
+		if err := json.NewDecoder(body).Decode(&data); err != nil {
+			return fmt.Errorf("failed to decode search results for %s: %w", keywords, err)
+		}
+		if data.ErrorMessage != "" {
+			return fmt.Errorf("API error for %s: %s", keywords, data.ErrorMessage)
+		}
+		return throttleError(keywords, data.Note, data.Information, len(data.BestMatches) > 0)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+// FetchMetadata implements MetadataSource. OVERVIEW is tried first; symbols it
+// does not cover, such as ETFs, fall back to an exact SYMBOL_SEARCH match,
+// which has no exchange or sector. Costs one or two requests of the quota.
+func (c *AlphaVantageClient) FetchMetadata(ctx context.Context, symbol string) (*SymbolMetadata, error) {
+	overview, err := c.FetchOverview(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	if overview.Symbol != "" {
+		return &SymbolMetadata{
+			Symbol:    symbol,
+			Name:      overview.Name,
+			AssetType: NormalizeAssetType(overview.AssetType),
+			Exchange:  overview.Exchange,
+			Sector:    overview.Sector,
+			Currency:  overview.Currency,
+		}, nil
+	}
+
+	search, err := c.SearchSymbol(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	for _, match := range search.BestMatches {
+		if strings.EqualFold(match.Symbol, symbol) {
+			return &SymbolMetadata{
+				Symbol:    symbol,
+				Name:      match.Name,
+				AssetType: NormalizeAssetType(match.Type),
+				Currency:  match.Currency,
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s: %w", symbol, ErrNoMetadata)
+}
+
+// GlobalQuote represents the GLOBAL_QUOTE response structure.
+type GlobalQuote struct {
+	Quote struct {
+		Symbol           string `json:"01. symbol"`
+		Open             string `json:"02. open"`
+		High             string `json:"03. high"`
+		Low              string `json:"04. low"`
+		Price            string `json:"05. price"`
+		Volume           string `json:"06. volume"`
+		LatestTradingDay string `json:"07. latest trading day"`
+		PreviousClose    string `json:"08. previous close"`
+	} `json:"Global Quote"`
+	ErrorMessage string `json:"Error Message,omitempty"`
+	Note         string `json:"Note,omitempty"`
+	Information  string `json:"Information,omitempty"`
+}
+
+// FetchQuote implements QuoteSource with the GLOBAL_QUOTE endpoint. Costs one
+// request of the quota.
+func (c *AlphaVantageClient) FetchQuote(ctx context.Context, symbol string) (*Quote, error) {
+	params := url.Values{}
+	params.Add("function", "GLOBAL_QUOTE")
+	params.Add("symbol", symbol)
+
+	var data GlobalQuote
+	err := c.query(ctx, params, symbol, func(body io.Reader) error {
+		data = GlobalQuote{}
+		if err := json.NewDecoder(body).Decode(&data); err != nil {
+			return fmt.Errorf("failed to decode quote for %s: %w", symbol, err)
+		}
+		if data.ErrorMessage != "" {
+			return fmt.Errorf("API error for %s: %s", symbol, data.ErrorMessage)
+		}
+		return throttleError(symbol, data.Note, data.Information, data.Quote.Symbol != "")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if data.Quote.Symbol == "" {
+		return nil, fmt.Errorf("%s: %w", symbol, ErrNoQuote)
+	}
+
+	day, err := time.Parse("2006-01-02", data.Quote.LatestTradingDay)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse trading day %q for %s: %w", data.Quote.LatestTradingDay, symbol, err)
+	}
+
+	quote := &Quote{Symbol: symbol, Day: day}
+	for _, field := range []struct {
+		name  string
+		value string
+		dest  *float64
+	}{
+		{"price", data.Quote.Price, &quote.Price},
+		{"open", data.Quote.Open, &quote.Open},
+		{"high", data.Quote.High, &quote.High},
+		{"low", data.Quote.Low, &quote.Low},
+		{"previous close", data.Quote.PreviousClose, &quote.PreviousClose},
+		{"volume", data.Quote.Volume, &quote.Volume},
+	} {
+		if *field.dest, err = strconv.ParseFloat(field.value, 64); err != nil {
+			return nil, fmt.Errorf("failed to parse %s for %s: %w", field.name, symbol, err)
+		}
+	}
+
+	return quote, nil
+}
@@ -5,39 +5,118 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 )
 
+// CSV columns that an importer can map from a header.
+const (
+	ColumnDate     = "date"
+	ColumnOpen     = "open"
+	ColumnHigh     = "high"
+	ColumnLow      = "low"
+	ColumnClose    = "close"
+	ColumnAdjClose = "adj_close"
+	ColumnVolume   = "volume"
+	ColumnDividend = "dividend"
+	ColumnSplit    = "split"
+)
+
+// Names of the built-in CSV format presets.
+const (
+	CSVFormatStooq   = "stooq"
+	CSVFormatYahoo   = "yahoo"
+	CSVFormatGeneric = "generic"
+)
+
+// positionalColumns is the column order assumed when a file has no header.
+var positionalColumns = []string{ColumnDate, ColumnOpen, ColumnHigh, ColumnLow, ColumnClose, ColumnVolume}
+
+// columnAliases lists the header names recognized for each column, compared
+// case-insensitively after trimming spaces and Stooq's angle brackets.
+var columnAliases = map[string][]string{
+	ColumnDate:     {"date", "timestamp", "day"},
+	ColumnOpen:     {"open"},
+	ColumnHigh:     {"high"},
+	ColumnLow:      {"low"},
+	ColumnClose:    {"close", "price", "last"},
+	ColumnAdjClose: {"adj close", "adj_close", "adjclose", "adjusted close", "adjusted_close"},
+	ColumnVolume:   {"volume", "vol"},
+	ColumnDividend: {"dividend", "dividends", "dividend amount", "dividend_amount"},
+	ColumnSplit:    {"split", "splits", "stock splits", "split coefficient", "split_coefficient", "split ratio"},
+}
+
 // CSVRecord represents a parsed CSV record from Stooq or similar sources.
+// AdjClose and SplitCoefficient are zero when the file has no such column.
 type CSVRecord struct {
-	Date   time.Time
-	Open   float64
-	High   float64
-	Low    float64
-	Close  float64
-	Volume float64
+	Date             time.Time
+	Open             float64
+	High             float64
+	Low              float64
+	Close            float64
+	Volume           float64
+	AdjClose         float64
+	Dividend         float64
+	SplitCoefficient float64
 }
 
 // CSVImporter handles importing OHLCV data from CSV files.
+// Columns are located by header name; files without a header use the
+// Date,Open,High,Low,Close,Volume order.
 type CSVImporter struct {
 	// DateFormat is the expected date format in the CSV (default: "2006-01-02")
 	DateFormat string
-	// SkipHeader indicates whether to skip the first row (default: true)
+	// AltDateFormats are tried in order when DateFormat does not match
+	AltDateFormats []string
+	// SkipHeader indicates whether the first row is a header (default: true)
 	SkipHeader bool
+	// Delimiter separates fields (default: ',')
+	Delimiter rune
+	// DecimalComma parses numbers written as 1.234,56
+	DecimalComma bool
+	// Columns overrides the header name used for a column, e.g. {"close": "Last Price"}
+	Columns map[string]string
+	// Required lists the columns that must be present in the header
+	Required []string
 }
 
 // NewCSVImporter creates a new CSV importer with default settings.
+// The defaults match Stooq's export format.
 func NewCSVImporter() *CSVImporter {
 	return &CSVImporter{
-		DateFormat: "2006-01-02",
-		SkipHeader: true,
+		DateFormat:     "2006-01-02",
+		AltDateFormats: []string{"20060102"},
+		SkipHeader:     true,
+		Delimiter:      ',',
+		Required:       []string{ColumnDate, ColumnOpen, ColumnHigh, ColumnLow, ColumnClose, ColumnVolume},
+	}
+}
+
+// NewCSVImporterFormat creates a CSV importer for a named preset:
+// "stooq" (Date,Open,High,Low,Close,Volume, also Stooq's <DATE>/<VOL> text files),
+// "yahoo" (Date,Open,High,Low,Close,Adj Close,Volume with "null" rows), or
+// "generic" (any recognizable header; only date and close are required).
+func NewCSVImporterFormat(format string) (*CSVImporter, error) {
+	ci := NewCSVImporter()
+
+	switch strings.ToLower(format) {
+	case "", CSVFormatStooq:
+	case CSVFormatYahoo:
+		ci.Required = []string{ColumnDate, ColumnOpen, ColumnHigh, ColumnLow, ColumnClose}
+	case CSVFormatGeneric:
+		ci.AltDateFormats = []string{"20060102", "01/02/2006", "02.01.2006", "2006/01/02", time.RFC3339}
+		ci.Required = []string{ColumnDate, ColumnClose}
+	default:
+		return nil, fmt.Errorf("unknown CSV format %q (expected %s, %s or %s)", format, CSVFormatStooq, CSVFormatYahoo, CSVFormatGeneric)
 	}
+
+	return ci, nil
 }
 
 // ImportFromFile imports OHLCV data from a CSV file.
-// Expected format: Date,Open,High,Low,Close,Volume
-// This matches Stooq's export format.
 func (ci *CSVImporter) ImportFromFile(filePath string) ([]CSVRecord, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -51,12 +130,33 @@ func (ci *CSVImporter) ImportFromFile(filePath string) ([]CSVRecord, error) {
 // Import reads OHLCV data from a CSV reader.
 func (ci *CSVImporter) Import(reader io.Reader) ([]CSVRecord, error) {
 	csvReader := csv.NewReader(reader)
+	if ci.Delimiter != 0 {
+		csvReader.Comma = ci.Delimiter
+	}
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
 
-	// Skip header if configured
+	// Locate columns from the header, or assume the positional layout
+	var columns map[string]int
 	if ci.SkipHeader {
-		if _, err := csvReader.Read(); err != nil {
+		header, err := csvReader.Read()
+		if err != nil {
 			return nil, fmt.Errorf("failed to read CSV header: %w", err)
 		}
+		columns, err = ci.mapColumns(header)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		columns = make(map[string]int, len(positionalColumns))
+		for i, col := range positionalColumns {
+			columns[col] = i
+		}
+	}
+
+	minColumns := 0
+	for _, idx := range columns {
+		minColumns = max(minColumns, idx+1)
 	}
 
 	var records []CSVRecord
@@ -71,53 +171,180 @@ func (ci *CSVImporter) Import(reader io.Reader) ([]CSVRecord, error) {
 		}
 
 		// Validate row length
-		if len(row) < 6 {
-			return nil, fmt.Errorf("invalid CSV row (expected at least 6 columns, got %d): %v", len(row), row)
+		if len(row) < minColumns {
+			return nil, fmt.Errorf("invalid CSV row (expected at least %d columns, got %d): %v", minColumns, len(row), row)
 		}
 
-		// Parse date
-		date, err := time.Parse(ci.DateFormat, row[0])
+		rec, ok, err := ci.parseRow(row, columns)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse date %q: %w", row[0], err)
+			return nil, err
+		}
+		if ok {
+			records = append(records, rec)
 		}
+	}
 
-		// Parse OHLCV values
-		open, err := strconv.ParseFloat(row[1], 64)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse open price %q: %w", row[1], err)
+	return records, nil
+}
+
+// mapColumns resolves column positions from a header row.
+func (ci *CSVImporter) mapColumns(header []string) (map[string]int, error) {
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		positions[normalizeHeader(name)] = i
+	}
+
+	columns := make(map[string]int)
+	for col, aliases := range columnAliases {
+		if override, ok := ci.Columns[col]; ok {
+			aliases = []string{override}
+		}
+		for _, alias := range aliases {
+			if idx, ok := positions[normalizeHeader(alias)]; ok {
+				columns[col] = idx
+				break
+			}
 		}
+	}
 
-		high, err := strconv.ParseFloat(row[2], 64)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse high price %q: %w", row[2], err)
+	var missing []string
+	for _, col := range ci.Required {
+		if _, ok := columns[col]; !ok {
+			missing = append(missing, col)
 		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("invalid CSV header %v (expected at least %d columns including %s; missing %s)",
+			header, len(ci.Required), strings.Join(ci.Required, ", "), strings.Join(missing, ", "))
+	}
+
+	return columns, nil
+}
+
+// parseRow converts a row into a record. Rows where a value is "null"
+// (Yahoo's marker for non-trading days) are skipped by returning ok=false.
+func (ci *CSVImporter) parseRow(row []string, columns map[string]int) (CSVRecord, bool, error) {
+	var rec CSVRecord
+
+	// Parse date
+	dateStr := strings.TrimSpace(row[columns[ColumnDate]])
+	date, err := ci.parseDate(dateStr)
+	if err != nil {
+		return rec, false, fmt.Errorf("failed to parse date %q: %w", dateStr, err)
+	}
+	rec.Date = date
+
+	// Parse numeric columns that are present
+	fields := []struct {
+		column string
+		label  string
+		dest   *float64
+	}{
+		{ColumnOpen, "open price", &rec.Open},
+		{ColumnHigh, "high price", &rec.High},
+		{ColumnLow, "low price", &rec.Low},
+		{ColumnClose, "close price", &rec.Close},
+		{ColumnAdjClose, "adjusted close", &rec.AdjClose},
+		{ColumnVolume, "volume", &rec.Volume},
+		{ColumnDividend, "dividend", &rec.Dividend},
+	}
 
-		low, err := strconv.ParseFloat(row[3], 64)
+	for _, f := range fields {
+		idx, ok := columns[f.column]
+		if !ok {
+			continue
+		}
+		raw := strings.TrimSpace(row[idx])
+		if strings.EqualFold(raw, "null") {
+			return rec, false, nil
+		}
+		if raw == "" {
+			continue
+		}
+		value, err := ci.parseNumber(raw)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse low price %q: %w", row[3], err)
+			return rec, false, fmt.Errorf("failed to parse %s %q: %w", f.label, raw, err)
 		}
+		*f.dest = value
+	}
 
-		close, err := strconv.ParseFloat(row[4], 64)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse close price %q: %w", row[4], err)
+	// Close-only files get a flat bar
+	if _, ok := columns[ColumnOpen]; !ok {
+		rec.Open = rec.Close
+	}
+	if _, ok := columns[ColumnHigh]; !ok {
+		rec.High = max(rec.Open, rec.Close)
+	}
+	if _, ok := columns[ColumnLow]; !ok {
+		rec.Low = min(rec.Open, rec.Close)
+	}
+
+	if idx, ok := columns[ColumnSplit]; ok {
+		if raw := strings.TrimSpace(row[idx]); raw != "" {
+			split, err := ci.parseSplit(raw)
+			if err != nil {
+				return rec, false, fmt.Errorf("failed to parse split %q: %w", raw, err)
+			}
+			rec.SplitCoefficient = split
 		}
+	}
 
-		volume, err := strconv.ParseFloat(row[5], 64)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse volume %q: %w", row[5], err)
+	return rec, true, nil
+}
+
+// parseDate parses a date with DateFormat, then each of AltDateFormats.
+func (ci *CSVImporter) parseDate(value string) (time.Time, error) {
+	date, err := time.Parse(ci.DateFormat, value)
+	if err == nil {
+		return date, nil
+	}
+	for _, layout := range ci.AltDateFormats {
+		if alt, altErr := time.Parse(layout, value); altErr == nil {
+			return alt, nil
 		}
+	}
+	return time.Time{}, err
+}
 
-		records = append(records, CSVRecord{
-			Date:   date,
-			Open:   open,
-			High:   high,
-			Low:    low,
-			Close:  close,
-			Volume: volume,
-		})
+// parseNumber parses a decimal number, honoring DecimalComma.
+func (ci *CSVImporter) parseNumber(value string) (float64, error) {
+	if ci.DecimalComma {
+		value = strings.ReplaceAll(value, ".", "")
+		value = strings.ReplaceAll(value, ",", ".")
 	}
+	return strconv.ParseFloat(value, 64)
+}
 
-	return records, nil
+// parseSplit parses a split coefficient written as a number (2.0) or a ratio (2:1, 2/1).
+func (ci *CSVImporter) parseSplit(value string) (float64, error) {
+	for _, sep := range []string{":", "/"} {
+		num, den, ok := strings.Cut(value, sep)
+		if !ok {
+			continue
+		}
+		n, err := ci.parseNumber(strings.TrimSpace(num))
+		if err != nil {
+			return 0, err
+		}
+		d, err := ci.parseNumber(strings.TrimSpace(den))
+		if err != nil {
+			return 0, err
+		}
+		if d == 0 {
+			return 0, fmt.Errorf("zero denominator")
+		}
+		return n / d, nil
+	}
+	return ci.parseNumber(value)
+}
+
+// normalizeHeader lower-cases a header name and strips a byte order mark and
+// Stooq's angle brackets (<DATE>, <VOL>).
+func normalizeHeader(name string) string {
+	name = strings.TrimPrefix(name, "\ufeff")
+	name = strings.Trim(strings.TrimSpace(name), "<>")
+	return strings.ToLower(strings.TrimSpace(name))
 }
 
 // ValidateRecords performs basic validation on imported CSV records.
@@ -128,7 +355,7 @@ func ValidateRecords(records []CSVRecord) error {
 
 	for i, rec := range records {
 		// Check for negative prices
-		if rec.Open < 0 || rec.High < 0 || rec.Low < 0 || rec.Close < 0 {
+		if rec.Open < 0 || rec.High < 0 || rec.Low < 0 || rec.Close < 0 || rec.AdjClose < 0 {
 			return fmt.Errorf("record %d has negative price values", i)
 		}
 
@@ -137,6 +364,11 @@ func ValidateRecords(records []CSVRecord) error {
 			return fmt.Errorf("record %d has negative volume", i)
 		}
 
+		// Check for negative corporate actions
+		if rec.Dividend < 0 || rec.SplitCoefficient < 0 {
+			return fmt.Errorf("record %d has negative dividend or split values", i)
+		}
+
 		// Check high/low consistency
 		if rec.High < rec.Low {
 			return fmt.Errorf("record %d has high < low", i)
@@ -154,3 +386,43 @@ func ValidateRecords(records []CSVRecord) error {
 
 	return nil
 }
+
+// RecordsToBars converts imported CSV records into provider bars.
+// Files without an adjusted close or split column are treated as already adjusted.
+func RecordsToBars(records []CSVRecord) []Bar {
+	bars := make([]Bar, 0, len(records))
+	for _, rec := range records {
+		adjClose := rec.AdjClose
+		if adjClose == 0 {
+			adjClose = rec.Close
+		}
+		split := rec.SplitCoefficient
+		if split == 0 {
+			split = 1
+		}
+
+		bars = append(bars, Bar{
+			Date:             rec.Date,
+			Open:             rec.Open,
+			High:             rec.High,
+			Low:              rec.Low,
+			Close:            rec.Close,
+			AdjClose:         adjClose,
+			Volume:           rec.Volume,
+			Dividend:         rec.Dividend,
+			SplitCoefficient: split,
+		})
+	}
+	return bars
+}
+
+// SymbolFromFilename infers a ticker from a CSV file name such as
+// SPY.csv, spy.us.csv or brk.b.us.txt (Stooq's two-letter market suffix is dropped).
+func SymbolFromFilename(path string) string {
+	name := filepath.Base(path)
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	if i := strings.LastIndex(name, "."); i > 0 && len(name)-i-1 == 2 {
+		name = name[:i]
+	}
+	return strings.ToUpper(name)
+}
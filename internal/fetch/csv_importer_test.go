@@ -237,3 +237,103 @@ func TestValidateRecords_CloseOutOfRange(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "close outside high/low range")
 }
+
+func TestSymbolFromFilename(t *testing.T) {
+	assert.Equal(t, "SPY", SymbolFromFilename("SPY.csv"))
+	assert.Equal(t, "SPY", SymbolFromFilename("/data/stooq/spy.us.txt"))
+	assert.Equal(t, "QQQ", SymbolFromFilename("qqq.csv"))
+	assert.Equal(t, "BRK.B", SymbolFromFilename("brk.b.us.csv"))
+}
+
+func TestCSVImporter_Import_HeaderOrder(t *testing.T) {
+	csvData := `Volume,Close,Low,High,Open,Date
+1000000,101.00,99.50,102.00,100.50,2024-01-01`
+
+	records, err := NewCSVImporter().Import(strings.NewReader(csvData))
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	assert.Equal(t, 100.50, records[0].Open)
+	assert.Equal(t, 101.00, records[0].Close)
+	assert.Equal(t, 1000000.0, records[0].Volume)
+}
+
+func TestCSVImporter_Import_StooqText(t *testing.T) {
+	csvData := `<TICKER>,<PER>,<DATE>,<TIME>,<OPEN>,<HIGH>,<LOW>,<CLOSE>,<VOL>,<OPENINT>
+SPY.US,D,20240102,000000,472.16,473.67,470.49,472.65,123623700,0`
+
+	importer, err := NewCSVImporterFormat(CSVFormatStooq)
+	require.NoError(t, err)
+
+	records, err := importer.Import(strings.NewReader(csvData))
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	assert.Equal(t, "2024-01-02", records[0].Date.Format("2006-01-02"))
+	assert.Equal(t, 472.65, records[0].Close)
+	assert.Equal(t, 123623700.0, records[0].Volume)
+}
+
+func TestCSVImporter_Import_Yahoo(t *testing.T) {
+	csvData := `Date,Open,High,Low,Close,Adj Close,Volume
+2024-01-02,100.00,102.00,99.00,101.00,98.50,1000
+2024-01-03,null,null,null,null,null,null
+2024-01-04,101.00,103.00,100.00,102.00,99.40,1100`
+
+	importer, err := NewCSVImporterFormat(CSVFormatYahoo)
+	require.NoError(t, err)
+
+	records, err := importer.Import(strings.NewReader(csvData))
+	require.NoError(t, err)
+	require.Len(t, records, 2) // null row skipped
+
+	assert.Equal(t, 98.50, records[0].AdjClose)
+
+	bars := RecordsToBars(records)
+	assert.Equal(t, 98.50, bars[0].AdjClose)
+	assert.Equal(t, 1.0, bars[0].SplitCoefficient)
+}
+
+func TestCSVImporter_Import_GenericDecimalComma(t *testing.T) {
+	csvData := `Datum;Schlusskurs;Dividende;Split
+02.01.2024;1.234,50;0,25;
+03.01.2024;1.240,00;;2:1`
+
+	importer, err := NewCSVImporterFormat(CSVFormatGeneric)
+	require.NoError(t, err)
+	importer.Delimiter = ';'
+	importer.DecimalComma = true
+	importer.Columns = map[string]string{
+		ColumnDate:     "Datum",
+		ColumnClose:    "Schlusskurs",
+		ColumnDividend: "Dividende",
+	}
+
+	records, err := importer.Import(strings.NewReader(csvData))
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	assert.Equal(t, "2024-01-02", records[0].Date.Format("2006-01-02"))
+	assert.Equal(t, 1234.50, records[0].Close)
+	assert.Equal(t, 1234.50, records[0].Open) // Close-only files get a flat bar
+	assert.Equal(t, 0.25, records[0].Dividend)
+	assert.Equal(t, 2.0, records[1].SplitCoefficient)
+	assert.NoError(t, ValidateRecords(records))
+}
+
+func TestCSVImporter_Import_MissingRequiredColumn(t *testing.T) {
+	csvData := `Date,Open,High,Low,Volume
+2024-01-01,100.50,102.00,99.50,1000000`
+
+	importer, err := NewCSVImporterFormat(CSVFormatGeneric)
+	require.NoError(t, err)
+
+	_, err = importer.Import(strings.NewReader(csvData))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "missing close")
+}
+
+func TestNewCSVImporterFormat_Unknown(t *testing.T) {
+	_, err := NewCSVImporterFormat("bloomberg")
+	assert.Error(t, err)
+}
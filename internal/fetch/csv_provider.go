@@ -0,0 +1,95 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CSVProvider serves daily bars from a directory of CSV drops (Stooq format by default).
+// Files are looked up by symbol, e.g. SPY.csv, spy.csv or spy.us.csv.
+type CSVProvider struct {
+	dir      string
+	importer *CSVImporter
+}
+
+// NewCSVProvider creates a provider that reads CSV files from dir.
+func NewCSVProvider(dir string) *CSVProvider {
+	return &CSVProvider{
+		dir:      dir,
+		importer: NewCSVImporter(),
+	}
+}
+
+// SetImporter replaces the importer used to parse files, e.g. to read a
+// different vendor format.
+func (p *CSVProvider) SetImporter(importer *CSVImporter) {
+	p.importer = importer
+}
+
+// Name implements Provider.
+func (p *CSVProvider) Name() string {
+	return ProviderCSV
+}
+
+// FetchDaily implements Provider by reading the symbol's CSV file.
+func (p *CSVProvider) FetchDaily(ctx context.Context, symbol string, from, to time.Time) ([]Bar, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	path, err := p.findFile(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := p.importer.ImportFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import %s: %w", path, err)
+	}
+
+	if err := ValidateRecords(records); err != nil {
+		return nil, fmt.Errorf("invalid data in %s: %w", path, err)
+	}
+
+	bars := filterBars(RecordsToBars(records), from, to)
+	if len(bars) == 0 {
+		return nil, errNoBars(p.Name(), symbol, from, to)
+	}
+
+	return bars, nil
+}
+
+// Quota implements Provider. CSV drops are not rate limited.
+func (p *CSVProvider) Quota() *RateLimiterStatus {
+	return nil
+}
+
+// Capabilities implements Provider.
+func (p *CSVProvider) Capabilities() Capabilities {
+	return Capabilities{
+		FullHistory: true,
+	}
+}
+
+// findFile locates the CSV file for a symbol in the provider directory.
+func (p *CSVProvider) findFile(symbol string) (string, error) {
+	lower := strings.ToLower(symbol)
+	candidates := []string{
+		strings.ToUpper(symbol) + ".csv",
+		lower + ".csv",
+		lower + ".us.csv",
+	}
+
+	for _, name := range candidates {
+		path := filepath.Join(p.dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+
+	return "", fmt.Errorf("%s: no CSV file for %s in %s", p.Name(), symbol, p.dir)
+}
@@ -0,0 +1,152 @@
+package fetch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Fixture modes for the Alpha Vantage client.
+const (
+	FixtureModeOff    = "off"    // Talk to the API directly
+	FixtureModeRecord = "record" // Talk to the API and save successful responses
+	FixtureModeReplay = "replay" // Serve saved responses; never touch the network
+)
+
+// ValidFixtureMode reports whether mode is a known fixture mode. Empty means off.
+func ValidFixtureMode(mode string) bool {
+	switch mode {
+	case "", FixtureModeOff, FixtureModeRecord, FixtureModeReplay:
+		return true
+	}
+	return false
+}
+
+// fixtureUnsafe matches characters not allowed in fixture file names.
+var fixtureUnsafe = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// fixtureTransport records API responses to, or replays them from, a directory
+// of raw JSON files named after the request's function, symbol (or search
+// keywords) and output size.
+// The API key is never part of the file name or contents.
+type fixtureTransport struct {
+	mode string
+	dir  string
+	next http.RoundTripper
+}
+
+// newFixtureTransport wraps next (nil means http.DefaultTransport) in a fixture transport.
+func newFixtureTransport(mode, dir string, next http.RoundTripper) *fixtureTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &fixtureTransport{mode: mode, dir: dir, next: next}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *fixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.mode == FixtureModeReplay {
+		return t.replay(req)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || t.mode != FixtureModeRecord || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	// Throttle notes and API errors would poison later replays
+	if isAPIPayload(body) {
+		if err := os.MkdirAll(t.dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create fixture directory: %w", err)
+		}
+		if err := os.WriteFile(filepath.Join(t.dir, fixtureName(req, "")), body, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write fixture: %w", err)
+		}
+	}
+
+	return resp, nil
+}
+
+// replay serves a saved response. A missing compact response falls back to the
+// full one, which covers the same dates and more.
+func (t *fixtureTransport) replay(req *http.Request) (*http.Response, error) {
+	candidates := []string{fixtureName(req, "")}
+	if req.URL.Query().Get("outputsize") == "compact" {
+		candidates = append(candidates, fixtureName(req, "full"))
+	}
+
+	for _, name := range candidates {
+		body, err := os.ReadFile(filepath.Join(t.dir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture %s: %w", name, err)
+		}
+
+		return &http.Response{
+			Status:        "200 OK",
+			StatusCode:    http.StatusOK,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": []string{"application/json"}},
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no fixture %s in %s (record one with fixture_mode: record)", candidates[0], t.dir)
+}
+
+// fixtureName builds the file name for a request, e.g.
+// TIME_SERIES_DAILY_ADJUSTED_SPY_full.json. A non-empty outputSize overrides the
+// request's own.
+func fixtureName(req *http.Request, outputSize string) string {
+	query := req.URL.Query()
+	if outputSize == "" {
+		outputSize = query.Get("outputsize")
+	}
+
+	symbol := query.Get("symbol")
+	if symbol == "" {
+		symbol = query.Get("keywords") // SYMBOL_SEARCH
+	}
+
+	parts := []string{query.Get("function"), strings.ToUpper(symbol)}
+	if outputSize != "" {
+		parts = append(parts, outputSize)
+	}
+
+	name := fixtureUnsafe.ReplaceAllString(strings.Join(parts, "_"), "-")
+	return name + ".json"
+}
+
+// isAPIPayload reports whether body is a data response rather than an error,
+// Note or Information message.
+func isAPIPayload(body []byte) bool {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return false
+	}
+	for _, key := range []string{"Error Message", "Note", "Information"} {
+		if _, ok := fields[key]; ok {
+			return false
+		}
+	}
+	return true
+}
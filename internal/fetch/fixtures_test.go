@@ -0,0 +1,77 @@
+package fetch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fixtureDailyJSON = `{"Meta Data": {"2. Symbol": "SPY"}, "Time Series (Daily)": {
+	"2024-01-02": {"1. open": "100", "2. high": "102", "3. low": "99", "4. close": "101", "5. adjusted close": "101", "6. volume": "1000", "7. dividend amount": "0.0000", "8. split coefficient": "1.0"},
+	"2024-01-03": {"1. open": "101", "2. high": "103", "3. low": "100", "4. close": "102", "5. adjusted close": "102", "6. volume": "1100", "7. dividend amount": "0.0000", "8. split coefficient": "1.0"}}}`
+
+func TestAlphaVantageClient_RecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("symbol") == "QQQ" {
+			w.Write([]byte(`{"Note": "Thank you for using Alpha Vantage!"}`))
+			return
+		}
+		w.Write([]byte(fixtureDailyJSON))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+
+	recorder := NewAlphaVantageClient("secret-key", server.URL, 25, 5*time.Second, 0)
+	require.NoError(t, recorder.SetFixtures(FixtureModeRecord, dir))
+
+	bars, err := recorder.FetchDaily(context.Background(), "SPY", time.Time{}, time.Time{})
+	require.NoError(t, err)
+	assert.Len(t, bars, 2)
+
+	// Throttle notes are not recorded
+	_, err = recorder.FetchDaily(context.Background(), "QQQ", time.Time{}, time.Time{})
+	require.Error(t, err)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "TIME_SERIES_DAILY_ADJUSTED_SPY_full.json", entries[0].Name())
+
+	saved, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	require.NoError(t, err)
+	assert.NotContains(t, string(saved), "secret-key")
+
+	// Replay without network or API key; a compact request falls back to the full response
+	server.Close()
+	replayer := NewAlphaVantageClient("", "http://127.0.0.1:1/query", 1, time.Second, 3)
+	require.NoError(t, replayer.SetFixtures(FixtureModeReplay, dir))
+
+	from := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		bars, err = replayer.FetchDaily(context.Background(), "SPY", from, time.Time{})
+		require.NoError(t, err)
+		require.Len(t, bars, 1)
+		assert.Equal(t, 102.0, bars[0].Close)
+	}
+	assert.Equal(t, 0, replayer.GetRateLimiterStatus().RequestsUsed)
+
+	_, err = replayer.FetchDaily(context.Background(), "IWM", time.Time{}, time.Time{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no fixture TIME_SERIES_DAILY_ADJUSTED_IWM_full.json")
+}
+
+func TestAlphaVantageClient_SetFixturesInvalid(t *testing.T) {
+	client := NewAlphaVantageClient("key", "http://localhost", 25, time.Second, 0)
+
+	assert.Error(t, client.SetFixtures("mock", t.TempDir()))
+	assert.Error(t, client.SetFixtures(FixtureModeReplay, ""))
+	assert.NoError(t, client.SetFixtures(FixtureModeOff, ""))
+}
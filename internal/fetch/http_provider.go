@@ -0,0 +1,163 @@
+package fetch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// HTTPProvider fetches daily bars from an in-house HTTP price feed.
+//
+// The feed is queried as GET {baseURL}/daily/{symbol}?from=YYYY-MM-DD&to=YYYY-MM-DD
+// and must respond with a JSON document of the form:
+//
+//	{"symbol": "SPY", "bars": [{"date": "2024-01-02", "open": 1, "high": 1, "low": 1,
+//	  "close": 1, "adj_close": 1, "volume": 100, "dividend": 0, "split": 1}]}
+type HTTPProvider struct {
+	baseURL    string
+	token      string
+	httpClient *retryablehttp.Client
+}
+
+// httpFeedResponse is the JSON document returned by the in-house feed.
+type httpFeedResponse struct {
+	Symbol string        `json:"symbol"`
+	Bars   []httpFeedBar `json:"bars"`
+	Error  string        `json:"error,omitempty"`
+}
+
+// httpFeedBar is a single bar in the in-house feed response.
+type httpFeedBar struct {
+	Date     string   `json:"date"`
+	Open     float64  `json:"open"`
+	High     float64  `json:"high"`
+	Low      float64  `json:"low"`
+	Close    float64  `json:"close"`
+	AdjClose *float64 `json:"adj_close"`
+	Volume   float64  `json:"volume"`
+	Dividend float64  `json:"dividend"`
+	Split    *float64 `json:"split"`
+}
+
+// NewHTTPProvider creates a client for the in-house price feed.
+// token is sent as a bearer token when non-empty.
+func NewHTTPProvider(baseURL, token string, timeout time.Duration, maxRetries int) *HTTPProvider {
+	client := retryablehttp.NewClient()
+	client.RetryMax = maxRetries
+	client.RetryWaitMin = 1 * time.Second
+	client.RetryWaitMax = 10 * time.Second
+	client.HTTPClient.Timeout = timeout
+	client.Logger = nil // Disable default logging
+	client.RequestLogHook = notifyRetry
+
+	return &HTTPProvider{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		httpClient: client,
+	}
+}
+
+// Name implements Provider.
+func (p *HTTPProvider) Name() string {
+	return ProviderHTTP
+}
+
+// FetchDaily implements Provider.
+func (p *HTTPProvider) FetchDaily(ctx context.Context, symbol string, from, to time.Time) ([]Bar, error) {
+	params := url.Values{}
+	if !from.IsZero() {
+		params.Add("from", from.Format("2006-01-02"))
+	}
+	if !to.IsZero() {
+		params.Add("to", to.Format("2006-01-02"))
+	}
+
+	fullURL := fmt.Sprintf("%s/daily/%s", p.baseURL, url.PathEscape(symbol))
+	if len(params) > 0 {
+		fullURL += "?" + params.Encode()
+	}
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, "GET", fullURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch data for %s: %w", symbol, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("feed returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var data httpFeedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response for %s: %w", symbol, err)
+	}
+
+	if data.Error != "" {
+		return nil, fmt.Errorf("feed error for %s: %s", symbol, data.Error)
+	}
+
+	bars := make([]Bar, 0, len(data.Bars))
+	for _, b := range data.Bars {
+		date, err := time.Parse("2006-01-02", b.Date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse date %q for %s: %w", b.Date, symbol, err)
+		}
+
+		bar := Bar{
+			Date:             date,
+			Open:             b.Open,
+			High:             b.High,
+			Low:              b.Low,
+			Close:            b.Close,
+			AdjClose:         b.Close,
+			Volume:           b.Volume,
+			Dividend:         b.Dividend,
+			SplitCoefficient: 1,
+		}
+		if b.AdjClose != nil {
+			bar.AdjClose = *b.AdjClose
+		}
+		if b.Split != nil {
+			bar.SplitCoefficient = *b.Split
+		}
+
+		bars = append(bars, bar)
+	}
+
+	bars = filterBars(bars, from, to)
+	if len(bars) == 0 {
+		return nil, errNoBars(p.Name(), symbol, from, to)
+	}
+
+	return bars, nil
+}
+
+// Quota implements Provider. The in-house feed is not rate limited.
+func (p *HTTPProvider) Quota() *RateLimiterStatus {
+	return nil
+}
+
+// Capabilities implements Provider.
+func (p *HTTPProvider) Capabilities() Capabilities {
+	return Capabilities{
+		AdjustedClose:    true,
+		CorporateActions: true,
+		FullHistory:      true,
+	}
+}
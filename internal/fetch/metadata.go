@@ -0,0 +1,168 @@
+package fetch
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Metadata sources selectable in the metadata section of the configuration.
+const (
+	MetadataSourceAlphaVantage = "alpha_vantage"
+	MetadataSourceCSV          = "csv"
+	MetadataSourceNone         = "none"
+)
+
+// ErrNoMetadata is wrapped by errors for symbols a metadata source knows nothing about.
+var ErrNoMetadata = errors.New("no metadata found")
+
+// SymbolMetadata describes a symbol beyond its prices. Fields a source does not
+// provide are empty.
+type SymbolMetadata struct {
+	Symbol    string
+	Name      string
+	AssetType string // ETF, STOCK or INDEX; empty if unknown
+	Exchange  string
+	Sector    string
+	Currency  string
+}
+
+// MetadataSource looks up symbol metadata.
+type MetadataSource interface {
+	FetchMetadata(ctx context.Context, symbol string) (*SymbolMetadata, error)
+}
+
+// NormalizeAssetType maps a provider's asset type description to ETF, STOCK or
+// INDEX, the types stored in the symbols table. Unknown types map to "".
+func NormalizeAssetType(raw string) string {
+	t := strings.ToUpper(raw)
+	switch {
+	case strings.Contains(t, "ETF"), strings.Contains(t, "FUND"):
+		return "ETF"
+	case strings.Contains(t, "INDEX"):
+		return "INDEX"
+	case strings.Contains(t, "STOCK"), strings.Contains(t, "EQUITY"):
+		return "STOCK"
+	}
+	return ""
+}
+
+// metadataColumns maps accepted CSV header names to SymbolMetadata fields.
+var metadataColumns = map[string]string{
+	"symbol": "symbol", "ticker": "symbol",
+	"name":       "name",
+	"asset_type": "asset_type", "type": "asset_type",
+	"exchange": "exchange",
+	"sector":   "sector",
+	"currency": "currency",
+}
+
+// CSVMetadataSource serves symbol metadata from a local CSV file with a header
+// row naming its columns, e.g. symbol,name,asset_type,exchange,sector,currency.
+// Only the symbol column is required. The file is read on first use.
+type CSVMetadataSource struct {
+	path string
+
+	once    sync.Once
+	records map[string]SymbolMetadata
+	err     error
+}
+
+// NewCSVMetadataSource creates a metadata source reading the CSV file at path.
+func NewCSVMetadataSource(path string) *CSVMetadataSource {
+	return &CSVMetadataSource{path: path}
+}
+
+// FetchMetadata implements MetadataSource.
+func (s *CSVMetadataSource) FetchMetadata(ctx context.Context, symbol string) (*SymbolMetadata, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.once.Do(func() {
+		s.records, s.err = s.load()
+	})
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	md, ok := s.records[strings.ToUpper(symbol)]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w in %s", symbol, ErrNoMetadata, s.path)
+	}
+	md.Symbol = symbol
+	return &md, nil
+}
+
+// load reads the whole file keyed by upper-cased symbol.
+func (s *CSVMetadataSource) load() (map[string]SymbolMetadata, error) {
+	file, err := os.Open(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open metadata file: %w", err)
+	}
+	defer file.Close()
+
+	return parseMetadataCSV(file)
+}
+
+// parseMetadataCSV parses metadata rows keyed by upper-cased symbol.
+func parseMetadataCSV(r io.Reader) (map[string]SymbolMetadata, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metadata header: %w", err)
+	}
+
+	index := make(map[string]int)
+	for i, name := range header {
+		key := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if field, ok := metadataColumns[key]; ok {
+			index[field] = i
+		}
+	}
+	if _, ok := index["symbol"]; !ok {
+		return nil, fmt.Errorf("metadata file has no symbol column")
+	}
+
+	records := make(map[string]SymbolMetadata)
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read metadata line %d: %w", line, err)
+		}
+
+		get := func(field string) string {
+			i, ok := index[field]
+			if !ok || i >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[i])
+		}
+
+		symbol := strings.ToUpper(get("symbol"))
+		if symbol == "" {
+			continue
+		}
+		records[symbol] = SymbolMetadata{
+			Symbol:    symbol,
+			Name:      get("name"),
+			AssetType: NormalizeAssetType(get("asset_type")),
+			Exchange:  get("exchange"),
+			Sector:    get("sector"),
+			Currency:  get("currency"),
+		}
+	}
+
+	return records, nil
+}
@@ -0,0 +1,113 @@
+package fetch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeAssetType(t *testing.T) {
+	assert.Equal(t, "STOCK", NormalizeAssetType("Common Stock"))
+	assert.Equal(t, "STOCK", NormalizeAssetType("Equity"))
+	assert.Equal(t, "ETF", NormalizeAssetType("ETF"))
+	assert.Equal(t, "ETF", NormalizeAssetType("Mutual Fund"))
+	assert.Equal(t, "INDEX", NormalizeAssetType("index"))
+	assert.Equal(t, "", NormalizeAssetType("Warrant"))
+}
+
+func TestAlphaVantageClient_FetchMetadata_Overview(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "OVERVIEW", r.URL.Query().Get("function"))
+		w.Write([]byte(`{"Symbol": "AAPL", "AssetType": "Common Stock", "Name": "Apple Inc",
+			"Exchange": "NASDAQ", "Currency": "USD", "Sector": "TECHNOLOGY"}`))
+	}))
+	defer server.Close()
+
+	client := NewAlphaVantageClient("demo", server.URL, 25, 5*time.Second, 0)
+	md, err := client.FetchMetadata(context.Background(), "AAPL")
+	require.NoError(t, err)
+
+	assert.Equal(t, SymbolMetadata{
+		Symbol: "AAPL", Name: "Apple Inc", AssetType: "STOCK",
+		Exchange: "NASDAQ", Sector: "TECHNOLOGY", Currency: "USD",
+	}, *md)
+	assert.Equal(t, 1, client.GetRateLimiterStatus().RequestsUsed)
+}
+
+func TestAlphaVantageClient_FetchMetadata_SearchFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("function") == "OVERVIEW" {
+			w.Write([]byte(`{}`)) // ETFs are not covered
+			return
+		}
+		assert.Equal(t, "SPY", r.URL.Query().Get("keywords"))
+		w.Write([]byte(`{"bestMatches": [
+			{"1. symbol": "SPYG", "2. name": "SPDR Portfolio S&P 500 Growth ETF", "3. type": "ETF", "8. currency": "USD"},
+			{"1. symbol": "SPY", "2. name": "SPDR S&P 500 ETF Trust", "3. type": "ETF", "4. region": "United States", "8. currency": "USD"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewAlphaVantageClient("demo", server.URL, 25, 5*time.Second, 0)
+	md, err := client.FetchMetadata(context.Background(), "SPY")
+	require.NoError(t, err)
+
+	assert.Equal(t, "SPDR S&P 500 ETF Trust", md.Name)
+	assert.Equal(t, "ETF", md.AssetType)
+	assert.Equal(t, "USD", md.Currency)
+	assert.Empty(t, md.Exchange)
+	assert.Equal(t, 2, client.GetRateLimiterStatus().RequestsUsed)
+}
+
+func TestAlphaVantageClient_FetchMetadata_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("function") == "OVERVIEW" {
+			w.Write([]byte(`{}`))
+			return
+		}
+		w.Write([]byte(`{"bestMatches": []}`))
+	}))
+	defer server.Close()
+
+	client := NewAlphaVantageClient("demo", server.URL, 25, 5*time.Second, 0)
+	_, err := client.FetchMetadata(context.Background(), "ZZZZ")
+	assert.ErrorIs(t, err, ErrNoMetadata)
+}
+
+func TestCSVMetadataSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metadata.csv")
+	require.NoError(t, os.WriteFile(path, []byte("Ticker,Name,Type,Exchange,Sector,Currency\n"+
+		"spy,SPDR S&P 500 ETF Trust,ETF,NYSE ARCA,,USD\n"+
+		"AAPL,Apple Inc,Common Stock,NASDAQ,Technology,USD\n"), 0644))
+
+	source := NewCSVMetadataSource(path)
+
+	md, err := source.FetchMetadata(context.Background(), "SPY")
+	require.NoError(t, err)
+	assert.Equal(t, SymbolMetadata{
+		Symbol: "SPY", Name: "SPDR S&P 500 ETF Trust", AssetType: "ETF",
+		Exchange: "NYSE ARCA", Currency: "USD",
+	}, *md)
+
+	md, err = source.FetchMetadata(context.Background(), "AAPL")
+	require.NoError(t, err)
+	assert.Equal(t, "STOCK", md.AssetType)
+	assert.Equal(t, "Technology", md.Sector)
+
+	_, err = source.FetchMetadata(context.Background(), "QQQ")
+	assert.ErrorIs(t, err, ErrNoMetadata)
+}
+
+func TestParseMetadataCSV_NoSymbolColumn(t *testing.T) {
+	_, err := parseMetadataCSV(strings.NewReader("Name,Sector\nApple,Technology\n"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no symbol column")
+}
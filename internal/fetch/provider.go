@@ -0,0 +1,143 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Provider names used in configuration to select a price source.
+const (
+	ProviderAlphaVantage = "alpha_vantage"
+	ProviderCSV          = "csv"
+	ProviderHTTP         = "http"
+)
+
+// Bar represents a single day's OHLCV data as returned by a price provider.
+type Bar struct {
+	Date             time.Time
+	Open             float64
+	High             float64
+	Low              float64
+	Close            float64
+	AdjClose         float64
+	Volume           float64
+	Dividend         float64
+	SplitCoefficient float64
+}
+
+// Capabilities describes what a price provider can deliver.
+type Capabilities struct {
+	AdjustedClose    bool // Provides split/dividend adjusted closes
+	CorporateActions bool // Provides dividend amounts and split coefficients
+	FullHistory      bool // Can return the complete history for a symbol
+	RateLimited      bool // Requests count against a daily quota
+}
+
+// Provider is a source of daily price bars.
+// Implementations must be safe for concurrent use by the scheduler's workers.
+type Provider interface {
+	// Name returns the provider name used in configuration and logs.
+	Name() string
+
+	// FetchDaily returns daily bars for symbol between from and to (inclusive).
+	// A zero from requests as much history as the provider has; a zero to means "latest".
+	FetchDaily(ctx context.Context, symbol string, from, to time.Time) ([]Bar, error)
+
+	// Quota returns the provider's current quota status, or nil if it is not rate limited.
+	Quota() *RateLimiterStatus
+
+	// Capabilities reports what the provider can deliver.
+	Capabilities() Capabilities
+}
+
+// Providers routes symbols to price providers.
+// Symbols without an explicit assignment use the default provider.
+type Providers struct {
+	defaultProvider Provider
+	bySymbol        map[string]Provider
+}
+
+// NewProviders creates a provider set with the given default provider.
+func NewProviders(defaultProvider Provider) *Providers {
+	return &Providers{
+		defaultProvider: defaultProvider,
+		bySymbol:        make(map[string]Provider),
+	}
+}
+
+// Assign routes a symbol to a specific provider.
+func (p *Providers) Assign(symbol string, provider Provider) {
+	p.bySymbol[strings.ToUpper(symbol)] = provider
+}
+
+// For returns the provider responsible for a symbol.
+func (p *Providers) For(symbol string) Provider {
+	if provider, ok := p.bySymbol[strings.ToUpper(symbol)]; ok {
+		return provider
+	}
+	return p.defaultProvider
+}
+
+// Default returns the default provider.
+func (p *Providers) Default() Provider {
+	return p.defaultProvider
+}
+
+// All returns every distinct provider in the set, ordered by name.
+func (p *Providers) All() []Provider {
+	seen := map[string]Provider{p.defaultProvider.Name(): p.defaultProvider}
+	for _, provider := range p.bySymbol {
+		seen[provider.Name()] = provider
+	}
+
+	all := make([]Provider, 0, len(seen))
+	for _, provider := range seen {
+		all = append(all, provider)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Name() < all[j].Name()
+	})
+
+	return all
+}
+
+// filterBars returns the bars between from and to (inclusive) sorted by date.
+// Zero bounds are treated as open-ended.
+func filterBars(bars []Bar, from, to time.Time) []Bar {
+	filtered := make([]Bar, 0, len(bars))
+	for _, bar := range bars {
+		if !from.IsZero() && bar.Date.Before(from) {
+			continue
+		}
+		if !to.IsZero() && bar.Date.After(to) {
+			continue
+		}
+		filtered = append(filtered, bar)
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].Date.Before(filtered[j].Date)
+	})
+
+	return filtered
+}
+
+// errNoBars builds the error returned when a provider has no data in the requested range.
+func errNoBars(provider, symbol string, from, to time.Time) error {
+	if from.IsZero() && to.IsZero() {
+		return fmt.Errorf("%s: no data returned for %s", provider, symbol)
+	}
+	return fmt.Errorf("%s: no data for %s between %s and %s",
+		provider, symbol, formatBound(from, "start"), formatBound(to, "latest"))
+}
+
+// formatBound formats an optional range bound for error messages.
+func formatBound(t time.Time, zero string) string {
+	if t.IsZero() {
+		return zero
+	}
+	return t.Format("2006-01-02")
+}
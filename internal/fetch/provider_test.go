@@ -0,0 +1,172 @@
+package fetch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviders_For(t *testing.T) {
+	av := NewAlphaVantageClient("key", "http://localhost", 25, time.Second, 0)
+	csvProvider := NewCSVProvider(t.TempDir())
+
+	providers := NewProviders(av)
+	providers.Assign("xlre", csvProvider)
+
+	assert.Equal(t, ProviderAlphaVantage, providers.For("SPY").Name())
+	assert.Equal(t, ProviderCSV, providers.For("XLRE").Name())
+	assert.Equal(t, ProviderAlphaVantage, providers.Default().Name())
+
+	all := providers.All()
+	require.Len(t, all, 2)
+	assert.Equal(t, ProviderAlphaVantage, all[0].Name())
+	assert.Equal(t, ProviderCSV, all[1].Name())
+}
+
+func TestParseDailyAdjusted(t *testing.T) {
+	data := &DailyAdjusted{
+		TimeSeries: map[string]DailyOHLCV{
+			"2024-01-03": {Open: "101", High: "103", Low: "100", Close: "102", AdjustedClose: "51", Volume: "2000", DividendAmount: "0.0000", SplitCoefficient: "2.0"},
+			"2024-01-02": {Open: "100", High: "102", Low: "99", Close: "101", AdjustedClose: "50.5", Volume: "1000", DividendAmount: "0.5000", SplitCoefficient: "1.0"},
+		},
+	}
+
+	bars, err := ParseDailyAdjusted(data)
+	require.NoError(t, err)
+	require.Len(t, bars, 2)
+
+	// Sorted ascending by date
+	assert.Equal(t, "2024-01-02", bars[0].Date.Format("2006-01-02"))
+	assert.Equal(t, 0.5, bars[0].Dividend)
+	assert.Equal(t, "2024-01-03", bars[1].Date.Format("2006-01-02"))
+	assert.Equal(t, 51.0, bars[1].AdjClose)
+	assert.Equal(t, 2.0, bars[1].SplitCoefficient)
+}
+
+func TestAlphaVantageClient_RetriesThrottleNote(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if requests.Add(1) == 1 {
+			w.Write([]byte(`{"Note": "Thank you for using Alpha Vantage! Our standard API rate limit is 5 requests per minute."}`))
+			return
+		}
+		w.Write([]byte(`{"Time Series (Daily)": {"2024-01-02": {"1. open": "100", "2. high": "102", "3. low": "99", "4. close": "101",
+			"5. adjusted close": "101", "6. volume": "1000", "7. dividend amount": "0.0000", "8. split coefficient": "1.0"}}}`))
+	}))
+	defer server.Close()
+
+	client := NewAlphaVantageClient("demo", server.URL, 25, 5*time.Second, 2)
+	client.throttleBackoff = 10 * time.Millisecond
+
+	var retries []int
+	ctx := withRetryNotifier(context.Background(), func(attempt int) {
+		retries = append(retries, attempt)
+	})
+
+	bars, err := client.FetchDaily(ctx, "SPY", time.Time{}, time.Time{})
+	require.NoError(t, err)
+	assert.Len(t, bars, 1)
+	assert.Equal(t, int32(2), requests.Load())
+	assert.Equal(t, []int{1}, retries)
+}
+
+func TestAlphaVantageClient_ThrottledAfterRetries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Information": "Please consider spreading out your free API requests more sparingly."}`))
+	}))
+	defer server.Close()
+
+	client := NewAlphaVantageClient("demo", server.URL, 25, 5*time.Second, 1)
+	client.throttleBackoff = time.Millisecond
+
+	_, err := client.FetchDailyAdjustedContext(context.Background(), "SPY", "compact")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrThrottled)
+	assert.Equal(t, 2, client.GetRateLimiterStatus().RequestsUsed)
+}
+
+func TestOutputSizeFor(t *testing.T) {
+	assert.Equal(t, "full", OutputSizeFor(time.Time{}))
+	assert.Equal(t, "full", OutputSizeFor(time.Now().AddDate(-1, 0, 0)))
+	assert.Equal(t, "compact", OutputSizeFor(time.Now().AddDate(0, 0, -10)))
+}
+
+func TestCSVProvider_FetchDaily(t *testing.T) {
+	dir := t.TempDir()
+	csvData := `Date,Open,High,Low,Close,Volume
+2024-01-02,100.00,102.00,99.00,101.00,1000000
+2024-01-03,101.00,103.00,100.00,102.00,1100000
+2024-01-04,102.00,104.00,101.00,103.00,1200000`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "spy.us.csv"), []byte(csvData), 0644))
+
+	provider := NewCSVProvider(dir)
+	from := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+
+	bars, err := provider.FetchDaily(context.Background(), "SPY", from, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, bars, 2)
+	assert.Equal(t, "2024-01-03", bars[0].Date.Format("2006-01-02"))
+	assert.Equal(t, 102.0, bars[0].AdjClose)
+	assert.Equal(t, 1.0, bars[0].SplitCoefficient)
+
+	assert.Nil(t, provider.Quota())
+	assert.False(t, provider.Capabilities().RateLimited)
+}
+
+func TestCSVProvider_MissingFile(t *testing.T) {
+	provider := NewCSVProvider(t.TempDir())
+
+	_, err := provider.FetchDaily(context.Background(), "QQQ", time.Time{}, time.Time{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no CSV file for QQQ")
+}
+
+func TestHTTPProvider_FetchDaily(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/daily/SPY", r.URL.Path)
+		assert.Equal(t, "2024-01-02", r.URL.Query().Get("from"))
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"symbol":"SPY","bars":[
+			{"date":"2024-01-03","open":101,"high":103,"low":100,"close":102,"adj_close":101.5,"volume":2000},
+			{"date":"2024-01-02","open":100,"high":102,"low":99,"close":101,"volume":1000,"dividend":0.25,"split":1}
+		]}`))
+	}))
+	defer server.Close()
+
+	provider := NewHTTPProvider(server.URL+"/", "secret", 5*time.Second, 0)
+	from := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	bars, err := provider.FetchDaily(context.Background(), "SPY", from, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, bars, 2)
+
+	assert.Equal(t, "2024-01-02", bars[0].Date.Format("2006-01-02"))
+	assert.Equal(t, 101.0, bars[0].AdjClose) // Falls back to close
+	assert.Equal(t, 0.25, bars[0].Dividend)
+	assert.Equal(t, 101.5, bars[1].AdjClose)
+	assert.Equal(t, 1.0, bars[1].SplitCoefficient)
+}
+
+func TestHTTPProvider_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unknown symbol", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	provider := NewHTTPProvider(server.URL, "", 5*time.Second, 0)
+
+	_, err := provider.FetchDaily(context.Background(), "ZZZ", time.Time{}, time.Time{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "status 404")
+}
@@ -0,0 +1,29 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNoQuote is returned when a quote source has no latest price for a symbol.
+var ErrNoQuote = errors.New("no quote available")
+
+// Quote is the latest traded price of a symbol, taken during a session or
+// after its close.
+type Quote struct {
+	Symbol        string
+	Day           time.Time // Trading day the price belongs to
+	Price         float64
+	Open          float64
+	High          float64
+	Low           float64
+	PreviousClose float64
+	Volume        float64 // Volume traded so far on Day
+}
+
+// QuoteSource is implemented by providers that serve latest quotes in addition
+// to daily bars.
+type QuoteSource interface {
+	FetchQuote(ctx context.Context, symbol string) (*Quote, error)
+}
@@ -0,0 +1,51 @@
+package fetch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlphaVantageClient_FetchQuote(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GLOBAL_QUOTE", r.URL.Query().Get("function"))
+		assert.Equal(t, "SPY", r.URL.Query().Get("symbol"))
+		w.Write([]byte(`{"Global Quote": {
+			"01. symbol": "SPY", "02. open": "500.10", "03. high": "505.00", "04. low": "499.50",
+			"05. price": "503.25", "06. volume": "41234567", "07. latest trading day": "2024-03-15",
+			"08. previous close": "500.00", "09. change": "3.25", "10. change percent": "0.6500%"}}`))
+	}))
+	defer server.Close()
+
+	client := NewAlphaVantageClient("demo", server.URL, 25, 5*time.Second, 0)
+	quote, err := client.FetchQuote(context.Background(), "SPY")
+	require.NoError(t, err)
+
+	assert.Equal(t, Quote{
+		Symbol:        "SPY",
+		Day:           time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		Price:         503.25,
+		Open:          500.10,
+		High:          505.00,
+		Low:           499.50,
+		PreviousClose: 500.00,
+		Volume:        41234567,
+	}, *quote)
+	assert.Equal(t, 1, client.GetRateLimiterStatus().RequestsUsed)
+}
+
+func TestAlphaVantageClient_FetchQuote_Unknown(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Global Quote": {}}`))
+	}))
+	defer server.Close()
+
+	client := NewAlphaVantageClient("demo", server.URL, 25, 5*time.Second, 0)
+	_, err := client.FetchQuote(context.Background(), "ZZZZ")
+	assert.ErrorIs(t, err, ErrNoQuote)
+}
@@ -1,22 +1,51 @@
 package fetch
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 )
 
-// RateLimiter manages API request quotas with daily limits.
+// QuotaStore persists request counts so a daily quota survives process restarts.
+// Days are UTC dates in ISO format (yyyy-mm-dd).
+type QuotaStore interface {
+	RequestsUsed(provider, day string) (int, error)
+	RecordRequest(provider, day string) error
+	ReleaseRequest(provider, day string) error
+}
+
+// ErrQuotaExceeded is wrapped by the error returned when the daily quota is used up.
+var ErrQuotaExceeded = errors.New("daily API quota exceeded")
+
+// RateLimiter manages API request quotas with a daily limit and an optional
+// per-minute burst limit. The daily window is the UTC calendar day, matching
+// Alpha Vantage's reset at midnight UTC. The per-minute limit is a token bucket
+// holding up to perMinute requests that refills continuously.
 type RateLimiter struct {
 	mu            sync.Mutex
 	dailyLimit    int
 	requestCount  int
 	lastResetTime time.Time
+
+	// Per-minute token bucket (disabled when perMinute is 0)
+	perMinute  int
+	tokens     float64
+	lastRefill time.Time
+
+	// Set when the provider reports throttling; no requests before this time
+	pausedUntil time.Time
+
+	// Optional persistent store shared across processes
+	provider string
+	store    QuotaStore
 }
 
 // RateLimiterStatus provides current status of the rate limiter.
 type RateLimiterStatus struct {
 	DailyLimit    int
+	MinuteLimit   int // 0 when there is no per-minute limit
 	RequestsUsed  int
 	RequestsLeft  int
 	LastResetTime time.Time
@@ -28,46 +57,177 @@ func NewRateLimiter(dailyLimit int) *RateLimiter {
 	return &RateLimiter{
 		dailyLimit:    dailyLimit,
 		requestCount:  0,
-		lastResetTime: time.Now(),
+		lastResetTime: startOfUTCDay(time.Now()),
 	}
 }
 
-// Wait blocks if the rate limit has been exceeded, returning an error with a friendly message.
+// SetPerMinuteLimit enables a per-minute burst limit of perMinute requests.
+// A value of 0 disables it.
+func (rl *RateLimiter) SetPerMinuteLimit(perMinute int) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.perMinute = max(perMinute, 0)
+	rl.tokens = float64(rl.perMinute)
+	rl.lastRefill = time.Now()
+}
+
+// AttachStore makes the rate limiter load and record usage in a persistent store
+// under the given provider name. Today's recorded usage is loaded immediately.
+func (rl *RateLimiter) AttachStore(provider string, store QuotaStore) error {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.provider = provider
+	rl.store = store
+	rl.resetIfNewDay()
+
+	return rl.loadFromStore()
+}
+
+// Wait blocks until the per-minute limit allows another request and reserves
+// a slot of the daily quota for it, so concurrent callers cannot overshoot it.
+// A request that never reached the provider hands its slot back with Release.
+// It does not wait for the daily quota to reset; when the quota is used up it
+// returns an error with a friendly message instead.
 func (rl *RateLimiter) Wait() error {
+	return rl.wait(context.Background())
+}
+
+// WaitContext blocks like Wait until a request may be made, or ctx is done.
+// When the daily quota is used up it returns the quota error right away rather
+// than sleeping until the reset, so a refresh can defer the symbols it has left.
+func (rl *RateLimiter) WaitContext(ctx context.Context) error {
+	return rl.wait(ctx)
+}
+
+// Throttle pauses all requests for d, e.g. after the provider reported that
+// requests are coming in too fast.
+func (rl *RateLimiter) Throttle(d time.Duration) {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	// Check if we need to reset (new day)
-	if time.Since(rl.lastResetTime) >= 24*time.Hour {
-		rl.reset()
+	if until := time.Now().Add(d); until.After(rl.pausedUntil) {
+		rl.pausedUntil = until
 	}
+}
+
+// wait blocks until reserve grants a request slot.
+func (rl *RateLimiter) wait(ctx context.Context) error {
+	for {
+		delay, err := rl.reserve()
+		if err != nil || delay == 0 {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
 
-	// Check if we've exceeded the limit
+// reserve takes a per-minute token and a daily slot if a request may be made
+// now. Otherwise it returns how long to wait before trying again, or the quota
+// error when the daily quota is used up.
+func (rl *RateLimiter) reserve() (time.Duration, error) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	// Check if we need to reset (new day)
+	rl.resetIfNewDay()
+
+	// Pick up requests recorded by other processes since the last check.
+	// If the store is unavailable the in-memory count is used instead.
+	_ = rl.loadFromStore()
+
+	now := time.Now()
+
+	// Check if we've exceeded the daily limit
 	if rl.requestCount >= rl.dailyLimit {
-		timeUntilReset := 24*time.Hour - time.Since(rl.lastResetTime)
-		hoursUntilReset := int(timeUntilReset.Hours())
-		minutesUntilReset := int(timeUntilReset.Minutes()) % 60
-
-		return fmt.Errorf(
-			"daily API quota exceeded (%d/%d requests used). Quota resets in %dh%dm. "+
-				"Consider: (1) waiting for reset, (2) using CSV import for historical data, "+
-				"or (3) upgrading to a paid API plan",
-			rl.requestCount,
-			rl.dailyLimit,
-			hoursUntilReset,
-			minutesUntilReset,
-		)
+		return 0, rl.quotaError()
 	}
 
-	return nil
+	if now.Before(rl.pausedUntil) {
+		return rl.pausedUntil.Sub(now), nil
+	}
+
+	if rl.perMinute > 0 {
+		// Refill the bucket for the time since the last request
+		rate := float64(rl.perMinute) / float64(time.Minute)
+		rl.tokens = min(float64(rl.perMinute), rl.tokens+float64(now.Sub(rl.lastRefill))*rate)
+		rl.lastRefill = now
+
+		if rl.tokens < 1 {
+			return time.Duration((1 - rl.tokens) / rate), nil
+		}
+		rl.tokens--
+	}
+
+	rl.record()
+	return 0, nil
 }
 
-// RecordRequest records that a request was made.
+// Release hands back the daily slot reserved by Wait for a request that failed
+// before reaching the provider. Slots reserved before the quota reset are not
+// handed back, since they were counted against the previous day.
+func (rl *RateLimiter) Release() {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	day := rl.lastResetTime
+	rl.resetIfNewDay()
+	if !rl.lastResetTime.Equal(day) || rl.requestCount == 0 {
+		return
+	}
+	rl.requestCount--
+
+	if rl.store != nil {
+		// A failed write leaves the slot used in the store, which only errs
+		// on the side of the quota
+		_ = rl.store.ReleaseRequest(rl.provider, rl.lastResetTime.Format("2006-01-02"))
+	}
+}
+
+// quotaError describes an exhausted daily quota (must be called with lock held).
+func (rl *RateLimiter) quotaError() error {
+	timeUntilReset := time.Until(rl.lastResetTime.Add(24 * time.Hour))
+	hoursUntilReset := int(timeUntilReset.Hours())
+	minutesUntilReset := int(timeUntilReset.Minutes()) % 60
+
+	return fmt.Errorf(
+		"%w (%d/%d requests used). Quota resets in %dh%dm. "+
+			"Consider: (1) waiting for reset, (2) using CSV import (`momo import`) for historical data, "+
+			"or (3) upgrading to a paid API plan",
+		ErrQuotaExceeded,
+		rl.requestCount,
+		rl.dailyLimit,
+		hoursUntilReset,
+		minutesUntilReset,
+	)
+}
+
+// RecordRequest records a request that was made without reserving it through Wait.
 func (rl *RateLimiter) RecordRequest() {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
+	rl.resetIfNewDay()
+	rl.record()
+}
+
+// record counts a request against today's quota (must be called with lock held).
+func (rl *RateLimiter) record() {
 	rl.requestCount++
+
+	if rl.store != nil {
+		// A failed write only loses cross-process visibility; the in-memory
+		// count still protects this process from overrunning the quota.
+		_ = rl.store.RecordRequest(rl.provider, rl.lastResetTime.Format("2006-01-02"))
+	}
 }
 
 // GetStatus returns the current status of the rate limiter.
@@ -75,6 +235,8 @@ func (rl *RateLimiter) GetStatus() *RateLimiterStatus {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
+	rl.resetIfNewDay()
+
 	requestsLeft := rl.dailyLimit - rl.requestCount
 	if requestsLeft < 0 {
 		requestsLeft = 0
@@ -84,6 +246,7 @@ func (rl *RateLimiter) GetStatus() *RateLimiterStatus {
 
 	return &RateLimiterStatus{
 		DailyLimit:    rl.dailyLimit,
+		MinuteLimit:   rl.perMinute,
 		RequestsUsed:  rl.requestCount,
 		RequestsLeft:  requestsLeft,
 		LastResetTime: rl.lastResetTime,
@@ -91,7 +254,8 @@ func (rl *RateLimiter) GetStatus() *RateLimiterStatus {
 	}
 }
 
-// Reset manually resets the rate limiter.
+// Reset manually resets the in-memory counter.
+// Usage already recorded in an attached store is not affected.
 func (rl *RateLimiter) Reset() {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
@@ -102,5 +266,40 @@ func (rl *RateLimiter) Reset() {
 // reset is the internal reset implementation (must be called with lock held).
 func (rl *RateLimiter) reset() {
 	rl.requestCount = 0
-	rl.lastResetTime = time.Now()
+	rl.lastResetTime = startOfUTCDay(time.Now())
+}
+
+// resetIfNewDay starts a new quota window when the UTC day has changed (must be called with lock held).
+func (rl *RateLimiter) resetIfNewDay() {
+	if time.Since(rl.lastResetTime) >= 24*time.Hour {
+		rl.reset()
+	}
+}
+
+// loadFromStore raises the request count to the usage recorded in the attached store,
+// which includes requests made by other processes (must be called with lock held).
+func (rl *RateLimiter) loadFromStore() error {
+	if rl.store == nil {
+		return nil
+	}
+
+	used, err := rl.store.RequestsUsed(rl.provider, rl.lastResetTime.Format("2006-01-02"))
+	if err != nil {
+		return fmt.Errorf("failed to load quota usage for %s: %w", rl.provider, err)
+	}
+	if used > rl.requestCount {
+		rl.requestCount = used
+	}
+
+	return nil
+}
+
+// startOfUTCDay returns midnight UTC of the day containing t.
+func startOfUTCDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// QuotaDay returns the quota window (UTC day, yyyy-mm-dd) that t falls in.
+func QuotaDay(t time.Time) string {
+	return startOfUTCDay(t).Format("2006-01-02")
 }
@@ -1,6 +1,9 @@
 package fetch
 
 import (
+	"context"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -31,7 +34,6 @@ func TestRateLimiter_Wait_ExceedsLimit(t *testing.T) {
 	for i := 0; i < 5; i++ {
 		err := rl.Wait()
 		require.NoError(t, err)
-		rl.RecordRequest()
 	}
 
 	// Next request should fail
@@ -103,8 +105,9 @@ func TestRateLimiter_AutoReset(t *testing.T) {
 	err := rl.Wait()
 	assert.NoError(t, err)
 
+	// Only the request just granted counts against the new day
 	status := rl.GetStatus()
-	assert.Equal(t, 0, status.RequestsUsed)
+	assert.Equal(t, 1, status.RequestsUsed)
 }
 
 func TestRateLimiter_FriendlyErrorMessage(t *testing.T) {
@@ -113,7 +116,6 @@ func TestRateLimiter_FriendlyErrorMessage(t *testing.T) {
 	// Exhaust quota
 	for i := 0; i < 3; i++ {
 		rl.Wait()
-		rl.RecordRequest()
 	}
 
 	err := rl.Wait()
@@ -127,3 +129,176 @@ func TestRateLimiter_FriendlyErrorMessage(t *testing.T) {
 	assert.Contains(t, err.Error(), "CSV import")
 	assert.Contains(t, err.Error(), "paid API plan")
 }
+
+// memoryQuotaStore is an in-memory QuotaStore for tests.
+type memoryQuotaStore struct {
+	usage map[string]int
+}
+
+func newMemoryQuotaStore() *memoryQuotaStore {
+	return &memoryQuotaStore{usage: make(map[string]int)}
+}
+
+func (s *memoryQuotaStore) RequestsUsed(provider, day string) (int, error) {
+	return s.usage[provider+"/"+day], nil
+}
+
+func (s *memoryQuotaStore) RecordRequest(provider, day string) error {
+	s.usage[provider+"/"+day]++
+	return nil
+}
+
+func (s *memoryQuotaStore) ReleaseRequest(provider, day string) error {
+	s.usage[provider+"/"+day]--
+	return nil
+}
+
+func TestRateLimiter_AttachStore_LoadsUsage(t *testing.T) {
+	store := newMemoryQuotaStore()
+	today := QuotaDay(time.Now())
+	store.usage["alpha_vantage/"+today] = 20
+
+	rl := NewRateLimiter(25)
+	require.NoError(t, rl.AttachStore("alpha_vantage", store))
+
+	status := rl.GetStatus()
+	assert.Equal(t, 20, status.RequestsUsed)
+	assert.Equal(t, 5, status.RequestsLeft)
+}
+
+func TestRateLimiter_AttachStore_PersistsAcrossRestarts(t *testing.T) {
+	store := newMemoryQuotaStore()
+
+	// First "process" uses up the quota
+	first := NewRateLimiter(3)
+	require.NoError(t, first.AttachStore("alpha_vantage", store))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, first.Wait())
+	}
+
+	// A fresh limiter sharing the store starts exhausted
+	second := NewRateLimiter(3)
+	require.NoError(t, second.AttachStore("alpha_vantage", store))
+
+	err := second.Wait()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "3/3 requests used")
+}
+
+func TestRateLimiter_Wait_SeesOtherProcessUsage(t *testing.T) {
+	store := newMemoryQuotaStore()
+
+	rl := NewRateLimiter(2)
+	require.NoError(t, rl.AttachStore("alpha_vantage", store))
+	require.NoError(t, rl.Wait())
+
+	// Another process records a request after this limiter was created
+	today := QuotaDay(time.Now())
+	require.NoError(t, store.RecordRequest("alpha_vantage", today))
+
+	assert.Error(t, rl.Wait())
+}
+
+func TestRateLimiter_WaitReservesDailySlot(t *testing.T) {
+	store := newMemoryQuotaStore()
+	rl := NewRateLimiter(5)
+	require.NoError(t, rl.AttachStore("alpha_vantage", store))
+
+	// Workers waiting at once cannot overshoot the quota
+	var granted atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if rl.Wait() == nil {
+				granted.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(5), granted.Load())
+	assert.Equal(t, 5, store.usage["alpha_vantage/"+QuotaDay(time.Now())])
+
+	// A request that failed in transport hands its slot back
+	rl.Release()
+	assert.Equal(t, 4, rl.GetStatus().RequestsUsed)
+	assert.Equal(t, 4, store.usage["alpha_vantage/"+QuotaDay(time.Now())])
+	require.NoError(t, rl.Wait())
+	assert.ErrorIs(t, rl.Wait(), ErrQuotaExceeded)
+}
+
+func TestRateLimiter_NextResetIsUTCMidnight(t *testing.T) {
+	rl := NewRateLimiter(25)
+
+	status := rl.GetStatus()
+	assert.Equal(t, time.UTC, status.NextResetTime.Location())
+	assert.Equal(t, 0, status.NextResetTime.Hour())
+	assert.Equal(t, 0, status.NextResetTime.Minute())
+	assert.True(t, status.NextResetTime.After(time.Now()))
+}
+
+func TestRateLimiter_PerMinuteBucketWaits(t *testing.T) {
+	rl := NewRateLimiter(100)
+	rl.SetPerMinuteLimit(600) // One token every 100ms
+
+	// Drain the bucket; the next request has to wait for a refill
+	rl.mu.Lock()
+	rl.tokens = 0
+	rl.lastRefill = time.Now()
+	rl.mu.Unlock()
+
+	start := time.Now()
+	require.NoError(t, rl.WaitContext(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond)
+
+	status := rl.GetStatus()
+	assert.Equal(t, 600, status.MinuteLimit)
+}
+
+func TestRateLimiter_PerMinuteBurst(t *testing.T) {
+	rl := NewRateLimiter(100)
+	rl.SetPerMinuteLimit(3)
+
+	// A full bucket allows a burst without waiting
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, rl.Wait())
+	}
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+
+	// The fourth request waits ~20s for a token; give up via the context
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, rl.WaitContext(ctx), context.DeadlineExceeded)
+}
+
+func TestRateLimiter_WaitContext_DailyQuota(t *testing.T) {
+	rl := NewRateLimiter(1)
+	require.NoError(t, rl.WaitContext(context.Background()))
+
+	// The deadline comes before midnight UTC, so waiting for the reset is pointless
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	start := time.Now()
+	err := rl.WaitContext(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "daily API quota exceeded")
+	assert.Less(t, time.Since(start), time.Second)
+
+	// Without a deadline it does not sleep until the reset either
+	start = time.Now()
+	err = rl.WaitContext(context.Background())
+	assert.ErrorIs(t, err, ErrQuotaExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRateLimiter_Throttle(t *testing.T) {
+	rl := NewRateLimiter(100)
+	rl.Throttle(100 * time.Millisecond)
+
+	start := time.Now()
+	require.NoError(t, rl.WaitContext(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond)
+}
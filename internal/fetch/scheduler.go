@@ -3,116 +3,216 @@ package fetch
 import (
 	"context"
 	"fmt"
+	"math"
+	"net/http"
+	"sort"
 	"sync"
 	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
 )
 
 // FetchResult represents the result of a fetch operation.
 type FetchResult struct {
-	Symbol    string
-	Success   bool
-	Error     error
+	Symbol         string
+	Provider       string
+	Success        bool
+	Error          error
+	Bars           []Bar // Parsed bars, sorted ascending by date
 	RecordsFetched int
-	Duration  time.Duration
-	Timestamp time.Time
+	Duration       time.Duration
+	Timestamp      time.Time
 }
 
 // FetchTask represents a task to fetch data for a symbol.
 type FetchTask struct {
-	Symbol     string
-	OutputSize string // "compact" or "full"
+	Symbol string
+	From   time.Time // Zero requests the provider's full history
+	To     time.Time // Zero requests up to the latest available bar
+}
+
+// FetchEventType identifies a stage in fetching a single symbol.
+type FetchEventType string
+
+const (
+	// FetchStarted is emitted when a worker picks up a symbol.
+	FetchStarted FetchEventType = "started"
+	// FetchRetrying is emitted before a provider retries a failed HTTP request.
+	FetchRetrying FetchEventType = "retrying"
+	// FetchFinished is emitted once per symbol with its result.
+	FetchFinished FetchEventType = "finished"
+)
+
+// FetchEvent reports per-symbol progress while the scheduler runs.
+type FetchEvent struct {
+	Type      FetchEventType
+	Symbol    string
+	Provider  string
+	Attempt   int          // Retry number for FetchRetrying (1 = first retry)
+	Result    *FetchResult // Set for FetchFinished
+	Completed int          // Symbols finished so far in this call
+	Total     int          // Symbols in this call
 }
 
 // Scheduler manages concurrent fetching of data across multiple symbols.
+// A scheduler holds no per-call state and may be used for any number of calls.
 type Scheduler struct {
-	avClient    *AlphaVantageClient
-	maxWorkers  int
-	resultsChan chan FetchResult
+	providers  *Providers
+	maxWorkers int
 }
 
-// NewScheduler creates a new fetch scheduler.
-func NewScheduler(avClient *AlphaVantageClient, maxWorkers int) *Scheduler {
+// NewScheduler creates a new fetch scheduler that routes each symbol to its provider.
+func NewScheduler(providers *Providers, maxWorkers int) *Scheduler {
+	if maxWorkers < 1 {
+		maxWorkers = 1
+	}
 	return &Scheduler{
-		avClient:    avClient,
-		maxWorkers:  maxWorkers,
-		resultsChan: make(chan FetchResult, 100),
+		providers:  providers,
+		maxWorkers: maxWorkers,
 	}
 }
 
-// FetchSymbols fetches data for multiple symbols concurrently.
-// It respects the rate limiter and uses a worker pool to control concurrency.
-func (s *Scheduler) FetchSymbols(ctx context.Context, symbols []string, outputSize string) ([]FetchResult, error) {
-	if len(symbols) == 0 {
+// FetchSymbols fetches data for multiple symbols concurrently over the same date range.
+func (s *Scheduler) FetchSymbols(ctx context.Context, symbols []string, from, to time.Time) ([]FetchResult, error) {
+	tasks := make([]FetchTask, len(symbols))
+	for i, symbol := range symbols {
+		tasks[i] = FetchTask{
+			Symbol: symbol,
+			From:   from,
+			To:     to,
+		}
+	}
+
+	return s.FetchTasks(ctx, tasks)
+}
+
+// FetchTasks executes fetch tasks concurrently and returns once every task has finished.
+// It respects the providers' rate limiters and uses a worker pool to control concurrency.
+func (s *Scheduler) FetchTasks(ctx context.Context, fetchTasks []FetchTask) ([]FetchResult, error) {
+	events, err := s.Stream(ctx, fetchTasks)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []FetchResult
+	for event := range events {
+		if event.Type == FetchFinished {
+			results = append(results, *event.Result)
+		}
+	}
+
+	return results, nil
+}
+
+// Stream executes fetch tasks concurrently and reports progress as it happens.
+// Every task produces a FetchStarted and a FetchFinished event, with FetchRetrying
+// events in between for retried HTTP requests. Tasks still queued when ctx is
+// canceled finish with ctx.Err() without being fetched, and in-flight requests
+// are aborted. The channel is closed after the last task finishes; callers must
+// drain it.
+func (s *Scheduler) Stream(ctx context.Context, fetchTasks []FetchTask) (<-chan FetchEvent, error) {
+	if len(fetchTasks) == 0 {
 		return nil, fmt.Errorf("no symbols provided")
 	}
 
 	// Create task queue
-	tasks := make(chan FetchTask, len(symbols))
-	for _, symbol := range symbols {
-		tasks <- FetchTask{
-			Symbol:     symbol,
-			OutputSize: outputSize,
-		}
+	tasks := make(chan FetchTask, len(fetchTasks))
+	for _, task := range fetchTasks {
+		tasks <- task
 	}
 	close(tasks)
 
+	run := &schedulerRun{
+		events: make(chan FetchEvent, s.maxWorkers*2),
+		total:  len(fetchTasks),
+	}
+
 	// Create worker pool
 	var wg sync.WaitGroup
 	for i := 0; i < s.maxWorkers; i++ {
 		wg.Add(1)
-		go s.worker(ctx, &wg, tasks)
+		go s.worker(ctx, &wg, tasks, run)
 	}
 
-	// Close results channel when all workers are done
+	// Close the events channel when all workers are done
 	go func() {
 		wg.Wait()
-		close(s.resultsChan)
+		close(run.events)
 	}()
 
-	// Collect results
-	var results []FetchResult
-	for result := range s.resultsChan {
-		results = append(results, result)
-	}
+	return run.events, nil
+}
 
-	return results, nil
+// schedulerRun holds the state of a single Stream call.
+type schedulerRun struct {
+	events    chan FetchEvent
+	mu        sync.Mutex
+	completed int
+	total     int
+}
+
+// emit sends an event, counting finished tasks so Completed is increasing.
+func (r *schedulerRun) emit(event FetchEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if event.Type == FetchFinished {
+		r.completed++
+	}
+	event.Completed = r.completed
+	event.Total = r.total
+	r.events <- event
 }
 
 // worker processes fetch tasks from the queue.
-func (s *Scheduler) worker(ctx context.Context, wg *sync.WaitGroup, tasks <-chan FetchTask) {
+func (s *Scheduler) worker(ctx context.Context, wg *sync.WaitGroup, tasks <-chan FetchTask, run *schedulerRun) {
 	defer wg.Done()
 
 	for task := range tasks {
+		providerName := s.providers.For(task.Symbol).Name()
+
 		// Check if context is canceled
-		select {
-		case <-ctx.Done():
-			s.resultsChan <- FetchResult{
-				Symbol:    task.Symbol,
-				Success:   false,
-				Error:     ctx.Err(),
-				Timestamp: time.Now(),
-			}
+		if err := ctx.Err(); err != nil {
+			run.emit(FetchEvent{
+				Type:     FetchFinished,
+				Symbol:   task.Symbol,
+				Provider: providerName,
+				Result: &FetchResult{
+					Symbol:    task.Symbol,
+					Provider:  providerName,
+					Success:   false,
+					Error:     err,
+					Timestamp: time.Now(),
+				},
+			})
 			continue
-		default:
 		}
 
+		run.emit(FetchEvent{Type: FetchStarted, Symbol: task.Symbol, Provider: providerName})
+
+		taskCtx := withRetryNotifier(ctx, func(attempt int) {
+			run.emit(FetchEvent{Type: FetchRetrying, Symbol: task.Symbol, Provider: providerName, Attempt: attempt})
+		})
+
 		// Fetch data
-		result := s.fetchSymbol(task)
-		s.resultsChan <- result
+		result := s.fetchSymbol(taskCtx, task)
+		run.emit(FetchEvent{Type: FetchFinished, Symbol: task.Symbol, Provider: providerName, Result: &result})
 	}
 }
 
-// fetchSymbol fetches data for a single symbol.
-func (s *Scheduler) fetchSymbol(task FetchTask) FetchResult {
+// fetchSymbol fetches data for a single symbol from its provider.
+func (s *Scheduler) fetchSymbol(ctx context.Context, task FetchTask) FetchResult {
 	startTime := time.Now()
+	provider := s.providers.For(task.Symbol)
 
-	data, err := s.avClient.FetchDailyAdjusted(task.Symbol, task.OutputSize)
+	bars, err := provider.FetchDaily(ctx, task.Symbol, task.From, task.To)
 
 	duration := time.Since(startTime)
 
 	if err != nil {
 		return FetchResult{
 			Symbol:    task.Symbol,
+			Provider:  provider.Name(),
 			Success:   false,
 			Error:     err,
 			Duration:  duration,
@@ -122,50 +222,103 @@ func (s *Scheduler) fetchSymbol(task FetchTask) FetchResult {
 
 	return FetchResult{
 		Symbol:         task.Symbol,
+		Provider:       provider.Name(),
 		Success:        true,
 		Error:          nil,
-		RecordsFetched: len(data.TimeSeries),
+		Bars:           bars,
+		RecordsFetched: len(bars),
 		Duration:       duration,
 		Timestamp:      time.Now(),
 	}
 }
 
-// GetRateLimiterStatus returns the current rate limiter status.
-func (s *Scheduler) GetRateLimiterStatus() *RateLimiterStatus {
-	return s.avClient.GetRateLimiterStatus()
+// retryNotifierKey is the context key for the per-task retry callback.
+type retryNotifierKey struct{}
+
+// withRetryNotifier returns a context that makes HTTP providers call notify
+// before each retry of a request made with it.
+func withRetryNotifier(ctx context.Context, notify func(attempt int)) context.Context {
+	return context.WithValue(ctx, retryNotifierKey{}, notify)
+}
+
+// notifyRetryContext reports a retry to the callback installed in ctx, if any.
+// Providers that retry outside the HTTP client use it to report their retries.
+func notifyRetryContext(ctx context.Context, attempt int) {
+	if notify, ok := ctx.Value(retryNotifierKey{}).(func(int)); ok {
+		notify(attempt)
+	}
+}
+
+// notifyRetry is installed as the RequestLogHook of the providers' retrying
+// HTTP clients. The hook runs before every attempt; attempt 0 is the first try.
+func notifyRetry(_ retryablehttp.Logger, req *http.Request, attempt int) {
+	if attempt == 0 {
+		return
+	}
+	notifyRetryContext(req.Context(), attempt)
+}
+
+// Quotas returns the quota status of every rate-limited provider, keyed by provider name.
+func (s *Scheduler) Quotas() map[string]*RateLimiterStatus {
+	quotas := make(map[string]*RateLimiterStatus)
+	for _, provider := range s.providers.All() {
+		if status := provider.Quota(); status != nil {
+			quotas[provider.Name()] = status
+		}
+	}
+	return quotas
 }
 
 // PrioritizeFetchOrder determines which symbols to fetch first based on staleness.
 // Returns symbols ordered by priority (most stale first).
 func PrioritizeFetchOrder(symbols []string, lastFetchTimes map[string]time.Time) []string {
+	return PrioritizeFetchOrderRanked(symbols, lastFetchTimes, nil)
+}
+
+// PrioritizeFetchOrderRanked orders symbols by staleness like PrioritizeFetchOrder,
+// breaking ties between symbols that are equally many days behind by ranking
+// relevance: top-ranked symbols first, then those just below the cutoff, then
+// the rest of the ranked universe and finally unranked symbols. ranks maps a
+// symbol to its latest rank (1 = best); missing symbols are unranked.
+// Symbols with equal priority keep their input order.
+func PrioritizeFetchOrderRanked(symbols []string, lastFetchTimes map[string]time.Time, ranks map[string]int) []string {
 	type symbolPriority struct {
 		symbol      string
 		lastFetched time.Time
+		staleDay    time.Time
+		rank        int
 	}
 
 	// Build priority list
 	priorities := make([]symbolPriority, 0, len(symbols))
 	for _, symbol := range symbols {
-		lastFetched, exists := lastFetchTimes[symbol]
-		if !exists {
-			// Never fetched - highest priority (use zero time)
-			lastFetched = time.Time{}
+		// Never fetched - highest priority (zero time)
+		lastFetched := lastFetchTimes[symbol]
+
+		rank, ok := ranks[symbol]
+		if !ok || rank < 1 {
+			rank = math.MaxInt
 		}
+
 		priorities = append(priorities, symbolPriority{
 			symbol:      symbol,
 			lastFetched: lastFetched,
+			staleDay:    lastFetched.UTC().Truncate(24 * time.Hour),
+			rank:        rank,
 		})
 	}
 
-	// Sort by staleness (oldest first)
-	// Using a simple bubble sort for small lists
-	for i := 0; i < len(priorities)-1; i++ {
-		for j := 0; j < len(priorities)-i-1; j++ {
-			if priorities[j].lastFetched.After(priorities[j+1].lastFetched) {
-				priorities[j], priorities[j+1] = priorities[j+1], priorities[j]
-			}
+	// Sort by staleness in days (oldest first), then rank, then exact staleness
+	sort.SliceStable(priorities, func(i, j int) bool {
+		a, b := priorities[i], priorities[j]
+		if !a.staleDay.Equal(b.staleDay) {
+			return a.staleDay.Before(b.staleDay)
 		}
-	}
+		if a.rank != b.rank {
+			return a.rank < b.rank
+		}
+		return a.lastFetched.Before(b.lastFetched)
+	})
 
 	// Extract ordered symbols
 	orderedSymbols := make([]string, len(priorities))
@@ -1,10 +1,17 @@
 package fetch
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPrioritizeFetchOrder_NeverFetched(t *testing.T) {
@@ -63,6 +70,23 @@ func TestPrioritizeFetchOrder_AllFetchedRecently(t *testing.T) {
 	assert.Equal(t, "IWM", ordered[2])
 }
 
+func TestPrioritizeFetchOrderRanked(t *testing.T) {
+	day := time.Date(2025, 10, 8, 0, 0, 0, 0, time.UTC)
+	symbols := []string{"UNRANKED", "MID", "TOP", "STALE", "NEW"}
+	lastFetchTimes := map[string]time.Time{
+		"UNRANKED": day,
+		"MID":      day,
+		"TOP":      day,
+		"STALE":    day.AddDate(0, 0, -3),
+	}
+	ranks := map[string]int{"TOP": 1, "MID": 6, "STALE": 20}
+
+	ordered := PrioritizeFetchOrderRanked(symbols, lastFetchTimes, ranks)
+
+	// Staleness first; rank only breaks ties between equally stale symbols
+	assert.Equal(t, []string{"NEW", "STALE", "TOP", "MID", "UNRANKED"}, ordered)
+}
+
 func TestEstimateFetchTime_NoSymbols(t *testing.T) {
 	duration := EstimateFetchTime(0, 5)
 	assert.Equal(t, time.Duration(0), duration)
@@ -85,3 +109,131 @@ func TestEstimateFetchTime_SingleWorker(t *testing.T) {
 	duration := EstimateFetchTime(10, 1)
 	assert.Equal(t, 20*time.Second, duration)
 }
+
+// newCSVScheduler creates a scheduler reading SPY and QQQ from a temp CSV directory.
+func newCSVScheduler(t *testing.T) *Scheduler {
+	t.Helper()
+
+	dir := t.TempDir()
+	csvData := "Date,Open,High,Low,Close,Volume\n2024-01-02,100,102,99,101,1000\n2024-01-03,101,103,100,102,1100\n"
+	for _, symbol := range []string{"SPY", "QQQ"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, symbol+".csv"), []byte(csvData), 0644))
+	}
+
+	return NewScheduler(NewProviders(NewCSVProvider(dir)), 2)
+}
+
+func TestScheduler_StreamEvents(t *testing.T) {
+	scheduler := newCSVScheduler(t)
+
+	events, err := scheduler.Stream(context.Background(), []FetchTask{{Symbol: "SPY"}, {Symbol: "QQQ"}, {Symbol: "IWM"}})
+	require.NoError(t, err)
+
+	started := map[string]bool{}
+	var finished []FetchEvent
+	for event := range events {
+		assert.Equal(t, 3, event.Total)
+		switch event.Type {
+		case FetchStarted:
+			started[event.Symbol] = true
+		case FetchFinished:
+			assert.True(t, started[event.Symbol], "%s finished before it started", event.Symbol)
+			finished = append(finished, event)
+		}
+	}
+
+	require.Len(t, finished, 3)
+	for i, event := range finished {
+		assert.Equal(t, i+1, event.Completed)
+		require.NotNil(t, event.Result)
+		assert.Equal(t, event.Symbol != "IWM", event.Result.Success)
+	}
+}
+
+func TestScheduler_Reusable(t *testing.T) {
+	scheduler := newCSVScheduler(t)
+
+	for i := 0; i < 2; i++ {
+		results, err := scheduler.FetchSymbols(context.Background(), []string{"SPY", "QQQ"}, time.Time{}, time.Time{})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		for _, result := range results {
+			assert.True(t, result.Success)
+			assert.Equal(t, 2, result.RecordsFetched)
+		}
+	}
+}
+
+func TestScheduler_CancelAbortsRequest(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	provider := NewHTTPProvider(server.URL, "", time.Minute, 0)
+	scheduler := NewScheduler(NewProviders(provider), 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := scheduler.Stream(ctx, []FetchTask{{Symbol: "SPY"}, {Symbol: "QQQ"}})
+	require.NoError(t, err)
+
+	var results []*FetchResult
+	for event := range events {
+		switch event.Type {
+		case FetchStarted:
+			cancel()
+		case FetchFinished:
+			results = append(results, event.Result)
+		}
+	}
+
+	// The in-flight request is aborted and the queued symbol is not fetched
+	require.Len(t, results, 2)
+	for _, result := range results {
+		assert.False(t, result.Success)
+		assert.ErrorIs(t, result.Error, context.Canceled)
+	}
+}
+
+func TestScheduler_RetryEvents(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"symbol":"SPY","bars":[{"date":"2024-01-02","open":100,"high":102,"low":99,"close":101,"volume":1000}]}`))
+	}))
+	defer server.Close()
+
+	provider := NewHTTPProvider(server.URL, "", 5*time.Second, 2)
+	provider.httpClient.RetryWaitMin = time.Millisecond
+	provider.httpClient.RetryWaitMax = time.Millisecond
+	scheduler := NewScheduler(NewProviders(provider), 1)
+
+	events, err := scheduler.Stream(context.Background(), []FetchTask{{Symbol: "SPY"}})
+	require.NoError(t, err)
+
+	var types []FetchEventType
+	var last FetchEvent
+	for event := range events {
+		types = append(types, event.Type)
+		last = event
+	}
+
+	assert.Equal(t, []FetchEventType{FetchStarted, FetchRetrying, FetchFinished}, types)
+	require.NotNil(t, last.Result)
+	assert.True(t, last.Result.Success)
+}
+
+func TestScheduler_NoTasks(t *testing.T) {
+	_, err := newCSVScheduler(t).Stream(context.Background(), nil)
+	assert.Error(t, err)
+}
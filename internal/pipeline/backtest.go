@@ -0,0 +1,112 @@
+package pipeline
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cajundata/momorot/internal/analytics"
+	"github.com/cajundata/momorot/internal/backtest"
+	"github.com/cajundata/momorot/internal/config"
+	"github.com/cajundata/momorot/internal/db"
+)
+
+// NewBacktestConfig returns the backtest settings for a date range: the top N
+// from app.top_n and the rest from the backtest section of the config.
+func NewBacktestConfig(cfg *config.Config, from, to time.Time) backtest.Config {
+	return backtest.Config{
+		From:             from,
+		To:               to,
+		TopN:             cfg.App.TopN,
+		Rebalance:        cfg.Backtest.Rebalance,
+		CostBps:          cfg.Backtest.CostBps,
+		CashSymbol:       cfg.Backtest.CashSymbol,
+		AbsoluteMomentum: cfg.Backtest.AbsoluteMomentum,
+	}
+}
+
+// RunBacktest replays the strategy over the stored prices, ranking each
+// rebalance day with the configured lookbacks, indicators and scoring.
+func RunBacktest(cfg *config.Config, database *db.DB, btCfg backtest.Config) (*backtest.Result, error) {
+	orchestrator := newBacktestRanker(cfg, database, btCfg)
+	history, err := loadBacktestHistory(orchestrator, btCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return backtest.Run(orchestrator, history, btCfg)
+}
+
+// NewSweepGrid returns the sweep section of the config as a grid. Without
+// weights it tries scoring.weights, and without top_n values app.top_n.
+func NewSweepGrid(cfg *config.Config) backtest.Grid {
+	grid := backtest.Grid{
+		Lambdas: cfg.Sweep.Lambdas,
+		Weights: cfg.Sweep.Weights,
+		TopN:    cfg.Sweep.TopN,
+	}
+	if len(grid.Weights) == 0 {
+		grid.Weights = []map[string]float64{cfg.Scoring.Weights}
+	}
+	if len(grid.TopN) == 0 {
+		grid.TopN = []int{cfg.App.TopN}
+	}
+	return grid
+}
+
+// RunSweep runs the backtest for every combination of the grid over the
+// stored prices, sweep.workers at a time. A zero btCfg.From starts at the
+// earliest stored price. Results are sorted by CAGR, best first.
+func RunSweep(cfg *config.Config, database *db.DB, btCfg backtest.Config, grid backtest.Grid) ([]backtest.SweepResult, error) {
+	history, err := loadBacktestHistory(NewOrchestrator(cfg, database), btCfg)
+	if err != nil {
+		return nil, err
+	}
+	if btCfg.From.IsZero() {
+		btCfg.From = history.Start()
+	}
+
+	// Each backtest ranks with its own orchestrator, sharing the history
+	newRanker := func(params backtest.Params) backtest.Ranker {
+		orchestrator := newBacktestRanker(cfg, database, btCfg)
+		orchestrator.SetScoring(params.Lambda, params.Weights)
+		return orchestrator
+	}
+
+	results := backtest.Sweep(history, btCfg, grid, newRanker, cfg.Sweep.Workers)
+	backtest.SortByCAGR(results)
+	return results, nil
+}
+
+// newBacktestRanker returns an orchestrator that ranks like the live ranking.
+// With absolute momentum it ranks with the dual momentum strategy instead of
+// scoring.strategy, measured against the cash symbol when it is ranked too.
+func newBacktestRanker(cfg *config.Config, database *db.DB, btCfg backtest.Config) *analytics.Orchestrator {
+	orchestrator := NewOrchestrator(cfg, database)
+	if btCfg.AbsoluteMomentum {
+		cashProxy := cfg.Scoring.CashProxy
+		if btCfg.CashSymbol != "" {
+			cashProxy = btCfg.CashSymbol
+		}
+		orchestrator.SetStrategy(analytics.StrategyDualMomentum, cashProxy)
+	}
+	return orchestrator
+}
+
+// loadBacktestHistory loads the prices a backtest through btCfg.To needs,
+// including those of the cash symbol.
+func loadBacktestHistory(orchestrator *analytics.Orchestrator, btCfg backtest.Config) (*analytics.History, error) {
+	var extra []string
+	if btCfg.CashSymbol != "" {
+		extra = append(extra, btCfg.CashSymbol)
+	}
+	history, err := orchestrator.LoadHistory(btCfg.To, extra...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load price history: %w", err)
+	}
+	if btCfg.CashSymbol != "" {
+		if _, ok := history.CloseOn(btCfg.CashSymbol, btCfg.To); !ok {
+			return nil, fmt.Errorf("cash symbol %s has no stored prices", btCfg.CashSymbol)
+		}
+	}
+	return history, nil
+}
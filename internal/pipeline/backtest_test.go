@@ -0,0 +1,119 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cajundata/momorot/internal/backtest"
+	"github.com/cajundata/momorot/internal/config"
+	"github.com/cajundata/momorot/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunBacktest(t *testing.T) {
+	database := setupTestDB(t)
+	csvDir := t.TempDir()
+	writePriceCSV(t, csvDir, "SPY", 60)
+	require.NoError(t, db.NewSymbolRepository(database).Create(&db.Symbol{Symbol: "SPY", Name: "SPDR S&P 500", AssetType: "ETF", Active: true}))
+
+	cfg := testConfig(csvDir)
+	cfg.App.TopN = 1
+	cfg.Backtest.Rebalance = backtest.RebalanceMonthly
+	_, err := NewRefresher(cfg, database, NewProviders(cfg, database)).Run(context.Background(), "test refresh")
+	require.NoError(t, err)
+
+	to := time.Now().AddDate(0, 0, -1)
+	btCfg := NewBacktestConfig(cfg, to.AddDate(0, 0, -120), to)
+	result, err := RunBacktest(cfg, database, btCfg)
+	require.NoError(t, err)
+
+	// Invested once 41 bars cover the 12-month lookback, at most the last 20 weekdays
+	require.NotEmpty(t, result.Curve)
+	assert.LessOrEqual(t, len(result.Curve), 20)
+	assert.Equal(t, []string{"SPY"}, result.Curve[0].Holdings)
+	assert.Greater(t, result.TotalReturn, 0.0)
+
+	btCfg.CashSymbol = "BIL"
+	_, err = RunBacktest(cfg, database, btCfg)
+	assert.Error(t, err)
+}
+
+func TestRunBacktest_AbsoluteMomentum(t *testing.T) {
+	database := setupTestDB(t)
+	csvDir := t.TempDir()
+	writePriceCSV(t, csvDir, "SPY", 60)
+	writePriceCSV(t, csvDir, "BIL", 60)
+	symbolRepo := db.NewSymbolRepository(database)
+	require.NoError(t, symbolRepo.Create(&db.Symbol{Symbol: "SPY", Name: "SPDR S&P 500", AssetType: "ETF", Active: true}))
+	require.NoError(t, symbolRepo.Create(&db.Symbol{Symbol: "BIL", Name: "SPDR 1-3 Month T-Bill", AssetType: "ETF", Active: true}))
+
+	cfg := testConfig(csvDir)
+	cfg.App.TopN = 2
+	cfg.Backtest.Rebalance = backtest.RebalanceMonthly
+	_, err := NewRefresher(cfg, database, NewProviders(cfg, database)).Run(context.Background(), "test refresh")
+	require.NoError(t, err)
+
+	to := time.Now().AddDate(0, 0, -1)
+	btCfg := NewBacktestConfig(cfg, to.AddDate(0, 0, -120), to)
+	btCfg.CashSymbol = "BIL"
+	result, err := RunBacktest(cfg, database, btCfg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BIL", "SPY"}, result.Curve[0].Holdings)
+
+	// SPY returns no more than the cash symbol, so dual momentum leaves it out
+	btCfg.AbsoluteMomentum = true
+	result, err = RunBacktest(cfg, database, btCfg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BIL"}, result.Curve[0].Holdings)
+}
+
+func TestRunSweep(t *testing.T) {
+	database := setupTestDB(t)
+	csvDir := t.TempDir()
+	writePriceCSV(t, csvDir, "SPY", 60)
+	writePriceCSV(t, csvDir, "QQQ", 60)
+	symbolRepo := db.NewSymbolRepository(database)
+	require.NoError(t, symbolRepo.Create(&db.Symbol{Symbol: "SPY", Name: "SPDR S&P 500", AssetType: "ETF", Active: true}))
+	require.NoError(t, symbolRepo.Create(&db.Symbol{Symbol: "QQQ", Name: "Invesco QQQ", AssetType: "ETF", Active: true}))
+
+	cfg := testConfig(csvDir)
+	cfg.App.TopN = 1
+	cfg.Backtest.Rebalance = backtest.RebalanceMonthly
+	cfg.Sweep = config.SweepConfig{Lambdas: []float64{0, 0.5}, Weights: []map[string]float64{nil, {"r1m": 2}}, Workers: 2}
+	_, err := NewRefresher(cfg, database, NewProviders(cfg, database)).Run(context.Background(), "test refresh")
+	require.NoError(t, err)
+
+	grid := NewSweepGrid(cfg)
+	assert.Equal(t, []int{1}, grid.TopN)
+
+	// No start date backtests the whole history
+	btCfg := NewBacktestConfig(cfg, time.Time{}, time.Now().AddDate(0, 0, -1))
+	results, err := RunSweep(cfg, database, btCfg, grid)
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	for _, r := range results {
+		require.NoError(t, r.Err)
+		assert.NotEmpty(t, r.Result.Curve)
+		assert.GreaterOrEqual(t, r.Result.CAGR, results[len(results)-1].Result.CAGR)
+	}
+}
+
+func TestNewSweepGrid(t *testing.T) {
+	cfg := testConfig(t.TempDir())
+	cfg.App.TopN = 3
+	cfg.Scoring.Weights = map[string]float64{"r12m": 2}
+	cfg.Sweep.Lambdas = []float64{0, 0.35}
+
+	// Without sweep values the configured weights and top N are tried
+	grid := NewSweepGrid(cfg)
+	assert.Equal(t, []map[string]float64{{"r12m": 2}}, grid.Weights)
+	assert.Equal(t, []int{3}, grid.TopN)
+	assert.Len(t, grid.Combinations(), 2)
+
+	cfg.Sweep.Weights = []map[string]float64{{}, {"r1m": 0}}
+	cfg.Sweep.TopN = []int{2, 5}
+	grid = NewSweepGrid(cfg)
+	assert.Len(t, grid.Combinations(), 8)
+}
@@ -0,0 +1,87 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cajundata/momorot/internal/db"
+	"github.com/cajundata/momorot/internal/export"
+	"github.com/cajundata/momorot/internal/fetch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeAlphaVantageFixture writes a recorded TIME_SERIES_DAILY_ADJUSTED response
+// with n weekday bars ending yesterday. Returns the last bar's date.
+func writeAlphaVantageFixture(t *testing.T, dir, symbol string, n int, step float64) string {
+	t.Helper()
+
+	var dates []time.Time
+	for d := time.Now().AddDate(0, 0, -1); len(dates) < n; d = d.AddDate(0, 0, -1) {
+		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+			dates = append([]time.Time{d}, dates...)
+		}
+	}
+
+	var series []string
+	for i, d := range dates {
+		price := 100.0 + step*float64(i)
+		series = append(series, fmt.Sprintf(
+			`"%s": {"1. open": "%.2f", "2. high": "%.2f", "3. low": "%.2f", "4. close": "%.2f", "5. adjusted close": "%.2f", "6. volume": "1000000", "7. dividend amount": "0.0000", "8. split coefficient": "1.0"}`,
+			d.Format("2006-01-02"), price, price+1, price-1, price, price))
+	}
+	body := fmt.Sprintf(`{"Meta Data": {"2. Symbol": %q}, "Time Series (Daily)": {%s}}`, symbol, strings.Join(series, ","))
+
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "TIME_SERIES_DAILY_ADJUSTED_"+symbol+"_full.json"), []byte(body), 0644))
+
+	return dates[len(dates)-1].Format("2006-01-02")
+}
+
+func TestRefresher_RunFromFixtures(t *testing.T) {
+	database := setupTestDB(t)
+	fixtureDir := filepath.Join(t.TempDir(), "fixtures")
+	writeAlphaVantageFixture(t, fixtureDir, "SPY", 60, 1)
+	lastDate := writeAlphaVantageFixture(t, fixtureDir, "QQQ", 60, 2)
+
+	symbolRepo := db.NewSymbolRepository(database)
+	require.NoError(t, symbolRepo.Create(&db.Symbol{Symbol: "SPY", Name: "SPDR S&P 500", AssetType: "ETF", Active: true}))
+	require.NoError(t, symbolRepo.Create(&db.Symbol{Symbol: "QQQ", Name: "Invesco QQQ", AssetType: "ETF", Active: true}))
+
+	cfg := testConfig(t.TempDir())
+	cfg.Providers.Default = fetch.ProviderAlphaVantage
+	cfg.AlphaVantage.BaseURL = "http://127.0.0.1:1/query" // Never contacted
+	cfg.AlphaVantage.DailyRequestLimit = 1
+	cfg.AlphaVantage.FixtureMode = fetch.FixtureModeReplay
+	cfg.AlphaVantage.FixtureDir = fixtureDir
+
+	// refresh -> analytics
+	result, err := NewRefresher(cfg, database, NewProviders(cfg, database)).Run(context.Background(), "fixture refresh")
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Succeeded)
+	assert.Equal(t, 0, result.Failed)
+
+	// Replays do not use up the daily quota
+	used, err := db.NewQuotaRepository(database).RequestsUsed(fetch.ProviderAlphaVantage, fetch.QuotaDay(time.Now()))
+	require.NoError(t, err)
+	assert.Equal(t, 0, used)
+
+	// -> export
+	filename, err := export.New(database, t.TempDir()).ExportFullRankings(lastDate)
+	require.NoError(t, err)
+
+	file, err := os.Open(filename)
+	require.NoError(t, err)
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3) // Header + SPY + QQQ
+	assert.Equal(t, "QQQ", records[1][1], "stronger trend ranks first")
+}
@@ -0,0 +1,213 @@
+package pipeline
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cajundata/momorot/internal/analytics"
+	"github.com/cajundata/momorot/internal/config"
+	"github.com/cajundata/momorot/internal/db"
+	"github.com/cajundata/momorot/internal/fetch"
+)
+
+// importExtensions are the file extensions picked up when importing a directory.
+var importExtensions = []string{".csv", ".txt"}
+
+// FileResult describes what an import did for a single file.
+type FileResult struct {
+	Path string
+	SymbolResult
+}
+
+// ImportResult summarizes a CSV import run.
+type ImportResult struct {
+	RunID        int64
+	Files        []FileResult
+	Created      []string // Symbols that did not exist before the import
+	Succeeded    int
+	Failed       int
+	AnalyticsErr error // Set when indicators could not be recomputed afterwards
+	Duration     time.Duration
+}
+
+// Importer loads historical prices from CSV files, recording the import as a
+// run with a fetch_log row per file so it shows up alongside API refreshes.
+type Importer struct {
+	csv          *fetch.CSVImporter
+	orchestrator *analytics.Orchestrator
+	store        *barStore
+	symbolRepo   *db.SymbolRepository
+	runRepo      *db.RunRepository
+}
+
+// NewImporter creates a CSV importer that reads files in the configured CSV
+// format (providers.csv) and writes to database.
+func NewImporter(cfg *config.Config, database *db.DB) (*Importer, error) {
+	csvImporter, err := NewCSVImporter(cfg.Providers.CSV)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure CSV format: %w", err)
+	}
+
+	return &Importer{
+		csv:          csvImporter,
+		orchestrator: NewOrchestrator(cfg, database),
+		store:        newBarStore(database),
+		symbolRepo:   db.NewSymbolRepository(database),
+		runRepo:      db.NewRunRepository(database),
+	}, nil
+}
+
+// ResolveImportPaths expands a file, directory or glob pattern into the list of
+// files to import. Directories contribute their .csv and .txt files.
+func ResolveImportPaths(target string) ([]string, error) {
+	var paths []string
+
+	info, err := os.Stat(target)
+	switch {
+	case err == nil && info.IsDir():
+		entries, err := os.ReadDir(target)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read directory %s: %w", target, err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			ext := strings.ToLower(filepath.Ext(entry.Name()))
+			for _, allowed := range importExtensions {
+				if ext == allowed {
+					paths = append(paths, filepath.Join(target, entry.Name()))
+					break
+				}
+			}
+		}
+	case err == nil:
+		paths = []string{target}
+	default:
+		paths, err = filepath.Glob(target)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %s: %w", target, err)
+		}
+	}
+
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no CSV files found for %s", target)
+	}
+
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// Run imports each file into prices. The symbol is taken from the file name
+// unless symbol is set, which is only allowed for a single file.
+func (im *Importer) Run(paths []string, symbol string) (*ImportResult, error) {
+	if symbol != "" && len(paths) > 1 {
+		return nil, fmt.Errorf("a symbol can only be given when importing a single file (got %d files)", len(paths))
+	}
+
+	startTime := time.Now()
+
+	runID, err := im.runRepo.CreateKind(db.RunKindImport, fmt.Sprintf("CSV import (%d files)", len(paths)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create run: %w", err)
+	}
+
+	result := &ImportResult{RunID: runID}
+	seen := make(map[string]string)
+
+	for _, path := range paths {
+		sym := strings.ToUpper(symbol)
+		if sym == "" {
+			sym = fetch.SymbolFromFilename(path)
+		}
+
+		var fr FileResult
+		if other, ok := seen[sym]; ok {
+			// fetch_log holds one row per symbol and run
+			fr = FileResult{
+				Path:         path,
+				SymbolResult: SymbolResult{Symbol: sym, Provider: fetch.ProviderCSV, Err: fmt.Errorf("%s was already imported from %s", sym, other)},
+			}
+		} else {
+			seen[sym] = path
+			fr = FileResult{Path: path, SymbolResult: im.importFile(runID, path, sym, result)}
+		}
+
+		if fr.Err != nil {
+			result.Failed++
+		} else {
+			result.Succeeded++
+		}
+		result.Files = append(result.Files, fr)
+	}
+
+	if result.Succeeded > 0 {
+		if _, err := im.orchestrator.ComputeAllIndicators(time.Now()); err != nil {
+			result.AnalyticsErr = err
+		} else if err := im.runRepo.SetStrategy(runID, im.orchestrator.Strategy()); err != nil {
+			return nil, fmt.Errorf("failed to record scoring strategy: %w", err)
+		}
+	}
+
+	status := "OK"
+	if result.Failed > 0 {
+		status = "ERROR"
+	}
+	if err := im.runRepo.Finish(runID, status, result.Succeeded, result.Failed); err != nil {
+		return nil, fmt.Errorf("failed to update run status: %w", err)
+	}
+
+	result.Duration = time.Since(startTime)
+	return result, nil
+}
+
+// importFile parses, validates and stores a single file for symbol.
+func (im *Importer) importFile(runID int64, path, symbol string, result *ImportResult) SymbolResult {
+	records, err := im.csv.ImportFromFile(path)
+	if err == nil {
+		err = fetch.ValidateRecords(records)
+	}
+	if err != nil {
+		err = fmt.Errorf("%s: %w", filepath.Base(path), err)
+		return im.store.save(runID, symbol, fetch.ProviderCSV, nil, err)
+	}
+
+	created, err := im.ensureSymbol(symbol)
+	if err != nil {
+		return im.store.save(runID, symbol, fetch.ProviderCSV, nil, err)
+	}
+	if created {
+		result.Created = append(result.Created, symbol)
+	}
+
+	return im.store.save(runID, symbol, fetch.ProviderCSV, fetch.RecordsToBars(records), nil)
+}
+
+// ensureSymbol creates symbol if it is not in the database yet.
+// Returns true when the symbol was created.
+func (im *Importer) ensureSymbol(symbol string) (bool, error) {
+	_, err := im.symbolRepo.Get(symbol)
+	if err == nil {
+		return false, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return false, fmt.Errorf("failed to look up symbol %s: %w", symbol, err)
+	}
+
+	if err := im.symbolRepo.Create(&db.Symbol{
+		Symbol:    symbol,
+		Name:      symbol, // Use symbol as name initially
+		AssetType: "ETF",  // Default to ETF
+		Active:    true,
+	}); err != nil {
+		return false, fmt.Errorf("failed to create symbol %s: %w", symbol, err)
+	}
+
+	return true, nil
+}
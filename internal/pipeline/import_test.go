@@ -0,0 +1,104 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cajundata/momorot/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveImportPaths(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"spy.us.txt", "QQQ.csv", "notes.md"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("Date\n"), 0644))
+	}
+
+	// Directory
+	paths, err := ResolveImportPaths(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "QQQ.csv"), filepath.Join(dir, "spy.us.txt")}, paths)
+
+	// Single file
+	paths, err = ResolveImportPaths(filepath.Join(dir, "QQQ.csv"))
+	require.NoError(t, err)
+	assert.Len(t, paths, 1)
+
+	// Glob
+	paths, err = ResolveImportPaths(filepath.Join(dir, "*.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "spy.us.txt")}, paths)
+
+	// No matches
+	_, err = ResolveImportPaths(filepath.Join(dir, "*.json"))
+	assert.Error(t, err)
+}
+
+func TestImporter_Run(t *testing.T) {
+	database := setupTestDB(t)
+	dir := t.TempDir()
+	writePriceCSV(t, dir, "spy.us", 60)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bad.csv"), []byte("Date,Open,High,Low,Close,Volume\n2024-01-02,100,99,101,100,1000\n"), 0644))
+
+	// SPY already exists; BAD does not and fails validation
+	require.NoError(t, db.NewSymbolRepository(database).Create(&db.Symbol{Symbol: "SPY", Name: "SPDR S&P 500", AssetType: "ETF", Active: true}))
+
+	paths, err := ResolveImportPaths(dir)
+	require.NoError(t, err)
+
+	importer, err := NewImporter(testConfig(dir), database)
+	require.NoError(t, err)
+	result, err := importer.Run(paths, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, result.Succeeded)
+	assert.Equal(t, 1, result.Failed)
+	assert.Empty(t, result.Created)
+	assert.NoError(t, result.AnalyticsErr)
+
+	var count int
+	require.NoError(t, database.QueryRow("SELECT COUNT(*) FROM prices WHERE symbol = 'SPY'").Scan(&count))
+	assert.Equal(t, 60, count)
+
+	// The import is recorded as a run with a fetch_log row per file
+	run, err := db.NewRunRepository(database).GetLatest()
+	require.NoError(t, err)
+	assert.Equal(t, result.RunID, run.RunID)
+	assert.Equal(t, "ERROR", run.Status)
+
+	failures, err := db.NewFetchLogRepository(database).GetFailures(result.RunID)
+	require.NoError(t, err)
+	require.Len(t, failures, 1)
+	assert.Equal(t, "BAD", failures[0].Symbol)
+	assert.Contains(t, *failures[0].Message, "high < low")
+}
+
+func TestImporter_RunCreatesSymbol(t *testing.T) {
+	database := setupTestDB(t)
+	dir := t.TempDir()
+	writePriceCSV(t, dir, "export", 10)
+
+	importer, err := NewImporter(testConfig(dir), database)
+	require.NoError(t, err)
+	result, err := importer.Run([]string{filepath.Join(dir, "export.csv")}, "iwm")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, result.Succeeded)
+	assert.Equal(t, []string{"IWM"}, result.Created)
+	assert.Equal(t, 10, result.Files[0].Rows)
+
+	sym, err := db.NewSymbolRepository(database).Get("IWM")
+	require.NoError(t, err)
+	assert.True(t, sym.Active)
+}
+
+func TestImporter_RunSymbolWithMultipleFiles(t *testing.T) {
+	database := setupTestDB(t)
+	importer, err := NewImporter(testConfig(t.TempDir()), database)
+	require.NoError(t, err)
+
+	_, err = importer.Run([]string{"a.csv", "b.csv"}, "SPY")
+	assert.Error(t, err)
+}
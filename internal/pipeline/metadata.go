@@ -0,0 +1,136 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cajundata/momorot/internal/config"
+	"github.com/cajundata/momorot/internal/db"
+	"github.com/cajundata/momorot/internal/fetch"
+)
+
+// MetadataResult describes a metadata lookup for a single symbol.
+type MetadataResult struct {
+	Symbol  string
+	Updated bool // Stored fields changed
+	Err     error
+}
+
+// MetadataEnricher fills in symbol names, asset types, exchanges, sectors and
+// currencies from a metadata source. Metadata changes rarely, so each symbol is
+// looked up at most once per refresh interval rather than on every refresh.
+type MetadataEnricher struct {
+	source     fetch.MetadataSource
+	maxAge     time.Duration
+	symbolRepo *db.SymbolRepository
+}
+
+// NewMetadataEnricher creates an enricher for the configured metadata source.
+// The alpha_vantage source reuses the Alpha Vantage client among providers, so
+// lookups share its rate limits and quota; without one, or with source none,
+// the enricher does nothing.
+func NewMetadataEnricher(cfg *config.Config, database *db.DB, providers *fetch.Providers) *MetadataEnricher {
+	return &MetadataEnricher{
+		source:     NewMetadataSource(cfg.Metadata, providers),
+		maxAge:     time.Duration(cfg.Metadata.RefreshDays) * 24 * time.Hour,
+		symbolRepo: db.NewSymbolRepository(database),
+	}
+}
+
+// NewMetadataSource returns the configured metadata source, or nil when
+// metadata lookups are disabled or the source is unavailable.
+func NewMetadataSource(metaCfg config.MetadataConfig, providers *fetch.Providers) fetch.MetadataSource {
+	switch metaCfg.Source {
+	case fetch.MetadataSourceCSV:
+		return fetch.NewCSVMetadataSource(metaCfg.CSVPath)
+	case fetch.MetadataSourceAlphaVantage:
+		if providers == nil {
+			return nil
+		}
+		for _, p := range providers.All() {
+			if source, ok := p.(fetch.MetadataSource); ok && p.Name() == fetch.ProviderAlphaVantage {
+				return source
+			}
+		}
+	}
+	return nil
+}
+
+// Due returns the symbols whose metadata was never fetched or is older than
+// the refresh interval.
+func (e *MetadataEnricher) Due(symbols []db.Symbol, now time.Time) []db.Symbol {
+	if e.source == nil {
+		return nil
+	}
+
+	var due []db.Symbol
+	for _, s := range symbols {
+		if s.MetadataUpdatedAt == nil || now.Sub(*s.MetadataUpdatedAt) >= e.maxAge {
+			due = append(due, s)
+		}
+	}
+	return due
+}
+
+// Run looks up and stores metadata for each symbol. Symbols the source does not
+// know are marked as refreshed so they are not retried until the interval
+// passes. Lookups stop when ctx is canceled or the daily quota is used up.
+func (e *MetadataEnricher) Run(ctx context.Context, symbols []db.Symbol) []MetadataResult {
+	if e.source == nil {
+		return nil
+	}
+
+	var results []MetadataResult
+	for _, s := range symbols {
+		if ctx.Err() != nil {
+			break
+		}
+
+		result := MetadataResult{Symbol: s.Symbol}
+		md, err := e.source.FetchMetadata(ctx, s.Symbol)
+		switch {
+		case err == nil:
+			updated := applyMetadata(&s, md)
+			if err := e.symbolRepo.UpdateMetadata(&s); err != nil {
+				result.Err = err
+			} else {
+				result.Updated = updated
+			}
+		case errors.Is(err, fetch.ErrNoMetadata):
+			result.Err = err
+			if err := e.symbolRepo.UpdateMetadata(&s); err != nil {
+				result.Err = err
+			}
+		default:
+			result.Err = err
+		}
+		results = append(results, result)
+
+		if errors.Is(err, fetch.ErrQuotaExceeded) || ctx.Err() != nil {
+			break
+		}
+	}
+
+	return results
+}
+
+// applyMetadata copies the non-empty fields of md onto s.
+// Returns true when any field changed.
+func applyMetadata(s *db.Symbol, md *fetch.SymbolMetadata) bool {
+	changed := false
+	set := func(field *string, value string) {
+		if value != "" && *field != value {
+			*field = value
+			changed = true
+		}
+	}
+
+	set(&s.Name, md.Name)
+	set(&s.AssetType, md.AssetType)
+	set(&s.Exchange, md.Exchange)
+	set(&s.Sector, md.Sector)
+	set(&s.Currency, md.Currency)
+
+	return changed
+}
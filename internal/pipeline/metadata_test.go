@@ -0,0 +1,73 @@
+package pipeline
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cajundata/momorot/internal/config"
+	"github.com/cajundata/momorot/internal/db"
+	"github.com/cajundata/momorot/internal/fetch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefresher_RunEnrichesMetadata(t *testing.T) {
+	database := setupTestDB(t)
+	csvDir := t.TempDir()
+	writePriceCSV(t, csvDir, "SPY", 60)
+
+	metadataPath := filepath.Join(t.TempDir(), "metadata.csv")
+	require.NoError(t, os.WriteFile(metadataPath, []byte("symbol,name,asset_type,exchange,sector,currency\n"+
+		"SPY,SPDR S&P 500 ETF Trust,ETF,NYSE ARCA,,USD\n"), 0644))
+
+	symbolRepo := db.NewSymbolRepository(database)
+	require.NoError(t, symbolRepo.Create(&db.Symbol{Symbol: "SPY", Name: "SPY", AssetType: "ETF", Active: true}))
+	require.NoError(t, symbolRepo.Create(&db.Symbol{Symbol: "QQQ", Name: "QQQ", AssetType: "ETF", Active: true}))
+
+	cfg := testConfig(csvDir)
+	cfg.Metadata = config.MetadataConfig{Source: fetch.MetadataSourceCSV, CSVPath: metadataPath, RefreshDays: 30}
+
+	result, err := NewRefresher(cfg, database, NewProviders(cfg, database)).Run(context.Background(), "test refresh")
+	require.NoError(t, err)
+	require.Len(t, result.Metadata, 2)
+	assert.ErrorIs(t, result.Metadata[0].Err, fetch.ErrNoMetadata) // QQQ is not in the file
+	assert.True(t, result.Metadata[1].Updated)
+
+	spy, err := symbolRepo.Get("SPY")
+	require.NoError(t, err)
+	assert.Equal(t, "SPDR S&P 500 ETF Trust", spy.Name)
+	assert.Equal(t, "NYSE ARCA", spy.Exchange)
+	assert.Equal(t, "USD", spy.Currency)
+	assert.Empty(t, spy.Sector)
+	require.NotNil(t, spy.MetadataUpdatedAt)
+
+	// Both symbols were looked up recently, so the next refresh skips them
+	result, err = NewRefresher(cfg, database, NewProviders(cfg, database)).Run(context.Background(), "second refresh")
+	require.NoError(t, err)
+	assert.Empty(t, result.Metadata)
+}
+
+func TestMetadataEnricher_Due(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	recent := now.AddDate(0, 0, -5)
+	stale := now.AddDate(0, 0, -45)
+
+	cfg := &config.Config{Metadata: config.MetadataConfig{Source: fetch.MetadataSourceCSV, CSVPath: "unused.csv", RefreshDays: 30}}
+	enricher := NewMetadataEnricher(cfg, setupTestDB(t), nil)
+
+	due := enricher.Due([]db.Symbol{
+		{Symbol: "NEW"},
+		{Symbol: "RECENT", MetadataUpdatedAt: &recent},
+		{Symbol: "STALE", MetadataUpdatedAt: &stale},
+	}, now)
+	require.Len(t, due, 2)
+	assert.Equal(t, "NEW", due[0].Symbol)
+	assert.Equal(t, "STALE", due[1].Symbol)
+
+	// A disabled source never has anything due
+	cfg.Metadata.Source = fetch.MetadataSourceNone
+	assert.Empty(t, NewMetadataEnricher(cfg, setupTestDB(t), nil).Due([]db.Symbol{{Symbol: "NEW"}}, now))
+}
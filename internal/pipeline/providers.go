@@ -0,0 +1,171 @@
+// Package pipeline wires configuration, price providers, storage and analytics
+// into the refresh and import workflows shared by the CLI and the TUI.
+package pipeline
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cajundata/momorot/internal/analytics"
+	"github.com/cajundata/momorot/internal/config"
+	"github.com/cajundata/momorot/internal/db"
+	"github.com/cajundata/momorot/internal/fetch"
+)
+
+// LoadConfig loads the configuration like config.Load and checks that
+// scoring.strategy names a registered scoring strategy, including those
+// registered with analytics.RegisterStrategy.
+func LoadConfig(configPath string) (*config.Config, error) {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if names := analytics.StrategyNames(); cfg.Scoring.Strategy != "" && !slices.Contains(names, cfg.Scoring.Strategy) {
+		return nil, fmt.Errorf("scoring.strategy must be one of: %s", strings.Join(names, ", "))
+	}
+
+	return cfg, nil
+}
+
+// NewProviders builds the price providers selected in the configuration.
+// Providers are only constructed when at least one symbol is routed to them.
+// Quota usage of rate-limited providers is persisted in the database.
+func NewProviders(cfg *config.Config, database *db.DB) *fetch.Providers {
+	timeout := time.Duration(cfg.Fetcher.Timeout) * time.Second
+
+	built := make(map[string]fetch.Provider)
+	provider := func(name string) fetch.Provider {
+		if p, ok := built[name]; ok {
+			return p
+		}
+
+		var p fetch.Provider
+		switch name {
+		case fetch.ProviderCSV:
+			csvProvider := fetch.NewCSVProvider(cfg.Providers.CSV.Dir)
+			if importer, err := NewCSVImporter(cfg.Providers.CSV); err != nil {
+				log.Printf("Warning: using the default CSV format: %v", err)
+			} else {
+				csvProvider.SetImporter(importer)
+			}
+			p = csvProvider
+		case fetch.ProviderHTTP:
+			p = fetch.NewHTTPProvider(cfg.Providers.HTTP.BaseURL, cfg.Providers.HTTP.Token, timeout, cfg.Fetcher.MaxRetries)
+		default:
+			client := fetch.NewAlphaVantageClient(
+				cfg.AlphaVantage.APIKey,
+				cfg.AlphaVantage.BaseURL,
+				cfg.AlphaVantage.DailyRequestLimit,
+				timeout,
+				cfg.Fetcher.MaxRetries,
+			)
+			client.SetRequestsPerMinute(cfg.AlphaVantage.RequestsPerMinute)
+			if err := client.SetFixtures(cfg.AlphaVantage.FixtureMode, cfg.AlphaVantage.FixtureDir); err != nil {
+				log.Printf("Warning: fixtures disabled: %v", err)
+			}
+			if err := client.SetQuotaStore(db.NewQuotaRepository(database)); err != nil {
+				log.Printf("Warning: quota usage will not be persisted: %v", err)
+			}
+			p = client
+		}
+		built[name] = p
+		return p
+	}
+
+	providers := fetch.NewProviders(provider(cfg.Providers.Default))
+	for _, symbol := range cfg.Universe {
+		if name := cfg.ProviderFor(symbol); name != cfg.Providers.Default {
+			providers.Assign(symbol, provider(name))
+		}
+	}
+
+	return providers
+}
+
+// NewCSVImporter creates a CSV importer for the configured format preset,
+// delimiter, decimal separator and column overrides.
+func NewCSVImporter(csvCfg config.CSVProviderConfig) (*fetch.CSVImporter, error) {
+	importer, err := fetch.NewCSVImporterFormat(csvCfg.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	switch csvCfg.Delimiter {
+	case "":
+	case "tab", "\\t":
+		importer.Delimiter = '\t'
+	default:
+		r, size := utf8.DecodeRuneInString(csvCfg.Delimiter)
+		if size != len(csvCfg.Delimiter) {
+			return nil, fmt.Errorf("invalid CSV delimiter %q (expected a single character)", csvCfg.Delimiter)
+		}
+		importer.Delimiter = r
+	}
+
+	importer.DecimalComma = csvCfg.DecimalComma
+	if importer.DecimalComma && importer.Delimiter == ',' {
+		return nil, fmt.Errorf("decimal-comma files need a delimiter other than ','")
+	}
+	importer.Columns = csvCfg.Columns
+
+	return importer, nil
+}
+
+// NewOrchestrator creates an analytics orchestrator from the configured
+// lookbacks, volatility windows, indicators, risk metrics and scoring parameters.
+func NewOrchestrator(cfg *config.Config, database *db.DB) *analytics.Orchestrator {
+	orchestrator := analytics.NewOrchestrator(
+		database,
+		map[string]int{
+			"r1m":  cfg.Lookbacks.R1M,
+			"r3m":  cfg.Lookbacks.R3M,
+			"r6m":  cfg.Lookbacks.R6M,
+			"r12m": cfg.Lookbacks.R12M,
+			"skip": cfg.Lookbacks.Skip,
+		},
+		map[string]int{
+			"short": cfg.VolWindows.Short,
+			"long":  cfg.VolWindows.Long,
+		},
+		analytics.ScoringConfig{
+			PenaltyLambda:      cfg.Scoring.PenaltyLambda,
+			MinADV:             cfg.Scoring.MinADVUSD,
+			BreadthMinPositive: cfg.Scoring.BreadthMinPositive,
+			BreadthTotal:       cfg.Scoring.BreadthTotalLookbacks,
+			Momentum:           cfg.Scoring.Momentum,
+			Penalty:            cfg.Scoring.Penalty,
+			Weights:            cfg.Scoring.Weights,
+			ZScoreHorizons:     cfg.Scoring.ZScoreHorizons,
+			Strategy:           cfg.Scoring.Strategy,
+			CashProxy:          cfg.Scoring.CashProxy,
+			RiskFreeRate:       cfg.Risk.RiskFreeRate,
+		},
+	)
+	orchestrator.SetIndicatorSpecs(IndicatorSpecs(cfg))
+	orchestrator.SetRisk(analytics.RiskConfig{
+		Window:       cfg.Risk.Window,
+		RiskFreeRate: cfg.Risk.RiskFreeRate,
+		Benchmark:    cfg.Risk.Benchmark,
+	})
+	return orchestrator
+}
+
+// IndicatorSpecs maps the indicators section of the config to analytics specs.
+func IndicatorSpecs(cfg *config.Config) []analytics.IndicatorSpec {
+	specs := make([]analytics.IndicatorSpec, len(cfg.Indicators))
+	for i, ind := range cfg.Indicators {
+		specs[i] = analytics.IndicatorSpec{
+			Name:     ind.Name,
+			Kind:     ind.Kind,
+			Lookback: ind.Lookback,
+			Skip:     ind.Skip,
+			Score:    ind.Score,
+		}
+	}
+	return specs
+}
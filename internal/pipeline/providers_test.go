@@ -0,0 +1,36 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cajundata/momorot/internal/analytics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig_Strategy(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := `
+alpha_vantage:
+  api_key: "test_key"
+
+universe:
+  - "SPY"
+
+scoring:
+  strategy: "magic"
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+
+	_, err := LoadConfig(configPath)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "scoring.strategy must be one of: dual_momentum, momentum, rank_sum, risk_adjusted")
+
+	// Registered strategies can be selected
+	analytics.RegisterStrategy("magic", func(c analytics.ScoringConfig) analytics.Strategy { return analytics.NewScorer(c) })
+	cfg, err := LoadConfig(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "magic", cfg.Scoring.Strategy)
+}
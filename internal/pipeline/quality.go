@@ -0,0 +1,40 @@
+package pipeline
+
+import (
+	"fmt"
+
+	"github.com/cajundata/momorot/internal/analytics"
+	"github.com/cajundata/momorot/internal/db"
+)
+
+// ValidationResult is the outcome of the data quality pass for one symbol.
+type ValidationResult struct {
+	Symbol   string
+	Findings []analytics.QualityFinding
+	Err      error
+}
+
+// ValidateSymbols runs the data quality checks over the stored prices of each
+// symbol, or of every symbol in the universe when none are given. Findings are
+// recorded outside any run. Refreshes and imports validate the symbols they
+// store on their own; this covers history stored before that.
+func ValidateSymbols(database *db.DB, symbols []string) ([]ValidationResult, error) {
+	if len(symbols) == 0 {
+		all, err := db.NewSymbolRepository(database).ListAll()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list symbols: %w", err)
+		}
+		for _, s := range all {
+			symbols = append(symbols, s.Symbol)
+		}
+	}
+
+	validator := analytics.NewValidator(database)
+	results := make([]ValidationResult, 0, len(symbols))
+	for _, symbol := range symbols {
+		findings, err := validator.ValidateSymbol(symbol, 0)
+		results = append(results, ValidationResult{Symbol: symbol, Findings: findings, Err: err})
+	}
+
+	return results, nil
+}
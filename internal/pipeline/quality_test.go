@@ -0,0 +1,59 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cajundata/momorot/internal/analytics"
+	"github.com/cajundata/momorot/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImporter_RunRecordsQualityFindings(t *testing.T) {
+	database := setupTestDB(t)
+	dir := t.TempDir()
+	// Jan 4 is missing and Jan 8 has no volume
+	csvData := "Date,Open,High,Low,Close,Volume\n" +
+		"2024-01-02,100,101,99,100,1000\n" +
+		"2024-01-03,100,101,99,100,1000\n" +
+		"2024-01-05,100,101,99,100,1000\n" +
+		"2024-01-08,100,101,99,100,0\n" +
+		"2024-01-09,100,101,99,100,1000\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "SPY.csv"), []byte(csvData), 0644))
+
+	importer, err := NewImporter(testConfig(dir), database)
+	require.NoError(t, err)
+	result, err := importer.Run([]string{filepath.Join(dir, "SPY.csv")}, "")
+	require.NoError(t, err)
+	require.Len(t, result.Files, 1)
+	require.NoError(t, result.Files[0].Err)
+	assert.Equal(t, 2, result.Files[0].Findings)
+
+	findings, err := db.NewQualityFindingRepository(database).ListForRun(result.RunID)
+	require.NoError(t, err)
+	require.Len(t, findings, 2)
+	assert.Equal(t, analytics.CheckMissingDays, findings[0].Check)
+	assert.Equal(t, "2024-01-04", findings[0].Date)
+	assert.Equal(t, analytics.CheckVolumeGap, findings[1].Check)
+
+	// Filling the gap and re-validating replaces the findings
+	volume := int64(1000)
+	require.NoError(t, db.NewPriceRepository(database).Create(&db.Price{
+		Symbol: "SPY", Date: "2024-01-04", Open: 100, High: 101, Low: 99, Close: 100, Volume: &volume,
+	}))
+
+	results, err := ValidateSymbols(database, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.NoError(t, results[0].Err)
+	assert.Len(t, results[0].Findings, 1)
+
+	findings, err = db.NewQualityFindingRepository(database).ListForSymbol("SPY")
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	assert.Equal(t, analytics.CheckVolumeGap, findings[0].Check)
+	assert.Equal(t, "2024-01-08", findings[0].Date)
+	assert.Nil(t, findings[0].RunID)
+}
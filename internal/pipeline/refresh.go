@@ -0,0 +1,703 @@
+package pipeline
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cajundata/momorot/internal/analytics"
+	"github.com/cajundata/momorot/internal/config"
+	"github.com/cajundata/momorot/internal/db"
+	"github.com/cajundata/momorot/internal/fetch"
+)
+
+// minRefreshTimeout is the least time a refresh is given, enough to score the
+// universe after a small fetch.
+const minRefreshTimeout = 5 * time.Minute
+
+// RefreshTimeout bounds a refresh of the active symbols. It allows twice the
+// estimated fetch time, to cover retries and throttle backoffs, where symbols
+// served by Alpha Vantage (at most a day's quota of them) are paced by
+// requests_per_minute. It is never less than five minutes.
+func RefreshTimeout(cfg *config.Config, database *db.DB) time.Duration {
+	symbols, err := db.NewSymbolRepository(database).ListActive()
+	if err != nil {
+		return minRefreshTimeout
+	}
+
+	paced := 0
+	for _, sym := range symbols {
+		if cfg.ProviderFor(sym.Symbol) == fetch.ProviderAlphaVantage {
+			paced++
+		}
+	}
+	paced = min(paced, cfg.AlphaVantage.DailyRequestLimit)
+
+	estimate := fetch.EstimateFetchTime(len(symbols), max(cfg.Fetcher.MaxWorkers, 1))
+	if rpm := cfg.AlphaVantage.RequestsPerMinute; rpm > 0 {
+		estimate = max(estimate, time.Duration(paced)*time.Minute/time.Duration(rpm))
+	}
+
+	return max(minRefreshTimeout, 2*estimate)
+}
+
+// staleRunMargin is how long past its timeout a refresh may take to record
+// its outcome after being canceled.
+const staleRunMargin = 10 * time.Minute
+
+// StaleRunAge is how long a run may stay RUNNING before it is considered
+// abandoned by a process that crashed or was killed: longer than a refresh of
+// the active symbols is allowed to run (RefreshTimeout), so a live one is never
+// taken for dead.
+func StaleRunAge(cfg *config.Config, database *db.DB) time.Duration {
+	return RefreshTimeout(cfg, database) + staleRunMargin
+}
+
+// ErrNothingToResume is returned by Resume when the latest refresh run finished OK.
+var ErrNothingToResume = errors.New("no incomplete refresh run to resume")
+
+// SymbolResult describes what a refresh did for a single symbol.
+type SymbolResult struct {
+	Symbol   string
+	Provider string
+	FromDate string // First stored date (yyyy-mm-dd), empty if nothing was stored
+	ToDate   string // Last stored date (yyyy-mm-dd), empty if nothing was stored
+	Rows     int    // Number of price rows written
+	Actions  int    // Number of new or changed corporate actions
+	Findings int    // Data quality findings in the symbol's stored prices
+	Backfill string // Gap backfill summary, empty if the fetch did not backfill
+	Err      error
+}
+
+// RefreshResult summarizes a refresh run.
+type RefreshResult struct {
+	RunID     int64
+	Symbols   []SymbolResult
+	Skipped   []string // Symbols already current as of the last business day
+	Deferred  []string // Symbols left for the next run because the daily quota ran out
+	Resumed   int      // Symbols already stored by the run before it was resumed
+	Metadata  []MetadataResult
+	Succeeded int
+	Failed    int
+	Duration  time.Duration
+}
+
+// Refresher downloads prices for the active universe, stores them and
+// recomputes analytics, recording the run and a fetch_log row per symbol.
+type Refresher struct {
+	fetcher       config.FetcherConfig
+	lookback      int           // Longest lookback in trading days; history needed before deltas suffice
+	staleAge      time.Duration // How long a run may stay RUNNING before it is abandoned (StaleRunAge)
+	providers     *fetch.Providers
+	scheduler     *fetch.Scheduler
+	orchestrator  *analytics.Orchestrator
+	store         *barStore
+	metadata      *MetadataEnricher
+	symbolRepo    *db.SymbolRepository
+	priceRepo     *db.PriceRepository
+	indicatorRepo *db.IndicatorRepository
+	pendingRepo   *db.PendingFetchRepository
+	fetchLogRepo  *db.FetchLogRepository
+	runRepo       *db.RunRepository
+	calendar      *analytics.Calendar
+	progress      func(fetch.FetchEvent)
+}
+
+// NewRefresher creates a refresher that fetches through the given providers.
+func NewRefresher(cfg *config.Config, database *db.DB, providers *fetch.Providers) *Refresher {
+	return &Refresher{
+		fetcher:       cfg.Fetcher,
+		lookback:      cfg.LongestLookback(),
+		staleAge:      StaleRunAge(cfg, database),
+		providers:     providers,
+		scheduler:     fetch.NewScheduler(providers, cfg.Fetcher.MaxWorkers),
+		orchestrator:  NewOrchestrator(cfg, database),
+		store:         newBarStore(database),
+		metadata:      NewMetadataEnricher(cfg, database, providers),
+		symbolRepo:    db.NewSymbolRepository(database),
+		priceRepo:     db.NewPriceRepository(database),
+		indicatorRepo: db.NewIndicatorRepository(database),
+		pendingRepo:   db.NewPendingFetchRepository(database),
+		fetchLogRepo:  db.NewFetchLogRepository(database),
+		runRepo:       db.NewRunRepository(database),
+		calendar:      analytics.NewCalendar(),
+	}
+}
+
+// SetProgress registers a callback that receives the scheduler's per-symbol
+// events while a run is fetching. The callback is invoked from a single goroutine.
+func (r *Refresher) SetProgress(progress func(fetch.FetchEvent)) {
+	r.progress = progress
+}
+
+// Run performs a full refresh: each active symbol is fetched once, its bars are
+// upserted in a single transaction, and indicators are recomputed afterwards.
+// Symbol metadata older than the configured refresh interval is looked up too.
+// Symbols are fetched in priority order and capped to the remaining quota;
+// whatever is left over is fetched first by the next run.
+// Per-symbol failures are recorded in the result; the returned error is only set
+// when the run itself could not be carried out.
+func (r *Refresher) Run(ctx context.Context, notes string) (*RefreshResult, error) {
+	startTime := time.Now()
+
+	runID, err := r.runRepo.Create(notes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create run: %w", err)
+	}
+
+	result, err := r.run(ctx, runID, nil)
+	if err != nil {
+		r.runRepo.Finish(runID, "ERROR", 0, 0)
+		return nil, err
+	}
+	result.Duration = time.Since(startTime)
+
+	return result, nil
+}
+
+// Resume continues the latest refresh run if it did not finish OK: it was
+// interrupted, hit the daily quota or had failures. Only active symbols without
+// a successful fetch_log row in that run are fetched again, and the run's
+// totals include the symbols stored before. Returns ErrNothingToResume when the
+// latest refresh finished OK, and an error when it is still running elsewhere.
+func (r *Refresher) Resume(ctx context.Context) (*RefreshResult, error) {
+	startTime := time.Now()
+
+	run, err := r.runRepo.GetLatestKind(db.RunKindRefresh)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNothingToResume
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest run: %w", err)
+	}
+	if run.Status == "OK" {
+		return nil, ErrNothingToResume
+	}
+	if run.Status == "RUNNING" && time.Since(run.StartedAt) < r.staleAge {
+		return nil, fmt.Errorf("run %d started %s ago and may still be running; try again later",
+			run.RunID, time.Since(run.StartedAt).Round(time.Second))
+	}
+
+	succeeded, err := r.fetchLogRepo.GetSucceeded(run.RunID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fetched symbols for run %d: %w", run.RunID, err)
+	}
+	done := make(map[string]bool, len(succeeded))
+	for _, symbol := range succeeded {
+		done[symbol] = true
+	}
+
+	if err := r.runRepo.Reopen(run.RunID, "resumed "+startTime.UTC().Format("2006-01-02 15:04:05")); err != nil {
+		return nil, fmt.Errorf("failed to reopen run %d: %w", run.RunID, err)
+	}
+
+	result, err := r.run(ctx, run.RunID, done)
+	if err != nil {
+		r.runRepo.Finish(run.RunID, "ERROR", len(succeeded), 0)
+		return nil, err
+	}
+	result.Resumed = len(succeeded)
+	result.Duration = time.Since(startTime)
+
+	return result, nil
+}
+
+// MarkStaleRuns marks runs that have been RUNNING for longer than StaleRunAge
+// as ERROR; their process died before finishing them. Returns the number of runs marked.
+func MarkStaleRuns(cfg *config.Config, database *db.DB) (int64, error) {
+	age := StaleRunAge(cfg, database)
+	note := fmt.Sprintf("interrupted: still RUNNING after %v, marked ERROR on startup (continue with momo refresh -resume)", age)
+	return db.NewRunRepository(database).MarkStale(time.Now().Add(-age), note)
+}
+
+// run executes the refresh for an already created run, skipping the symbols in done.
+func (r *Refresher) run(ctx context.Context, runID int64, done map[string]bool) (*RefreshResult, error) {
+	activeSymbols, err := r.symbolRepo.ListActive()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active symbols: %w", err)
+	}
+
+	var symbols []string
+	for _, sym := range activeSymbols {
+		if !done[sym.Symbol] {
+			symbols = append(symbols, sym.Symbol)
+		}
+	}
+
+	tasks, skipped, backfills, err := r.plan(symbols, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, deferred, err := r.queue(tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RefreshResult{RunID: runID, Skipped: skipped}
+	var pending []db.PendingFetch
+	for _, task := range deferred {
+		result.Deferred = append(result.Deferred, task.Symbol)
+		pending = append(pending, db.PendingFetch{Symbol: task.Symbol, Reason: "quota"})
+	}
+
+	if len(tasks) > 0 {
+		events, err := r.scheduler.Stream(ctx, tasks)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch symbols: %w", err)
+		}
+
+		// Store each symbol as soon as it arrives
+		for event := range events {
+			if r.progress != nil {
+				r.progress(event)
+			}
+			if event.Type != fetch.FetchFinished {
+				continue
+			}
+
+			var sr SymbolResult
+			if gap, ok := backfills[event.Symbol]; ok {
+				sr = r.storeBackfill(runID, *event.Result, gap)
+			} else {
+				sr = r.Store(runID, *event.Result)
+			}
+			if sr.Err != nil {
+				result.Failed++
+			} else {
+				result.Succeeded++
+			}
+			result.Symbols = append(result.Symbols, sr)
+
+			// Symbols that never got a real attempt are carried over too
+			if reason := carryOverReason(event.Result.Error); reason != "" {
+				pending = append(pending, db.PendingFetch{Symbol: sr.Symbol, Reason: reason})
+			}
+		}
+	}
+
+	if err := r.pendingRepo.Replace(pending); err != nil {
+		return nil, fmt.Errorf("failed to save pending fetches: %w", err)
+	}
+
+	// Metadata goes last so prices get first claim on the quota
+	result.Metadata = r.metadata.Run(ctx, r.metadata.Due(activeSymbols, time.Now()))
+
+	if _, err := r.orchestrator.ComputeAllIndicators(time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to compute analytics: %w", err)
+	}
+	if err := r.runRepo.SetStrategy(runID, r.orchestrator.Strategy()); err != nil {
+		return nil, fmt.Errorf("failed to record scoring strategy: %w", err)
+	}
+
+	// Totals come from fetch_log so a resumed run counts its earlier symbols
+	succeeded, failed, err := r.fetchLogRepo.CountByStatus(runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count fetches: %w", err)
+	}
+
+	// Deferred symbols leave the run incomplete, so it can be resumed
+	status := "OK"
+	if failed > 0 || len(result.Deferred) > 0 {
+		status = "ERROR"
+	}
+	if err := r.runRepo.Finish(runID, status, succeeded, failed); err != nil {
+		return nil, fmt.Errorf("failed to update run status: %w", err)
+	}
+
+	return result, nil
+}
+
+// plan decides what to request for each symbol based on the prices already stored.
+// With caching enabled, symbols that are current as of the previous business day
+// are skipped. With delta fetching enabled, symbols whose history covers the longest
+// lookback only request bars after their latest stored date (a "compact" download);
+// everything else requests full history.
+// Symbols with missing business days in their stored history are fetched from
+// the first gap onwards, even when current, which turns into a "full" download
+// once the gap is older than the compact window. The returned map holds the
+// backfilled range per symbol.
+func (r *Refresher) plan(symbols []string, now time.Time) ([]fetch.FetchTask, []string, map[string]analytics.Gap, error) {
+	lastClose := analytics.PreviousBusinessDay(now).Format("2006-01-02")
+
+	var tasks []fetch.FetchTask
+	var skipped []string
+	backfills := make(map[string]analytics.Gap)
+	for _, symbol := range symbols {
+		latest, err := r.priceRepo.GetLatestDate(symbol)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to get latest date for %s: %w", symbol, err)
+		}
+
+		gap, hasGap, err := r.openGaps(symbol)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		if r.fetcher.CacheEnabled && latest != "" && latest >= lastClose && !hasGap {
+			skipped = append(skipped, symbol)
+			continue
+		}
+
+		task := fetch.FetchTask{Symbol: symbol}
+		if r.fetcher.OnlyFetchDeltas && latest != "" {
+			count, err := r.priceRepo.Count(symbol)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("failed to count prices for %s: %w", symbol, err)
+			}
+
+			// The lookback return needs lookback+1 closes
+			if count > r.lookback {
+				latestDate, err := time.Parse("2006-01-02", latest)
+				if err != nil {
+					return nil, nil, nil, fmt.Errorf("failed to parse latest date %s for %s: %w", latest, symbol, err)
+				}
+				task.From = latestDate.AddDate(0, 0, 1)
+			}
+		}
+		if hasGap {
+			if !task.From.IsZero() && gap.From.Before(task.From) {
+				task.From = gap.From
+			}
+			backfills[symbol] = gap
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, skipped, backfills, nil
+}
+
+// openGaps returns the span of business days missing from symbol's stored
+// history, from the first missing day of the earliest gap to the last of the
+// latest, with Days counting only missing days. Gaps that an earlier backfill
+// already requested are left out: the provider has nothing for them.
+func (r *Refresher) openGaps(symbol string) (analytics.Gap, bool, error) {
+	stored, err := r.priceRepo.ListDates(symbol)
+	if err != nil {
+		return analytics.Gap{}, false, fmt.Errorf("failed to get stored dates for %s: %w", symbol, err)
+	}
+	if len(stored) < 2 {
+		return analytics.Gap{}, false, nil
+	}
+
+	dates := make([]time.Time, 0, len(stored))
+	for _, d := range stored {
+		date, err := time.Parse("2006-01-02", d)
+		if err != nil {
+			return analytics.Gap{}, false, fmt.Errorf("failed to parse date %s for %s: %w", d, symbol, err)
+		}
+		dates = append(dates, date)
+	}
+
+	gaps := analytics.FindGaps(dates, r.calendar)
+	if len(gaps) == 0 {
+		return analytics.Gap{}, false, nil
+	}
+
+	attempted, err := r.fetchLogRepo.GetBackfills(symbol)
+	if err != nil {
+		return analytics.Gap{}, false, fmt.Errorf("failed to get backfills for %s: %w", symbol, err)
+	}
+
+	var span analytics.Gap
+	for _, gap := range gaps {
+		if backfilled(gap, attempted) {
+			continue
+		}
+		if span.Days == 0 {
+			span.From = gap.From
+		}
+		span.To = gap.To
+		span.Days += gap.Days
+	}
+
+	return span, span.Days > 0, nil
+}
+
+// backfilled reports whether gap lies within the range of an earlier backfill.
+func backfilled(gap analytics.Gap, attempted []db.FetchLog) bool {
+	from, to := gap.From.Format("2006-01-02"), gap.To.Format("2006-01-02")
+	for _, log := range attempted {
+		if log.FromDate != nil && log.ToDate != nil && *log.FromDate <= from && *log.ToDate >= to {
+			return true
+		}
+	}
+	return false
+}
+
+// queue orders tasks by priority and caps each rate-limited provider to its
+// remaining daily quota. Symbols carried over from an earlier run go first,
+// followed by the rest ordered by staleness and latest rank (see
+// fetch.PrioritizeFetchOrderRanked). Returns the tasks to fetch now and the
+// tasks deferred to the next run.
+func (r *Refresher) queue(tasks []fetch.FetchTask) ([]fetch.FetchTask, []fetch.FetchTask, error) {
+	if len(tasks) == 0 {
+		return nil, nil, nil
+	}
+
+	bySymbol := make(map[string]fetch.FetchTask, len(tasks))
+	symbols := make([]string, len(tasks))
+	lastFetchTimes := make(map[string]time.Time)
+	for i, task := range tasks {
+		bySymbol[task.Symbol] = task
+		symbols[i] = task.Symbol
+
+		latest, err := r.priceRepo.GetLatestDate(task.Symbol)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get latest date for %s: %w", task.Symbol, err)
+		}
+		if latest != "" {
+			if t, err := time.Parse("2006-01-02", latest); err == nil {
+				lastFetchTimes[task.Symbol] = t
+			}
+		}
+	}
+
+	ranks, err := r.indicatorRepo.LatestRanks(r.orchestrator.Strategy())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get latest ranks: %w", err)
+	}
+	carried, err := r.pendingRepo.List()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get pending fetches: %w", err)
+	}
+	isCarried := make(map[string]bool, len(carried))
+	for _, p := range carried {
+		isCarried[p.Symbol] = true
+	}
+
+	// Carried-over symbols first, each group in priority order
+	var first, rest []string
+	for _, symbol := range fetch.PrioritizeFetchOrderRanked(symbols, lastFetchTimes, ranks) {
+		if isCarried[symbol] {
+			first = append(first, symbol)
+		} else {
+			rest = append(rest, symbol)
+		}
+	}
+
+	// Remaining requests per rate-limited provider
+	remaining := make(map[string]int)
+	var queued, deferred []fetch.FetchTask
+	for _, symbol := range append(first, rest...) {
+		task := bySymbol[symbol]
+		provider := r.providers.For(symbol)
+
+		left, limited := remaining[provider.Name()]
+		if !limited {
+			if status := provider.Quota(); status != nil {
+				left, limited = status.RequestsLeft, true
+			}
+		}
+		if limited {
+			if left <= 0 {
+				deferred = append(deferred, task)
+				continue
+			}
+			remaining[provider.Name()] = left - 1
+		}
+		queued = append(queued, task)
+	}
+
+	return queued, deferred, nil
+}
+
+// carryOverReason returns why a failed fetch should be retried first next run,
+// or "" when the failure was a genuine attempt.
+func carryOverReason(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, fetch.ErrQuotaExceeded):
+		return "quota"
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return "canceled"
+	}
+	return ""
+}
+
+// Store writes the bars of a fetch result in one transaction and logs the
+// outcome in fetch_log for the given run.
+func (r *Refresher) Store(runID int64, fr fetch.FetchResult) SymbolResult {
+	if !fr.Success {
+		return r.store.save(runID, fr.Symbol, fr.Provider, nil, fr.Error)
+	}
+	return r.store.save(runID, fr.Symbol, fr.Provider, fr.Bars, nil)
+}
+
+// storeBackfill stores a fetch result that was requested to fill gap. A
+// successful fetch_log row records the backfilled range as from_dt/to_dt rather
+// than the range of bars stored, with a note on how many missing days were filled.
+func (r *Refresher) storeBackfill(runID int64, fr fetch.FetchResult, gap analytics.Gap) SymbolResult {
+	if !fr.Success {
+		return r.store.save(runID, fr.Symbol, fr.Provider, nil, fr.Error)
+	}
+
+	from, to := gap.From.Format("2006-01-02"), gap.To.Format("2006-01-02")
+	filled := 0
+	for _, bar := range fr.Bars {
+		if date := bar.Date.Format("2006-01-02"); date >= from && date <= to && r.calendar.IsBusinessDay(bar.Date) {
+			filled++
+		}
+	}
+
+	note := fmt.Sprintf("backfill: filled %d of %d missing days", filled, gap.Days)
+	sr := r.store.write(runID, fr.Symbol, fr.Provider, fr.Bars, nil, &fetchRange{from: from, to: to, note: note})
+	if sr.Err == nil {
+		sr.Backfill = fmt.Sprintf("filled %d of %d missing days (%s to %s)", filled, gap.Days, from, to)
+	}
+	return sr
+}
+
+// fetchRange marks a successful fetch as a backfill in fetch_log, overriding
+// the range and message logged.
+type fetchRange struct {
+	from, to string
+	note     string
+}
+
+// barStore persists provider bars: prices, the corporate actions they carry,
+// and a fetch_log row per symbol, then re-runs the data quality checks over the
+// symbol's stored prices. Shared by refresh and import.
+type barStore struct {
+	adjuster     *analytics.Adjuster
+	validator    *analytics.Validator
+	priceRepo    *db.PriceRepository
+	actionRepo   *db.CorporateActionRepository
+	fetchLogRepo *db.FetchLogRepository
+}
+
+// newBarStore creates a bar store writing to database.
+func newBarStore(database *db.DB) *barStore {
+	return &barStore{
+		adjuster:     analytics.NewAdjuster(database),
+		validator:    analytics.NewValidator(database),
+		priceRepo:    db.NewPriceRepository(database),
+		actionRepo:   db.NewCorporateActionRepository(database),
+		fetchLogRepo: db.NewFetchLogRepository(database),
+	}
+}
+
+// save upserts bars for a symbol in one transaction and records a fetch_log
+// row for the run. When the bars carry a new or corrected corporate action, the
+// symbol's adjusted closes are rebuilt so stored history stays consistent;
+// bars older than its first recorded action keep the provider's adjustments.
+// A non-nil fetchErr is logged as a failure without storing anything.
+func (s *barStore) save(runID int64, symbol, provider string, bars []fetch.Bar, fetchErr error) SymbolResult {
+	return s.write(runID, symbol, provider, bars, fetchErr, nil)
+}
+
+// write is save with an optional fetch_log range override for successful fetches.
+func (s *barStore) write(runID int64, symbol, provider string, bars []fetch.Bar, fetchErr error, logged *fetchRange) SymbolResult {
+	sr := SymbolResult{
+		Symbol:   symbol,
+		Provider: provider,
+		Err:      fetchErr,
+	}
+
+	if fetchErr == nil {
+		prices := BarsToPrices(symbol, bars)
+		if err := s.priceRepo.UpsertBatch(prices); err != nil {
+			sr.Err = fmt.Errorf("failed to store prices: %w", err)
+		} else if len(prices) > 0 {
+			sr.Rows = len(prices)
+			sr.FromDate = prices[0].Date
+			sr.ToDate = prices[len(prices)-1].Date
+		}
+	}
+
+	if sr.Err == nil {
+		changed, err := s.actionRepo.UpsertBatch(BarsToActions(symbol, provider, bars))
+		if err != nil {
+			sr.Err = fmt.Errorf("failed to store corporate actions: %w", err)
+		}
+		sr.Actions = changed
+	}
+
+	if sr.Err == nil && sr.Actions > 0 {
+		if _, err := s.adjuster.RebuildSymbol(symbol); err != nil {
+			sr.Err = fmt.Errorf("failed to rebuild adjusted closes: %w", err)
+		}
+	}
+
+	if sr.Err == nil && sr.Rows > 0 {
+		findings, err := s.validator.ValidateSymbol(symbol, runID)
+		if err != nil {
+			sr.Err = fmt.Errorf("failed to validate prices: %w", err)
+		}
+		sr.Findings = len(findings)
+	}
+
+	entry := &db.FetchLog{
+		RunID:  runID,
+		Symbol: sr.Symbol,
+		Rows:   sr.Rows,
+		OK:     sr.Err == nil,
+	}
+	if sr.Rows > 0 {
+		entry.FromDate = &sr.FromDate
+		entry.ToDate = &sr.ToDate
+	}
+	if sr.Err != nil {
+		msg := sr.Err.Error()
+		entry.Message = &msg
+	} else if logged != nil {
+		entry.FromDate = &logged.from
+		entry.ToDate = &logged.to
+		entry.Message = &logged.note
+		entry.Backfill = true
+	}
+	if err := s.fetchLogRepo.Log(entry); err != nil && sr.Err == nil {
+		sr.Err = fmt.Errorf("failed to log fetch: %w", err)
+	}
+
+	return sr
+}
+
+// BarsToPrices converts provider bars into price rows for a symbol.
+func BarsToPrices(symbol string, bars []fetch.Bar) []db.Price {
+	prices := make([]db.Price, len(bars))
+	for i, bar := range bars {
+		adjClose := bar.AdjClose
+		volume := int64(bar.Volume)
+
+		prices[i] = db.Price{
+			Symbol:   symbol,
+			Date:     bar.Date.Format("2006-01-02"),
+			Open:     bar.Open,
+			High:     bar.High,
+			Low:      bar.Low,
+			Close:    bar.Close,
+			AdjClose: &adjClose,
+			Volume:   &volume,
+		}
+	}
+	return prices
+}
+
+// BarsToActions extracts the dividends and splits carried by provider bars.
+// Bars without a dividend or with a split coefficient of 0 or 1 are ignored.
+func BarsToActions(symbol, provider string, bars []fetch.Bar) []db.CorporateAction {
+	var actions []db.CorporateAction
+	for _, bar := range bars {
+		split := bar.SplitCoefficient
+		if split == 0 {
+			split = 1
+		}
+		if bar.Dividend == 0 && split == 1 {
+			continue
+		}
+
+		source := provider
+		actions = append(actions, db.CorporateAction{
+			Symbol:           symbol,
+			Date:             bar.Date.Format("2006-01-02"),
+			Dividend:         bar.Dividend,
+			SplitCoefficient: split,
+			Source:           &source,
+		})
+	}
+	return actions
+}
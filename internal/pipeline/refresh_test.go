@@ -0,0 +1,670 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cajundata/momorot/internal/config"
+	"github.com/cajundata/momorot/internal/db"
+	"github.com/cajundata/momorot/internal/fetch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestDB(t *testing.T) *db.DB {
+	t.Helper()
+
+	database, err := db.New(db.Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	require.NoError(t, err)
+
+	err = database.Migrate()
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		database.Close()
+	})
+
+	return database
+}
+
+// testConfig returns a configuration with short lookbacks so a few months of
+// generated prices are enough to compute indicators.
+func testConfig(csvDir string) *config.Config {
+	return &config.Config{
+		Lookbacks:  config.LookbacksConfig{R1M: 5, R3M: 10, R6M: 20, R12M: 40},
+		VolWindows: config.VolWindowsConfig{Short: 10, Long: 20},
+		Scoring: config.ScoringConfig{
+			PenaltyLambda:         0.35,
+			BreadthTotalLookbacks: 4,
+		},
+		Fetcher:   config.FetcherConfig{MaxWorkers: 2},
+		Providers: config.ProvidersConfig{Default: fetch.ProviderCSV, CSV: config.CSVProviderConfig{Dir: csvDir}},
+	}
+}
+
+// writePriceCSV writes n consecutive weekday bars ending yesterday for symbol.
+func writePriceCSV(t *testing.T, dir, symbol string, n int) {
+	t.Helper()
+
+	var dates []time.Time
+	for d := time.Now().AddDate(0, 0, -1); len(dates) < n; d = d.AddDate(0, 0, -1) {
+		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+			dates = append([]time.Time{d}, dates...)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("Date,Open,High,Low,Close,Volume\n")
+	for i, d := range dates {
+		price := 100.0 + float64(i)
+		fmt.Fprintf(&b, "%s,%.2f,%.2f,%.2f,%.2f,1000000\n", d.Format("2006-01-02"), price, price+1, price-1, price)
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, symbol+".csv"), []byte(b.String()), 0644))
+}
+
+func TestRefresher_Run(t *testing.T) {
+	database := setupTestDB(t)
+	csvDir := t.TempDir()
+	writePriceCSV(t, csvDir, "SPY", 60)
+
+	symbolRepo := db.NewSymbolRepository(database)
+	require.NoError(t, symbolRepo.Create(&db.Symbol{Symbol: "SPY", Name: "SPDR S&P 500", AssetType: "ETF", Active: true}))
+	require.NoError(t, symbolRepo.Create(&db.Symbol{Symbol: "QQQ", Name: "Invesco QQQ", AssetType: "ETF", Active: true}))
+
+	cfg := testConfig(csvDir)
+	refresher := NewRefresher(cfg, database, NewProviders(cfg, database))
+
+	var events []fetch.FetchEvent
+	refresher.SetProgress(func(event fetch.FetchEvent) {
+		events = append(events, event)
+	})
+
+	result, err := refresher.Run(context.Background(), "test refresh")
+	require.NoError(t, err)
+
+	// Each symbol reports start and finish while the run is in progress
+	require.Len(t, events, 4)
+	assert.Equal(t, 2, events[3].Completed)
+	assert.Equal(t, 2, events[3].Total)
+
+	assert.Equal(t, 1, result.Succeeded)
+	assert.Equal(t, 1, result.Failed) // QQQ has no CSV file
+	require.Len(t, result.Symbols, 2)
+
+	// Prices were stored once per bar
+	var count int
+	require.NoError(t, database.QueryRow("SELECT COUNT(*) FROM prices WHERE symbol = 'SPY'").Scan(&count))
+	assert.Equal(t, 60, count)
+
+	// The fetch log records the stored range
+	var fromDt, toDt string
+	var rows int
+	err = database.QueryRow("SELECT from_dt, to_dt, rows FROM fetch_log WHERE run_id = ? AND symbol = 'SPY'", result.RunID).
+		Scan(&fromDt, &toDt, &rows)
+	require.NoError(t, err)
+	assert.Equal(t, 60, rows)
+	assert.Less(t, fromDt, toDt)
+
+	failures, err := db.NewFetchLogRepository(database).GetFailures(result.RunID)
+	require.NoError(t, err)
+	require.Len(t, failures, 1)
+	assert.Equal(t, "QQQ", failures[0].Symbol)
+
+	// The run is finished with the per-symbol counts
+	run, err := db.NewRunRepository(database).GetLatest()
+	require.NoError(t, err)
+	assert.Equal(t, "ERROR", run.Status)
+	assert.Equal(t, 1, run.SymbolsProcessed)
+	assert.Equal(t, 1, run.SymbolsFailed)
+
+	// Indicators were computed for the stored symbol
+	require.NoError(t, database.QueryRow("SELECT COUNT(*) FROM indicators WHERE symbol = 'SPY'").Scan(&count))
+	assert.Equal(t, 1, count)
+}
+
+func TestRefresher_RunStoresConfiguredIndicators(t *testing.T) {
+	database := setupTestDB(t)
+	csvDir := t.TempDir()
+	writePriceCSV(t, csvDir, "SPY", 60)
+	require.NoError(t, db.NewSymbolRepository(database).Create(&db.Symbol{Symbol: "SPY", Name: "SPDR S&P 500", AssetType: "ETF", Active: true}))
+
+	cfg := testConfig(csvDir)
+	cfg.Indicators = []config.IndicatorConfig{
+		{Name: "r50_5", Kind: "return", Lookback: 50, Skip: 5, Score: true},
+		{Name: "vol1w", Kind: "volatility", Lookback: 5},
+	}
+
+	_, err := NewRefresher(cfg, database, NewProviders(cfg, database)).Run(context.Background(), "test refresh")
+	require.NoError(t, err)
+
+	var date string
+	require.NoError(t, database.QueryRow("SELECT MAX(date) FROM indicators WHERE symbol = 'SPY'").Scan(&date))
+	top, err := db.NewIndicatorRepository(database).GetTopN(date, db.DefaultStrategy, 1)
+	require.NoError(t, err)
+	require.Len(t, top, 1)
+
+	// Closes run 100 ... 159: r50_5 measures 109 to 154
+	assert.InDelta(t, 154.0/109.0-1, top[0].Values["r50_5"], 1e-9)
+	assert.Contains(t, top[0].Values, "vol1w")
+}
+
+func TestRefresher_RunStoresSkipMomentum(t *testing.T) {
+	database := setupTestDB(t)
+	csvDir := t.TempDir()
+	writePriceCSV(t, csvDir, "SPY", 60)
+	require.NoError(t, db.NewSymbolRepository(database).Create(&db.Symbol{Symbol: "SPY", Name: "SPDR S&P 500", AssetType: "ETF", Active: true}))
+
+	cfg := testConfig(csvDir)
+	cfg.Lookbacks.Skip = 5
+	cfg.Scoring.Momentum = "12-1"
+
+	_, err := NewRefresher(cfg, database, NewProviders(cfg, database)).Run(context.Background(), "test refresh")
+	require.NoError(t, err)
+
+	var date string
+	require.NoError(t, database.QueryRow("SELECT MAX(date) FROM indicators WHERE symbol = 'SPY'").Scan(&date))
+	top, err := db.NewIndicatorRepository(database).GetTopN(date, db.DefaultStrategy, 1)
+	require.NoError(t, err)
+	require.Len(t, top, 1)
+
+	// Closes run 100 ... 159 and r12m is 40: 12-1 measures 119 to 154
+	assert.InDelta(t, 154.0/119.0-1, top[0].Values["r12_1"], 1e-9)
+}
+
+func TestRefresher_RunStoresRiskMetrics(t *testing.T) {
+	database := setupTestDB(t)
+	csvDir := t.TempDir()
+	writePriceCSV(t, csvDir, "SPY", 60)
+	require.NoError(t, db.NewSymbolRepository(database).Create(&db.Symbol{Symbol: "SPY", Name: "SPDR S&P 500", AssetType: "ETF", Active: true}))
+
+	cfg := testConfig(csvDir)
+	cfg.Risk = config.RiskConfig{Window: 20, Benchmark: "SPY"}
+	cfg.Scoring.Penalty = "max_drawdown"
+
+	_, err := NewRefresher(cfg, database, NewProviders(cfg, database)).Run(context.Background(), "test refresh")
+	require.NoError(t, err)
+
+	var date string
+	require.NoError(t, database.QueryRow("SELECT MAX(date) FROM indicators WHERE symbol = 'SPY'").Scan(&date))
+	top, err := db.NewIndicatorRepository(database).GetTopN(date, db.DefaultStrategy, 1)
+	require.NoError(t, err)
+	require.Len(t, top, 1)
+
+	// Closes only rise, and SPY is its own benchmark
+	assert.Equal(t, 0.0, top[0].Values["max_drawdown"])
+	assert.InDelta(t, 1.0, top[0].Values["beta"], 1e-9)
+	assert.Contains(t, top[0].Values, "sharpe")
+}
+
+func TestRefresher_RunRecordsStrategy(t *testing.T) {
+	database := setupTestDB(t)
+	csvDir := t.TempDir()
+	writePriceCSV(t, csvDir, "SPY", 60)
+	require.NoError(t, db.NewSymbolRepository(database).Create(&db.Symbol{Symbol: "SPY", Name: "SPDR S&P 500", AssetType: "ETF", Active: true}))
+
+	// History ranked by the default strategy
+	cfg := testConfig(csvDir)
+	_, err := NewRefresher(cfg, database, NewProviders(cfg, database)).Run(context.Background(), "test refresh")
+	require.NoError(t, err)
+	stored, err := NewOrchestrator(cfg, database).Backfill(time.Now().AddDate(0, 0, -15), time.Now(), nil)
+	require.NoError(t, err)
+	require.Greater(t, stored, 1)
+
+	cfg.Scoring.Strategy = "rank_sum"
+	result, err := NewRefresher(cfg, database, NewProviders(cfg, database)).Run(context.Background(), "test refresh")
+	require.NoError(t, err)
+
+	// The earlier strategy keeps its history alongside the new ranking
+	var momentumDays int
+	require.NoError(t, database.QueryRow("SELECT COUNT(DISTINCT date) FROM indicators WHERE strategy = 'momentum'").Scan(&momentumDays))
+	assert.Equal(t, stored, momentumDays)
+
+	run, err := db.NewRunRepository(database).GetLatest()
+	require.NoError(t, err)
+	assert.Equal(t, result.RunID, run.RunID)
+	require.NotNil(t, run.Strategy)
+	assert.Equal(t, "rank_sum", *run.Strategy)
+
+	var date string
+	require.NoError(t, database.QueryRow("SELECT MAX(date) FROM indicators WHERE symbol = 'SPY'").Scan(&date))
+	indicatorRepo := db.NewIndicatorRepository(database)
+	for _, strategy := range []string{"momentum", "rank_sum"} {
+		top, err := indicatorRepo.GetTopN(date, strategy, 1)
+		require.NoError(t, err)
+		require.Len(t, top, 1)
+		assert.Equal(t, strategy, top[0].Strategy)
+	}
+
+	ranks, err := indicatorRepo.LatestRanks("rank_sum")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"SPY": 1}, ranks)
+}
+
+func TestRefresher_RunBelowHurdle(t *testing.T) {
+	database := setupTestDB(t)
+	csvDir := t.TempDir()
+	writePriceCSV(t, csvDir, "SPY", 60)
+	require.NoError(t, db.NewSymbolRepository(database).Create(&db.Symbol{Symbol: "SPY", Name: "SPDR S&P 500", AssetType: "ETF", Active: true}))
+
+	// Nothing beats a 1000% hurdle: the refresh still succeeds, holding cash
+	cfg := testConfig(csvDir)
+	cfg.Scoring.Strategy = "dual_momentum"
+	cfg.Risk.RiskFreeRate = 10
+	result, err := NewRefresher(cfg, database, NewProviders(cfg, database)).Run(context.Background(), "test refresh")
+	require.NoError(t, err)
+
+	run, err := db.NewRunRepository(database).GetLatest()
+	require.NoError(t, err)
+	assert.Equal(t, result.RunID, run.RunID)
+	assert.Equal(t, "OK", run.Status)
+
+	var unranked int
+	require.NoError(t, database.QueryRow("SELECT COUNT(*) FROM indicators WHERE strategy = 'dual_momentum' AND rank IS NULL").Scan(&unranked))
+	assert.Equal(t, 1, unranked)
+}
+
+func TestRefreshTimeout(t *testing.T) {
+	database := setupTestDB(t)
+	symbolRepo := db.NewSymbolRepository(database)
+	for i := 0; i < 100; i++ {
+		require.NoError(t, symbolRepo.Create(&db.Symbol{Symbol: fmt.Sprintf("S%03d", i), AssetType: "ETF", Active: true}))
+	}
+
+	// CSV fetches are quick
+	cfg := testConfig(t.TempDir())
+	assert.Equal(t, 5*time.Minute, RefreshTimeout(cfg, database))
+
+	// Alpha Vantage fetches at most a day's quota, five a minute
+	cfg.Providers.Default = fetch.ProviderAlphaVantage
+	cfg.AlphaVantage.DailyRequestLimit = 25
+	cfg.AlphaVantage.RequestsPerMinute = 5
+	assert.Equal(t, 10*time.Minute, RefreshTimeout(cfg, database))
+
+	cfg.AlphaVantage.DailyRequestLimit = 500
+	assert.Equal(t, 40*time.Minute, RefreshTimeout(cfg, database))
+
+	// A run within that time may still be going; one past it is abandoned
+	assert.Equal(t, 50*time.Minute, StaleRunAge(cfg, database))
+	runRepo := db.NewRunRepository(database)
+	for _, age := range []string{"-30 minutes", "-1 hour"} {
+		runID, err := runRepo.Create("test refresh")
+		require.NoError(t, err)
+		_, err = database.Exec(`UPDATE runs SET started_at = datetime('now', ?) WHERE run_id = ?`, age, runID)
+		require.NoError(t, err)
+	}
+	marked, err := MarkStaleRuns(cfg, database)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), marked)
+}
+
+func TestRefresher_StoreUpsertsExistingDates(t *testing.T) {
+	database := setupTestDB(t)
+	cfg := testConfig(t.TempDir())
+	refresher := NewRefresher(cfg, database, NewProviders(cfg, database))
+
+	err := db.NewSymbolRepository(database).Create(&db.Symbol{Symbol: "SPY", Name: "SPDR S&P 500", AssetType: "ETF", Active: true})
+	require.NoError(t, err)
+
+	runID, err := db.NewRunRepository(database).Create("test")
+	require.NoError(t, err)
+
+	day := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	bars := []fetch.Bar{
+		{Date: day, Open: 100, High: 101, Low: 99, Close: 100, AdjClose: 100, Volume: 1000},
+		{Date: day.AddDate(0, 0, 1), Open: 100, High: 102, Low: 99, Close: 101, AdjClose: 101, Volume: 1000},
+	}
+
+	// Re-storing an overlapping range updates rows instead of failing
+	sr := refresher.Store(runID, fetch.FetchResult{Symbol: "SPY", Provider: fetch.ProviderCSV, Success: true, Bars: bars[:1]})
+	require.NoError(t, sr.Err)
+
+	bars[0].Close = 99.5
+	otherRun, err := db.NewRunRepository(database).Create("test")
+	require.NoError(t, err)
+	sr = refresher.Store(otherRun, fetch.FetchResult{Symbol: "SPY", Provider: fetch.ProviderCSV, Success: true, Bars: bars})
+	require.NoError(t, sr.Err)
+	assert.Equal(t, 2, sr.Rows)
+	assert.Equal(t, "2024-01-02", sr.FromDate)
+	assert.Equal(t, "2024-01-03", sr.ToDate)
+
+	prices, err := db.NewPriceRepository(database).GetRange("SPY", "2024-01-01", "2024-01-31")
+	require.NoError(t, err)
+	require.Len(t, prices, 2)
+	assert.Equal(t, 99.5, prices[0].Close)
+}
+
+func TestRefresher_StoreCorporateActions(t *testing.T) {
+	database := setupTestDB(t)
+	cfg := testConfig(t.TempDir())
+	refresher := NewRefresher(cfg, database, NewProviders(cfg, database))
+
+	err := db.NewSymbolRepository(database).Create(&db.Symbol{Symbol: "AAPL", Name: "Apple", AssetType: "STOCK", Active: true})
+	require.NoError(t, err)
+
+	runID, err := db.NewRunRepository(database).Create("test")
+	require.NoError(t, err)
+
+	day := time.Date(2020, 8, 28, 0, 0, 0, 0, time.UTC)
+	bars := []fetch.Bar{
+		{Date: day, Open: 500, High: 510, Low: 495, Close: 499, AdjClose: 124.75, Volume: 1000, SplitCoefficient: 1},
+		{Date: day.AddDate(0, 0, 3), Open: 127, High: 131, Low: 126, Close: 129, AdjClose: 129, Volume: 4000, SplitCoefficient: 4},
+		{Date: day.AddDate(0, 0, 4), Open: 132, High: 134, Low: 130, Close: 131, AdjClose: 131, Volume: 3000, Dividend: 0.205},
+	}
+
+	sr := refresher.Store(runID, fetch.FetchResult{Symbol: "AAPL", Provider: fetch.ProviderAlphaVantage, Success: true, Bars: bars})
+	require.NoError(t, sr.Err)
+	assert.Equal(t, 2, sr.Actions)
+
+	actions, err := db.NewCorporateActionRepository(database).ListForSymbol("AAPL")
+	require.NoError(t, err)
+	require.Len(t, actions, 2)
+	assert.Equal(t, "2020-08-31", actions[0].Date)
+	assert.Equal(t, 4.0, actions[0].SplitCoefficient)
+	assert.Equal(t, 0.205, actions[1].Dividend)
+	assert.Equal(t, 1.0, actions[1].SplitCoefficient)
+	assert.Equal(t, fetch.ProviderAlphaVantage, *actions[1].Source)
+
+	// New actions trigger a rebuild of adjusted closes from raw closes
+	prices, err := db.NewPriceRepository(database).GetRange("AAPL", "2020-08-01", "2020-09-30")
+	require.NoError(t, err)
+	require.Len(t, prices, 3)
+	assert.InDelta(t, 499.0/4*(1-0.205/129), *prices[0].AdjClose, 1e-9)
+	assert.InDelta(t, 131.0, *prices[2].AdjClose, 1e-9)
+
+	// Storing the same bars again reports no new actions
+	otherRun, err := db.NewRunRepository(database).Create("test")
+	require.NoError(t, err)
+	sr = refresher.Store(otherRun, fetch.FetchResult{Symbol: "AAPL", Provider: fetch.ProviderAlphaVantage, Success: true, Bars: bars})
+	require.NoError(t, sr.Err)
+	assert.Equal(t, 0, sr.Actions)
+}
+
+func TestRefresher_Plan(t *testing.T) {
+	database := setupTestDB(t)
+	cfg := testConfig(t.TempDir())
+	cfg.Fetcher.CacheEnabled = true
+	cfg.Fetcher.OnlyFetchDeltas = true
+	cfg.Lookbacks.R12M = 3
+	refresher := NewRefresher(cfg, database, NewProviders(cfg, database))
+
+	symbolRepo := db.NewSymbolRepository(database)
+	priceRepo := db.NewPriceRepository(database)
+	for _, symbol := range []string{"CUR", "OLD", "THIN", "NEW"} {
+		require.NoError(t, symbolRepo.Create(&db.Symbol{Symbol: symbol, Name: symbol, AssetType: "ETF", Active: true}))
+	}
+
+	// Friday 2025-10-10; the previous business day is Thursday 2025-10-09
+	now := time.Date(2025, 10, 10, 12, 0, 0, 0, time.UTC)
+	store := func(symbol string, dates ...string) {
+		for _, date := range dates {
+			require.NoError(t, priceRepo.UpsertBatch([]db.Price{{Symbol: symbol, Date: date, Open: 1, High: 1, Low: 1, Close: 1}}))
+		}
+	}
+	store("CUR", "2025-10-06", "2025-10-07", "2025-10-08", "2025-10-09")
+	store("OLD", "2025-10-01", "2025-10-02", "2025-10-03", "2025-10-06")
+	store("THIN", "2025-10-06")
+
+	tasks, skipped, _, err := refresher.plan([]string{"CUR", "OLD", "THIN", "NEW"}, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"CUR"}, skipped)
+	require.Len(t, tasks, 3)
+
+	// Enough history: only the delta after the latest stored bar
+	assert.Equal(t, "OLD", tasks[0].Symbol)
+	assert.Equal(t, "2025-10-07", tasks[0].From.Format("2006-01-02"))
+
+	// Insufficient or no history: full download
+	assert.Equal(t, "THIN", tasks[1].Symbol)
+	assert.True(t, tasks[1].From.IsZero())
+	assert.Equal(t, "NEW", tasks[2].Symbol)
+	assert.True(t, tasks[2].From.IsZero())
+}
+
+func TestRefresher_PlanWithoutCacheOrDeltas(t *testing.T) {
+	database := setupTestDB(t)
+	cfg := testConfig(t.TempDir())
+	refresher := NewRefresher(cfg, database, NewProviders(cfg, database))
+
+	require.NoError(t, db.NewSymbolRepository(database).Create(&db.Symbol{Symbol: "SPY", Name: "SPY", AssetType: "ETF", Active: true}))
+	require.NoError(t, db.NewPriceRepository(database).UpsertBatch([]db.Price{{Symbol: "SPY", Date: "2025-10-09", Open: 1, High: 1, Low: 1, Close: 1}}))
+
+	tasks, skipped, _, err := refresher.plan([]string{"SPY"}, time.Date(2025, 10, 10, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	assert.Empty(t, skipped)
+	require.Len(t, tasks, 1)
+	assert.True(t, tasks[0].From.IsZero())
+}
+
+// quotaProvider reports a fixed number of requests left on the daily quota.
+type quotaProvider struct {
+	fetch.Provider
+	left int
+}
+
+func (p *quotaProvider) Quota() *fetch.RateLimiterStatus {
+	return &fetch.RateLimiterStatus{DailyLimit: 25, RequestsLeft: p.left}
+}
+
+func TestRefresher_Queue(t *testing.T) {
+	database := setupTestDB(t)
+	cfg := testConfig(t.TempDir())
+	providers := fetch.NewProviders(&quotaProvider{Provider: fetch.NewCSVProvider(t.TempDir()), left: 3})
+	refresher := NewRefresher(cfg, database, providers)
+
+	symbolRepo := db.NewSymbolRepository(database)
+	priceRepo := db.NewPriceRepository(database)
+	for _, symbol := range []string{"LOW", "TOP", "NEW", "OLD", "TAIL"} {
+		require.NoError(t, symbolRepo.Create(&db.Symbol{Symbol: symbol, Name: symbol, AssetType: "ETF", Active: true}))
+	}
+	for _, symbol := range []string{"LOW", "TOP", "TAIL"} {
+		require.NoError(t, priceRepo.Create(&db.Price{Symbol: symbol, Date: "2025-10-08", Open: 1, High: 1, Low: 1, Close: 1}))
+	}
+	require.NoError(t, priceRepo.Create(&db.Price{Symbol: "OLD", Date: "2025-10-01", Open: 1, High: 1, Low: 1, Close: 1}))
+
+	rank := func(n int) *int { return &n }
+	require.NoError(t, db.NewIndicatorRepository(database).UpsertBatch([]db.Indicator{
+		{Symbol: "LOW", Date: "2025-10-08", Rank: rank(9)},
+		{Symbol: "TOP", Date: "2025-10-08", Rank: rank(1)},
+	}))
+
+	// TAIL ran out of quota last time
+	require.NoError(t, db.NewPendingFetchRepository(database).Replace([]db.PendingFetch{{Symbol: "TAIL", Reason: "quota"}}))
+
+	var tasks []fetch.FetchTask
+	for _, symbol := range []string{"LOW", "TOP", "NEW", "OLD", "TAIL"} {
+		tasks = append(tasks, fetch.FetchTask{Symbol: symbol})
+	}
+
+	queued, deferred, err := refresher.queue(tasks)
+	require.NoError(t, err)
+
+	symbolsOf := func(tasks []fetch.FetchTask) []string {
+		var symbols []string
+		for _, task := range tasks {
+			symbols = append(symbols, task.Symbol)
+		}
+		return symbols
+	}
+
+	// Carried over, then most stale, then by rank among equally stale symbols
+	assert.Equal(t, []string{"TAIL", "NEW", "OLD"}, symbolsOf(queued))
+	assert.Equal(t, []string{"TOP", "LOW"}, symbolsOf(deferred))
+}
+
+func TestRefresher_RunCarriesDeferredSymbols(t *testing.T) {
+	database := setupTestDB(t)
+	csvDir := t.TempDir()
+	writePriceCSV(t, csvDir, "SPY", 60)
+	writePriceCSV(t, csvDir, "QQQ", 60)
+
+	symbolRepo := db.NewSymbolRepository(database)
+	require.NoError(t, symbolRepo.Create(&db.Symbol{Symbol: "SPY", Name: "SPDR S&P 500", AssetType: "ETF", Active: true}))
+	require.NoError(t, symbolRepo.Create(&db.Symbol{Symbol: "QQQ", Name: "Invesco QQQ", AssetType: "ETF", Active: true}))
+
+	cfg := testConfig(csvDir)
+	cfg.Fetcher.CacheEnabled = true
+	provider := &quotaProvider{Provider: fetch.NewCSVProvider(csvDir), left: 1}
+	refresher := NewRefresher(cfg, database, fetch.NewProviders(provider))
+
+	result, err := refresher.Run(context.Background(), "first refresh")
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Succeeded)
+	require.Len(t, result.Deferred, 1)
+	deferred := result.Deferred[0]
+
+	pending, err := db.NewPendingFetchRepository(database).List()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, deferred, pending[0].Symbol)
+
+	// The next run picks up the leftover symbol and clears the queue
+	result, err = refresher.Run(context.Background(), "second refresh")
+	require.NoError(t, err)
+	require.Len(t, result.Symbols, 1)
+	assert.Equal(t, deferred, result.Symbols[0].Symbol)
+	assert.Empty(t, result.Deferred)
+
+	pending, err = db.NewPendingFetchRepository(database).List()
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestRefresher_Resume(t *testing.T) {
+	database := setupTestDB(t)
+	csvDir := t.TempDir()
+	writePriceCSV(t, csvDir, "SPY", 60)
+
+	symbolRepo := db.NewSymbolRepository(database)
+	require.NoError(t, symbolRepo.Create(&db.Symbol{Symbol: "SPY", Name: "SPDR S&P 500", AssetType: "ETF", Active: true}))
+	require.NoError(t, symbolRepo.Create(&db.Symbol{Symbol: "QQQ", Name: "Invesco QQQ", AssetType: "ETF", Active: true}))
+
+	cfg := testConfig(csvDir)
+	refresher := NewRefresher(cfg, database, NewProviders(cfg, database))
+
+	// Nothing has run yet
+	_, err := refresher.Resume(context.Background())
+	assert.ErrorIs(t, err, ErrNothingToResume)
+
+	// QQQ has no file yet, so the run finishes with an error
+	first, err := refresher.Run(context.Background(), "test refresh")
+	require.NoError(t, err)
+	assert.Equal(t, 1, first.Failed)
+
+	// Simulate a crash: the run never finished
+	_, err = database.Exec(`UPDATE runs SET status = 'RUNNING', finished_at = NULL, started_at = datetime('now', '-1 hour') WHERE run_id = ?`, first.RunID)
+	require.NoError(t, err)
+
+	marked, err := MarkStaleRuns(cfg, database)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), marked)
+
+	writePriceCSV(t, csvDir, "QQQ", 60)
+
+	result, err := refresher.Resume(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, first.RunID, result.RunID)
+	assert.Equal(t, 1, result.Resumed)
+	require.Len(t, result.Symbols, 1, "only the symbol without an ok fetch_log row is fetched")
+	assert.Equal(t, "QQQ", result.Symbols[0].Symbol)
+	assert.Equal(t, 1, result.Succeeded)
+
+	run, err := db.NewRunRepository(database).GetLatest()
+	require.NoError(t, err)
+	assert.Equal(t, first.RunID, run.RunID)
+	assert.Equal(t, "OK", run.Status)
+	assert.Equal(t, 2, run.SymbolsProcessed)
+	assert.Equal(t, 0, run.SymbolsFailed)
+	assert.Contains(t, *run.Notes, "interrupted")
+
+	_, err = refresher.Resume(context.Background())
+	assert.ErrorIs(t, err, ErrNothingToResume)
+}
+
+func TestRefresher_RunBackfillsGaps(t *testing.T) {
+	database := setupTestDB(t)
+	csvDir := t.TempDir()
+	writePriceCSV(t, csvDir, "SPY", 60)
+	require.NoError(t, db.NewSymbolRepository(database).Create(&db.Symbol{Symbol: "SPY", Name: "SPY", AssetType: "ETF", Active: true}))
+
+	cfg := testConfig(csvDir)
+	cfg.Fetcher.CacheEnabled = true
+	cfg.Fetcher.OnlyFetchDeltas = true
+	refresher := NewRefresher(cfg, database, NewProviders(cfg, database))
+
+	_, err := refresher.Run(context.Background(), "initial")
+	require.NoError(t, err)
+
+	// Knock a hole in the stored history; SPY is still current
+	_, err = database.Exec(`DELETE FROM prices WHERE symbol = 'SPY' AND date IN (
+		SELECT date FROM prices WHERE symbol = 'SPY' ORDER BY date LIMIT 3 OFFSET 30)`)
+	require.NoError(t, err)
+	gap, hasGap, err := refresher.openGaps("SPY")
+	require.NoError(t, err)
+	require.True(t, hasGap)
+
+	result, err := refresher.Run(context.Background(), "backfill")
+	require.NoError(t, err)
+	assert.Empty(t, result.Skipped)
+	require.Len(t, result.Symbols, 1)
+	require.NoError(t, result.Symbols[0].Err)
+	assert.Contains(t, result.Symbols[0].Backfill, fmt.Sprintf("filled %d of %d missing days", gap.Days, gap.Days))
+
+	var count int
+	require.NoError(t, database.QueryRow("SELECT COUNT(*) FROM prices WHERE symbol = 'SPY'").Scan(&count))
+	assert.Equal(t, 60, count)
+
+	// fetch_log records the backfilled range rather than the bars stored
+	backfills, err := db.NewFetchLogRepository(database).GetBackfills("SPY")
+	require.NoError(t, err)
+	require.Len(t, backfills, 1)
+	assert.Equal(t, result.RunID, backfills[0].RunID)
+	assert.Equal(t, gap.From.Format("2006-01-02"), *backfills[0].FromDate)
+	assert.Equal(t, gap.To.Format("2006-01-02"), *backfills[0].ToDate)
+
+	// Nothing left to fill
+	result, err = refresher.Run(context.Background(), "current")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SPY"}, result.Skipped)
+}
+
+func TestRefresher_PlanSkipsAttemptedBackfill(t *testing.T) {
+	database := setupTestDB(t)
+	cfg := testConfig(t.TempDir())
+	cfg.Fetcher.CacheEnabled = true
+	refresher := NewRefresher(cfg, database, NewProviders(cfg, database))
+
+	require.NoError(t, db.NewSymbolRepository(database).Create(&db.Symbol{Symbol: "SPY", Name: "SPY", AssetType: "ETF", Active: true}))
+	for _, date := range []string{"2025-10-01", "2025-10-02", "2025-10-07", "2025-10-08", "2025-10-09"} {
+		require.NoError(t, db.NewPriceRepository(database).UpsertBatch([]db.Price{{Symbol: "SPY", Date: date, Open: 1, High: 1, Low: 1, Close: 1}}))
+	}
+	now := time.Date(2025, 10, 10, 12, 0, 0, 0, time.UTC)
+
+	// Oct 3 and 6 are missing
+	tasks, skipped, backfills, err := refresher.plan([]string{"SPY"}, now)
+	require.NoError(t, err)
+	assert.Empty(t, skipped)
+	require.Len(t, tasks, 1)
+	require.Contains(t, backfills, "SPY")
+	assert.Equal(t, 2, backfills["SPY"].Days)
+	assert.Equal(t, "2025-10-03", backfills["SPY"].From.Format("2006-01-02"))
+
+	// The provider had nothing for those days last time
+	runID, err := db.NewRunRepository(database).Create("test")
+	require.NoError(t, err)
+	from, to, note := "2025-10-03", "2025-10-06", "backfill: filled 0 of 2 missing days"
+	require.NoError(t, db.NewFetchLogRepository(database).Log(&db.FetchLog{RunID: runID, Symbol: "SPY", FromDate: &from, ToDate: &to, OK: true, Message: &note, Backfill: true}))
+
+	tasks, skipped, backfills, err = refresher.plan([]string{"SPY"}, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SPY"}, skipped)
+	assert.Empty(t, tasks)
+	assert.Empty(t, backfills)
+}
@@ -0,0 +1,125 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cajundata/momorot/internal/analytics"
+	"github.com/cajundata/momorot/internal/config"
+	"github.com/cajundata/momorot/internal/db"
+	"github.com/cajundata/momorot/internal/fetch"
+)
+
+// Snapshot is a provisional ranking computed from latest quotes. It is never
+// stored in the indicators table.
+type Snapshot struct {
+	AsOf    time.Time
+	Leaders []db.Indicator  // Provisional top N, Date is the quote's trading day for quoted symbols
+	Quoted  map[string]bool // Symbols whose current bar is a live quote
+	Failed  []string        // Symbols that could not be quoted and use their last close
+}
+
+// Snapshotter quotes the current leaders and re-ranks the universe with the
+// latest prices standing in for today's bar.
+type Snapshotter struct {
+	source        fetch.QuoteSource
+	enabled       bool
+	maxSymbols    int
+	orchestrator  *analytics.Orchestrator
+	indicatorRepo *db.IndicatorRepository
+}
+
+// NewSnapshotter creates a snapshotter for the live_quotes settings. Quotes come
+// from the Alpha Vantage client among providers, so they share its rate limits
+// and quota.
+func NewSnapshotter(cfg *config.Config, database *db.DB, providers *fetch.Providers) *Snapshotter {
+	return &Snapshotter{
+		source:        NewQuoteSource(providers),
+		enabled:       cfg.LiveQuotes.Enabled,
+		maxSymbols:    cfg.LiveQuotes.MaxSymbols,
+		orchestrator:  NewOrchestrator(cfg, database),
+		indicatorRepo: db.NewIndicatorRepository(database),
+	}
+}
+
+// NewQuoteSource returns the provider serving latest quotes, or nil when none does.
+func NewQuoteSource(providers *fetch.Providers) fetch.QuoteSource {
+	if providers == nil {
+		return nil
+	}
+	for _, p := range providers.All() {
+		if source, ok := p.(fetch.QuoteSource); ok && p.Name() == fetch.ProviderAlphaVantage {
+			return source
+		}
+	}
+	return nil
+}
+
+// Enabled reports whether live quotes are turned on and a quote source is available.
+func (s *Snapshotter) Enabled() bool {
+	return s.enabled && s.source != nil
+}
+
+// Run quotes the best ranked symbols (up to live_quotes.max_symbols) and
+// returns the provisional top n. Symbols that are not quoted keep their last
+// stored close. Quoting stops early when ctx is canceled or the daily quota is
+// used up.
+func (s *Snapshotter) Run(ctx context.Context, n int) (*Snapshot, error) {
+	if !s.Enabled() {
+		return nil, fmt.Errorf("live quotes are not enabled")
+	}
+
+	ranks, err := s.indicatorRepo.LatestRanks(s.orchestrator.Strategy())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest ranks: %w", err)
+	}
+
+	symbols := make([]string, 0, len(ranks))
+	for symbol := range ranks {
+		symbols = append(symbols, symbol)
+	}
+	sort.Slice(symbols, func(i, j int) bool {
+		if ranks[symbols[i]] != ranks[symbols[j]] {
+			return ranks[symbols[i]] < ranks[symbols[j]]
+		}
+		return symbols[i] < symbols[j]
+	})
+	if len(symbols) > s.maxSymbols {
+		symbols = symbols[:s.maxSymbols]
+	}
+
+	snapshot := &Snapshot{Quoted: make(map[string]bool)}
+	live := make(map[string]analytics.LivePrice)
+	for i, symbol := range symbols {
+		quote, err := s.source.FetchQuote(ctx, symbol)
+		if err != nil {
+			if errors.Is(err, fetch.ErrQuotaExceeded) || ctx.Err() != nil {
+				snapshot.Failed = append(snapshot.Failed, symbols[i:]...)
+				break
+			}
+			snapshot.Failed = append(snapshot.Failed, symbol)
+			continue
+		}
+		live[symbol] = analytics.LivePrice{Date: quote.Day, Price: quote.Price, Volume: quote.Volume}
+		snapshot.Quoted[symbol] = true
+	}
+
+	if len(live) == 0 {
+		return nil, fmt.Errorf("no live quotes available for %d symbols", len(symbols))
+	}
+
+	ranked, err := s.orchestrator.ComputeProvisional(live)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute provisional ranking: %w", err)
+	}
+
+	for _, rs := range analytics.GetTopN(ranked, n) {
+		snapshot.Leaders = append(snapshot.Leaders, analytics.IndicatorRecord(rs))
+	}
+	snapshot.AsOf = time.Now()
+
+	return snapshot, nil
+}
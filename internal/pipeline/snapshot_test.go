@@ -0,0 +1,125 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cajundata/momorot/internal/config"
+	"github.com/cajundata/momorot/internal/db"
+	"github.com/cajundata/momorot/internal/fetch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// quoteProvider serves fixed latest prices in place of the Alpha Vantage client.
+type quoteProvider struct {
+	fetch.Provider
+	day    time.Time
+	prices map[string]float64
+	quoted []string
+}
+
+func (p *quoteProvider) Name() string { return fetch.ProviderAlphaVantage }
+
+func (p *quoteProvider) FetchQuote(ctx context.Context, symbol string) (*fetch.Quote, error) {
+	p.quoted = append(p.quoted, symbol)
+	price, ok := p.prices[symbol]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", symbol, fetch.ErrQuotaExceeded)
+	}
+	return &fetch.Quote{Symbol: symbol, Day: p.day, Price: price, Volume: 1000}, nil
+}
+
+func TestSnapshotter_Run(t *testing.T) {
+	database := setupTestDB(t)
+	cfg := testConfig(t.TempDir())
+	cfg.LiveQuotes = config.LiveQuotesConfig{Enabled: true, MaxSymbols: 2}
+
+	// CCC gains 3% a day, BBB 2% and AAA 1%
+	today := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	symbolRepo := db.NewSymbolRepository(database)
+	priceRepo := db.NewPriceRepository(database)
+	last := make(map[string]float64)
+	for i, symbol := range []string{"AAA", "BBB", "CCC"} {
+		require.NoError(t, symbolRepo.Create(&db.Symbol{Symbol: symbol, Name: symbol, AssetType: "ETF", Active: true}))
+
+		close := 100.0
+		for d := today.AddDate(0, 0, -60); d.Before(today); d = d.AddDate(0, 0, 1) {
+			volume := int64(1_000_000)
+			adjClose := close
+			require.NoError(t, priceRepo.Create(&db.Price{
+				Symbol: symbol, Date: d.Format("2006-01-02"),
+				Open: close, High: close, Low: close, Close: close, AdjClose: &adjClose, Volume: &volume,
+			}))
+			last[symbol] = close
+			close *= 1 + 0.01*float64(i+1)
+		}
+	}
+	_, err := NewOrchestrator(cfg, database).ComputeAllIndicators(today)
+	require.NoError(t, err)
+
+	// CCC drops below where it was a month ago; AAA is outside max_symbols
+	provider := &quoteProvider{day: today, prices: map[string]float64{
+		"CCC": last["CCC"] * 0.5,
+		"BBB": last["BBB"] * 1.01,
+	}}
+	snapshotter := NewSnapshotter(cfg, database, fetch.NewProviders(provider))
+	require.True(t, snapshotter.Enabled())
+
+	snapshot, err := snapshotter.Run(context.Background(), 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"CCC", "BBB"}, provider.quoted)
+	assert.Equal(t, map[string]bool{"CCC": true, "BBB": true}, snapshot.Quoted)
+	assert.Empty(t, snapshot.Failed)
+
+	require.Len(t, snapshot.Leaders, 3)
+	assert.Equal(t, "BBB", snapshot.Leaders[0].Symbol)
+	assert.Equal(t, "2024-03-15", snapshot.Leaders[0].Date)
+	assert.Equal(t, "CCC", snapshot.Leaders[2].Symbol)
+	assert.Equal(t, "2024-03-14", snapshot.Leaders[1].Date) // AAA keeps its last close
+
+	// The stored ranking is untouched
+	stored, err := db.NewIndicatorRepository(database).GetTopN("2024-03-14", db.DefaultStrategy, 10)
+	require.NoError(t, err)
+	require.Len(t, stored, 3)
+	assert.Equal(t, "CCC", stored[0].Symbol)
+	provisional, err := db.NewIndicatorRepository(database).GetTopN("2024-03-15", db.DefaultStrategy, 10)
+	require.NoError(t, err)
+	assert.Empty(t, provisional)
+}
+
+func TestSnapshotter_RunStopsOnQuota(t *testing.T) {
+	database := setupTestDB(t)
+	cfg := testConfig(t.TempDir())
+	cfg.LiveQuotes = config.LiveQuotesConfig{Enabled: true, MaxSymbols: 5}
+
+	for _, symbol := range []string{"AAA", "BBB"} {
+		require.NoError(t, db.NewSymbolRepository(database).Create(&db.Symbol{Symbol: symbol, Name: symbol, AssetType: "ETF", Active: true}))
+		require.NoError(t, db.NewPriceRepository(database).Create(&db.Price{Symbol: symbol, Date: "2024-03-14", Open: 1, High: 1, Low: 1, Close: 1}))
+	}
+	rank := func(n int) *int { return &n }
+	require.NoError(t, db.NewIndicatorRepository(database).UpsertBatch([]db.Indicator{
+		{Symbol: "AAA", Date: "2024-03-14", Rank: rank(1)},
+		{Symbol: "BBB", Date: "2024-03-14", Rank: rank(2)},
+	}))
+
+	provider := &quoteProvider{prices: map[string]float64{}}
+	_, err := NewSnapshotter(cfg, database, fetch.NewProviders(provider)).Run(context.Background(), 10)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"AAA"}, provider.quoted)
+}
+
+func TestSnapshotter_Disabled(t *testing.T) {
+	cfg := testConfig(t.TempDir())
+	cfg.LiveQuotes = config.LiveQuotesConfig{Enabled: true, MaxSymbols: 5}
+
+	// The CSV provider cannot quote
+	snapshotter := NewSnapshotter(cfg, setupTestDB(t), NewProviders(cfg, nil))
+	assert.False(t, snapshotter.Enabled())
+
+	_, err := snapshotter.Run(context.Background(), 10)
+	assert.Error(t, err)
+}
@@ -10,6 +10,8 @@ type KeyBindings struct {
 	Refresh  key.Binding
 	Search   key.Binding
 	Export   key.Binding
+	Live     key.Binding // Leaders screen: toggle the live quote snapshot
+	Sweep    key.Binding // Sweep screen: run the parameter sweep
 	NextTab  key.Binding
 	PrevTab  key.Binding
 	Enter    key.Binding
@@ -41,6 +43,14 @@ func DefaultKeyBindings() KeyBindings {
 			key.WithKeys("e"),
 			key.WithHelp("e", "export"),
 		),
+		Live: key.NewBinding(
+			key.WithKeys("l"),
+			key.WithHelp("l", "live quotes"),
+		),
+		Sweep: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "run sweep"),
+		),
 		NextTab: key.NewBinding(
 			key.WithKeys("right", "tab"),
 			key.WithHelp("→/tab", "next screen"),
@@ -102,6 +112,6 @@ func (k KeyBindings) FullHelp() [][]key.Binding {
 		{k.Up, k.Down, k.PageUp, k.PageDown},
 		{k.Home, k.End, k.Enter, k.Back},
 		{k.NextTab, k.PrevTab, k.Refresh, k.Search},
-		{k.Export, k.Quit},
+		{k.Export, k.Live, k.Sweep, k.Quit},
 	}
 }
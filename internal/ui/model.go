@@ -1,9 +1,13 @@
 package ui
 
 import (
+	"context"
+
 	"github.com/cajundata/momorot/internal/analytics"
 	"github.com/cajundata/momorot/internal/config"
 	"github.com/cajundata/momorot/internal/db"
+	"github.com/cajundata/momorot/internal/fetch"
+	"github.com/cajundata/momorot/internal/pipeline"
 	"github.com/cajundata/momorot/internal/ui/screens"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -17,6 +21,7 @@ const (
 	ScreenUniverse
 	ScreenSymbol
 	ScreenLogs
+	ScreenSweep
 )
 
 // Model represents the main application state.
@@ -36,6 +41,7 @@ type Model struct {
 	universe  screens.UniverseModel
 	symbol    screens.SymbolModel
 	logs      screens.LogsModel
+	sweep     screens.SweepModel
 
 	// Symbol drill-down state
 	selectedSymbol string // For navigating from Leaders to Symbol Detail
@@ -48,6 +54,9 @@ type Model struct {
 	height       int
 	statusBarMsg string
 
+	// Cancels the running refresh; nil when no refresh is in progress
+	refreshCancel context.CancelFunc
+
 	// Key bindings and theme
 	keys  KeyBindings
 	theme Theme
@@ -57,25 +66,7 @@ type Model struct {
 // New creates a new Model with the given dependencies.
 func New(database *db.DB, cfg *config.Config) Model {
 	// Create orchestrator
-	orchestrator := analytics.NewOrchestrator(
-		database,
-		map[string]int{
-			"r1m":  cfg.Lookbacks.R1M,
-			"r3m":  cfg.Lookbacks.R3M,
-			"r6m":  cfg.Lookbacks.R6M,
-			"r12m": cfg.Lookbacks.R12M,
-		},
-		map[string]int{
-			"short": cfg.VolWindows.Short,
-			"long":  cfg.VolWindows.Long,
-		},
-		analytics.ScoringConfig{
-			PenaltyLambda:      cfg.Scoring.PenaltyLambda,
-			MinADV:             cfg.Scoring.MinADVUSD,
-			BreadthMinPositive: cfg.Scoring.BreadthMinPositive,
-			BreadthTotal:       cfg.Scoring.BreadthTotalLookbacks,
-		},
-	)
+	orchestrator := pipeline.NewOrchestrator(cfg, database)
 
 	// Initial dimensions (will be updated by WindowSizeMsg)
 	width := 80
@@ -84,10 +75,20 @@ func New(database *db.DB, cfg *config.Config) Model {
 
 	// Initialize all screens
 	dashboard := screens.NewDashboard(database, width, contentHeight)
+	quotaLimits := make(map[string]int)
+	for provider, status := range fetch.NewScheduler(pipeline.NewProviders(cfg, database), cfg.Fetcher.MaxWorkers).Quotas() {
+		quotaLimits[provider] = status.DailyLimit
+	}
+	dashboard.SetQuotaSources(quotaLimits)
 	leaders := screens.NewLeaders(database, width, contentHeight)
+	leaders.SetExtraColumns(cfg.IndicatorNames())
+	leaders.SetStrategy(orchestrator.Strategy())
 	universe := screens.NewUniverse(database, width, contentHeight)
 	symbol := screens.NewSymbol(database, "", width, contentHeight) // Empty symbol initially
+	symbol.SetStrategy(orchestrator.Strategy())
 	logs := screens.NewLogs(database, width, contentHeight)
+	sweep := screens.NewSweep(width, contentHeight)
+	sweep.SetCombinations(len(pipeline.NewSweepGrid(cfg).Combinations()))
 
 	return Model{
 		db:            database,
@@ -100,6 +101,7 @@ func New(database *db.DB, cfg *config.Config) Model {
 		universe:      universe,
 		symbol:        symbol,
 		logs:          logs,
+		sweep:         sweep,
 		keys:          DefaultKeyBindings(),
 		theme:         DefaultTheme(),
 		loading:       false,
@@ -117,6 +119,7 @@ func (m Model) Init() tea.Cmd {
 		m.universe.Init(),
 		m.symbol.Init(),
 		m.logs.Init(),
+		m.sweep.Init(),
 	)
 }
 
@@ -161,6 +164,7 @@ func (m *Model) NavigateToSymbol(symbol string) {
 	m.NavigateTo(ScreenSymbol)
 	// Reinitialize symbol screen with new symbol
 	m.symbol = screens.NewSymbol(m.db, symbol, m.width, m.height-6)
+	m.symbol.SetStrategy(m.orchestrator.Strategy())
 }
 
 // Messages for screen navigation
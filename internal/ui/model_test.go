@@ -1,10 +1,19 @@
 package ui
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/cajundata/momorot/internal/backtest"
 	"github.com/cajundata/momorot/internal/config"
 	"github.com/cajundata/momorot/internal/db"
+	"github.com/cajundata/momorot/internal/pipeline"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -56,6 +65,31 @@ func TestNew(t *testing.T) {
 	assert.False(t, model.loading)
 }
 
+func TestNew_QuotaSources(t *testing.T) {
+	database, err := db.New(db.Config{Path: ":memory:"})
+	require.NoError(t, err)
+	defer database.Close()
+	require.NoError(t, database.Migrate())
+
+	// Alpha Vantage only serves an override of the unlimited default
+	cfg := &config.Config{
+		Universe:     []string{"SPY", "QQQ"},
+		AlphaVantage: config.AlphaVantageConfig{DailyRequestLimit: 25},
+		Providers: config.ProvidersConfig{
+			Default: "csv",
+			CSV:     config.CSVProviderConfig{Dir: t.TempDir()},
+			Symbols: map[string]string{"qqq": "alpha_vantage"},
+		},
+	}
+	model := New(database, cfg)
+
+	dashboard, _ := model.dashboard.Update(model.dashboard.Init()())
+	view := dashboard.View()
+	assert.Contains(t, view, "0/25")
+	assert.Contains(t, view, "alpha_vantage")
+	assert.NotContains(t, view, "Unlimited")
+}
+
 func TestInit(t *testing.T) {
 	model, database := setupTestModel(t)
 	defer database.Close()
@@ -104,7 +138,8 @@ func TestNavigateNext(t *testing.T) {
 		{ScreenLeaders, ScreenUniverse},
 		{ScreenUniverse, ScreenSymbol},
 		{ScreenSymbol, ScreenLogs},
-		{ScreenLogs, ScreenDashboard},
+		{ScreenLogs, ScreenSweep},
+		{ScreenSweep, ScreenDashboard},
 	}
 
 	for _, tt := range tests {
@@ -122,7 +157,8 @@ func TestNavigatePrev(t *testing.T) {
 		from Screen
 		to   Screen
 	}{
-		{ScreenDashboard, ScreenLogs},
+		{ScreenDashboard, ScreenSweep},
+		{ScreenSweep, ScreenLogs},
 		{ScreenLeaders, ScreenDashboard},
 		{ScreenUniverse, ScreenLeaders},
 		{ScreenSymbol, ScreenUniverse},
@@ -212,6 +248,8 @@ func TestDefaultKeyBindings(t *testing.T) {
 	assert.NotNil(t, keys.Export)
 	assert.NotNil(t, keys.NextTab)
 	assert.NotNil(t, keys.PrevTab)
+	assert.Equal(t, []string{"l"}, keys.Live.Keys())
+	assert.Equal(t, []string{"s"}, keys.Sweep.Keys())
 
 	// Test help text
 	shortHelp := keys.ShortHelp()
@@ -220,3 +258,168 @@ func TestDefaultKeyBindings(t *testing.T) {
 	fullHelp := keys.FullHelp()
 	assert.Len(t, fullHelp, 4)
 }
+
+func TestRefresh_StreamsProgress(t *testing.T) {
+	model, database := setupTestModel(t)
+	defer database.Close()
+
+	// Serve SPY from a CSV directory with lookbacks short enough for 60 bars
+	csvDir := t.TempDir()
+	var b strings.Builder
+	b.WriteString("Date,Open,High,Low,Close,Volume\n")
+	day := time.Now().AddDate(0, 0, -90)
+	for i := 0; i < 60; i++ {
+		price := 100.0 + float64(i)
+		fmt.Fprintf(&b, "%s,%.2f,%.2f,%.2f,%.2f,1000000\n", day.AddDate(0, 0, i).Format("2006-01-02"), price, price+1, price-1, price)
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(csvDir, "SPY.csv"), []byte(b.String()), 0644))
+
+	model.config.Providers = config.ProvidersConfig{Default: "csv", CSV: config.CSVProviderConfig{Dir: csvDir}}
+	model.config.Fetcher = config.FetcherConfig{MaxWorkers: 1}
+	model.config.Lookbacks = config.LookbacksConfig{R1M: 5, R3M: 10, R6M: 20, R12M: 40}
+	model.config.VolWindows = config.VolWindowsConfig{Short: 10, Long: 20}
+	require.NoError(t, db.NewSymbolRepository(database).Create(&db.Symbol{Symbol: "SPY", Name: "SPDR S&P 500", AssetType: "ETF", Active: true}))
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	model = updated.(Model)
+	require.NotNil(t, cmd)
+	assert.True(t, model.loading)
+
+	// A second refresh is ignored while one is running
+	_, again := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	assert.Nil(t, again)
+
+	var progress []string
+	msg := cmd()
+	for {
+		if _, ok := msg.(refreshProgressMsg); !ok {
+			break
+		}
+		updated, cmd = model.Update(msg)
+		model = updated.(Model)
+		progress = append(progress, model.loadingMsg)
+		msg = cmd()
+	}
+
+	assert.Equal(t, []string{"[0/1] Fetching SPY", "[1/1] Fetched SPY"}, progress)
+
+	require.IsType(t, refreshCompleteMsg{}, msg)
+	updated, _ = model.Update(msg)
+	model = updated.(Model)
+	assert.False(t, model.loading)
+	assert.Nil(t, model.refreshCancel)
+	assert.Contains(t, model.statusBarMsg, "1 updated")
+}
+
+func TestRefresh_CanceledWithoutReader(t *testing.T) {
+	model, database := setupTestModel(t)
+	defer database.Close()
+
+	// More progress events than the channel buffers: none of these has a CSV
+	model.config.Providers = config.ProvidersConfig{Default: "csv", CSV: config.CSVProviderConfig{Dir: t.TempDir()}}
+	model.config.Fetcher = config.FetcherConfig{MaxWorkers: 2}
+	symbolRepo := db.NewSymbolRepository(database)
+	for i := 0; i < 20; i++ {
+		require.NoError(t, symbolRepo.Create(&db.Symbol{Symbol: fmt.Sprintf("S%02d", i), AssetType: "ETF", Active: true}))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	finished := make(chan struct{})
+	var once sync.Once
+	model.triggerRefresh(ctx, func() {
+		cancel()
+		once.Do(func() { close(finished) })
+	})
+
+	// Nobody reads the updates, as after quitting
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("refresh goroutine blocked after its context was canceled")
+	}
+}
+
+func TestLiveSnapshot_Disabled(t *testing.T) {
+	model, database := setupTestModel(t)
+	defer database.Close()
+
+	model.NavigateTo(ScreenLeaders)
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	model = updated.(Model)
+
+	assert.Nil(t, cmd)
+	assert.False(t, model.loading)
+	assert.Contains(t, model.statusBarMsg, "Live quotes are off")
+}
+
+func TestLiveSnapshot_Complete(t *testing.T) {
+	model, database := setupTestModel(t)
+	defer database.Close()
+
+	model.NavigateTo(ScreenLeaders)
+	model.SetLoading(true, "Fetching live quotes")
+
+	rank := 1
+	updated, _ := model.Update(snapshotCompleteMsg{snapshot: &pipeline.Snapshot{
+		AsOf:    time.Now(),
+		Leaders: []db.Indicator{{Symbol: "SPY", Rank: &rank}},
+		Quoted:  map[string]bool{"SPY": true},
+		Failed:  []string{"QQQ"},
+	}})
+	model = updated.(Model)
+
+	assert.False(t, model.loading)
+	assert.True(t, model.leaders.Provisional())
+	assert.Contains(t, model.statusBarMsg, "1 live quotes (not saved), 1 not quoted")
+
+	// Pressing l again goes back to the stored ranking
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	model = updated.(Model)
+	require.NotNil(t, cmd)
+	updated, _ = model.Update(cmd())
+	model = updated.(Model)
+	assert.False(t, model.leaders.Provisional())
+}
+
+func TestSweep_NoData(t *testing.T) {
+	model, database := setupTestModel(t)
+	defer database.Close()
+
+	model.NavigateTo(ScreenSweep)
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	model = updated.(Model)
+	require.NotNil(t, cmd)
+	assert.True(t, model.loading)
+	assert.True(t, model.sweep.Running())
+
+	// No symbols to backtest
+	msg := cmd()
+	require.IsType(t, sweepErrorMsg{}, msg)
+	updated, _ = model.Update(msg)
+	model = updated.(Model)
+
+	assert.False(t, model.loading)
+	assert.False(t, model.sweep.Running())
+	assert.Contains(t, model.errorMsg, "sweep failed")
+}
+
+func TestSweep_Complete(t *testing.T) {
+	model, database := setupTestModel(t)
+	defer database.Close()
+
+	model.NavigateTo(ScreenSweep)
+	model.SetLoading(true, "Backtesting")
+
+	results := []backtest.SweepResult{
+		{Params: backtest.Params{Lambda: 0.35, TopN: 3}, Result: &backtest.Result{CAGR: 0.12}},
+	}
+	updated, _ := model.Update(sweepCompleteMsg{results: results, elapsed: time.Second, filename: "sweep.csv"})
+	model = updated.(Model)
+
+	assert.False(t, model.loading)
+	assert.Contains(t, model.statusBarMsg, "1 combinations, exported to sweep.csv")
+	assert.Contains(t, model.View(), "12.00%")
+}
@@ -2,6 +2,7 @@ package screens
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/cajundata/momorot/internal/db"
@@ -19,8 +20,8 @@ type DashboardModel struct {
 	totalSymbols   int
 	activeSymbols  int
 	lastFetchDate  string
-	apiQuotaUsed   int
-	apiQuotaLimit  int
+	apiQuotaUsed   map[string]int // Requests used today by provider
+	apiQuotaLimit  map[string]int // Daily limit of each rate-limited provider
 	nextResetTime  time.Time
 
 	// UI state
@@ -49,11 +50,17 @@ func NewDashboard(database *db.DB, width, height int) DashboardModel {
 		width:         width,
 		height:        height,
 		theme:         defaultDashboardTheme(),
-		apiQuotaLimit: 25, // Alpha Vantage free tier
+		apiQuotaLimit: map[string]int{"alpha_vantage": 25}, // Alpha Vantage free tier
 		ready:         false,
 	}
 }
 
+// SetQuotaSources sets the rate-limited providers the API Quota card reports,
+// mapped to their daily limits. An empty map marks no provider as rate limited.
+func (m *DashboardModel) SetQuotaSources(limits map[string]int) {
+	m.apiQuotaLimit = limits
+}
+
 // defaultDashboardTheme returns the default dashboard theme.
 func defaultDashboardTheme() DashboardTheme {
 	cardStyle := lipgloss.NewStyle().
@@ -251,28 +258,47 @@ func (m DashboardModel) renderCacheCard() string {
 func (m DashboardModel) renderQuotaCard() string {
 	title := m.theme.CardTitle.Render("📡 API Quota")
 
-	remaining := m.apiQuotaLimit - m.apiQuotaUsed
-	quotaText := fmt.Sprintf("%d/%d", m.apiQuotaUsed, m.apiQuotaLimit)
+	if len(m.apiQuotaLimit) == 0 {
+		content := lipgloss.JoinVertical(lipgloss.Left,
+			m.theme.StatusOK.Render("Unlimited"),
+			"",
+			m.theme.CardLabel.Render("Not rate limited"),
+		)
+		return m.theme.CardStyle.Render(lipgloss.JoinVertical(lipgloss.Left, title, content))
+	}
 
-	var quotaStatus string
-	if remaining > 10 {
-		quotaStatus = m.theme.StatusOK.Render(quotaText)
-	} else if remaining > 0 {
-		quotaStatus = m.theme.StatusRunning.Render(quotaText)
-	} else {
-		quotaStatus = m.theme.StatusError.Render(quotaText)
+	providers := make([]string, 0, len(m.apiQuotaLimit))
+	for provider := range m.apiQuotaLimit {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+
+	// One line of requests used per rate-limited provider
+	lines := make([]string, 0, len(providers)+2)
+	for _, provider := range providers {
+		used, limit := m.apiQuotaUsed[provider], m.apiQuotaLimit[provider]
+		remaining := limit - used
+		quotaText := fmt.Sprintf("%d/%d", used, limit)
+
+		var quotaStatus string
+		if remaining > 10 {
+			quotaStatus = m.theme.StatusOK.Render(quotaText)
+		} else if remaining > 0 {
+			quotaStatus = m.theme.StatusRunning.Render(quotaText)
+		} else {
+			quotaStatus = m.theme.StatusError.Render(quotaText)
+		}
+		lines = append(lines, quotaStatus+" "+m.theme.CardLabel.Render(provider))
 	}
 
 	// Next reset (daily reset at midnight UTC)
-	nextReset := m.theme.CardLabel.Render("Resets daily at midnight UTC")
-
-	content := lipgloss.JoinVertical(lipgloss.Left,
-		quotaStatus,
+	lines = append(lines,
 		m.theme.CardLabel.Render("requests used"),
-		"",
-		nextReset,
+		m.theme.CardLabel.Render("Resets daily at midnight UTC"),
 	)
 
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+
 	return m.theme.CardStyle.Render(lipgloss.JoinVertical(lipgloss.Left, title, content))
 }
 
@@ -312,10 +338,16 @@ func (m DashboardModel) loadData() tea.Msg {
 		return dashboardErrorMsg{err: fmt.Errorf("failed to get latest fetch date: %w", err)}
 	}
 
-	// API quota (for now, hardcoded - would need to track this in DB)
-	apiQuotaUsed := 0
-	if latestRun != nil {
-		apiQuotaUsed = latestRun.SymbolsProcessed
+	// API quota usage for the current UTC day, as recorded by the rate limiters
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	quotaRepo := db.NewQuotaRepository(m.database)
+	apiQuotaUsed := make(map[string]int, len(m.apiQuotaLimit))
+	for provider := range m.apiQuotaLimit {
+		used, err := quotaRepo.RequestsUsed(provider, today.Format("2006-01-02"))
+		if err != nil {
+			return dashboardErrorMsg{err: fmt.Errorf("failed to get API quota usage: %w", err)}
+		}
+		apiQuotaUsed[provider] = used
 	}
 
 	return dashboardDataMsg{
@@ -324,7 +356,7 @@ func (m DashboardModel) loadData() tea.Msg {
 		activeSymbols:  len(activeSymbols),
 		lastFetchDate:  lastFetch,
 		apiQuotaUsed:   apiQuotaUsed,
-		nextResetTime:  time.Now().Add(24 * time.Hour), // Placeholder
+		nextResetTime:  today.Add(24 * time.Hour),
 	}
 }
 
@@ -334,7 +366,7 @@ type dashboardDataMsg struct {
 	totalSymbols   int
 	activeSymbols  int
 	lastFetchDate  string
-	apiQuotaUsed   int
+	apiQuotaUsed   map[string]int
 	nextResetTime  time.Time
 }
 
@@ -41,7 +41,7 @@ func TestNewDashboard(t *testing.T) {
 	assert.NotNil(t, model.database)
 	assert.Equal(t, 80, model.width)
 	assert.Equal(t, 24, model.height)
-	assert.Equal(t, 25, model.apiQuotaLimit)
+	assert.Equal(t, map[string]int{"alpha_vantage": 25}, model.apiQuotaLimit)
 	assert.False(t, model.ready)
 	assert.Nil(t, model.err)
 }
@@ -98,7 +98,7 @@ func TestDashboardUpdateWithDataMsg(t *testing.T) {
 		totalSymbols:   25,
 		activeSymbols:  20,
 		lastFetchDate:  "2025-10-08",
-		apiQuotaUsed:   10,
+		apiQuotaUsed:   map[string]int{"alpha_vantage": 10},
 		nextResetTime:  time.Now().Add(24 * time.Hour),
 	}
 
@@ -111,7 +111,7 @@ func TestDashboardUpdateWithDataMsg(t *testing.T) {
 	assert.Equal(t, 25, updated.totalSymbols)
 	assert.Equal(t, 20, updated.activeSymbols)
 	assert.Equal(t, "2025-10-08", updated.lastFetchDate)
-	assert.Equal(t, 10, updated.apiQuotaUsed)
+	assert.Equal(t, 10, updated.apiQuotaUsed["alpha_vantage"])
 }
 
 func TestDashboardUpdateWithErrorMsg(t *testing.T) {
@@ -166,7 +166,7 @@ func TestDashboardViewWithData(t *testing.T) {
 	model.totalSymbols = 25
 	model.activeSymbols = 20
 	model.lastFetchDate = "2025-10-08"
-	model.apiQuotaUsed = 10
+	model.apiQuotaUsed = map[string]int{"alpha_vantage": 10}
 	model.ready = true
 
 	view := model.View()
@@ -283,13 +283,15 @@ func TestDashboardRenderQuotaCard(t *testing.T) {
 	database := setupTestDB(t)
 	model := NewDashboard(database, 80, 24)
 	model.ready = true
-	model.apiQuotaUsed = 15
-	model.apiQuotaLimit = 25
+	model.apiQuotaUsed = map[string]int{"alpha_vantage": 15, "http": 2}
+	model.SetQuotaSources(map[string]int{"alpha_vantage": 25, "http": 100})
 
 	card := model.renderQuotaCard()
 
 	assert.Contains(t, card, "API Quota")
 	assert.Contains(t, card, "15/25")
+	assert.Contains(t, card, "alpha_vantage")
+	assert.Contains(t, card, "2/100")
 	assert.Contains(t, card, "Resets daily")
 }
 
@@ -346,6 +348,13 @@ func TestDashboardLoadData_WithData(t *testing.T) {
 	})
 	require.NoError(t, err)
 
+	// Record API usage for today and an earlier day
+	quotaRepo := db.NewQuotaRepository(database)
+	today := time.Now().UTC().Format("2006-01-02")
+	require.NoError(t, quotaRepo.RecordRequest("alpha_vantage", today))
+	require.NoError(t, quotaRepo.RecordRequest("alpha_vantage", today))
+	require.NoError(t, quotaRepo.RecordRequest("alpha_vantage", "2025-10-07"))
+
 	model := NewDashboard(database, 80, 24)
 	msg := model.loadData()
 
@@ -358,7 +367,8 @@ func TestDashboardLoadData_WithData(t *testing.T) {
 	assert.Equal(t, 1, dataMsg.totalSymbols)
 	assert.Equal(t, 1, dataMsg.activeSymbols)
 	assert.Equal(t, "2025-10-08", dataMsg.lastFetchDate)
-	assert.Equal(t, 1, dataMsg.apiQuotaUsed) // symbols processed
+	assert.Equal(t, 2, dataMsg.apiQuotaUsed["alpha_vantage"]) // only today's requests
+	assert.Equal(t, 0, dataMsg.nextResetTime.Hour())
 }
 
 func TestDashboard_FullIntegration(t *testing.T) {
@@ -428,3 +438,15 @@ func TestDashboard_FullIntegration(t *testing.T) {
 	assert.Contains(t, view, "2025-10-08")
 	assert.Contains(t, view, "API Quota")
 }
+
+func TestDashboardRenderQuotaCardUnlimited(t *testing.T) {
+	database := setupTestDB(t)
+	model := NewDashboard(database, 80, 24)
+	model.SetQuotaSources(map[string]int{})
+
+	card := model.renderQuotaCard()
+
+	assert.Contains(t, card, "API Quota")
+	assert.Contains(t, card, "Unlimited")
+	assert.Contains(t, card, "Not rate limited")
+}
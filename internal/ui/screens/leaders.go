@@ -2,6 +2,8 @@ package screens
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/cajundata/momorot/internal/db"
 	"github.com/cajundata/momorot/internal/ui/components"
@@ -18,8 +20,17 @@ type LeadersModel struct {
 
 	// Screen data
 	leaders        []db.Indicator
+	date           string // Date of the stored ranking, empty if none
 	topN           int
 	selectedSymbol string
+	extraColumns   []string // Configured indicators shown after the fixed columns
+	strategy       string   // Scoring strategy whose ranking is shown
+
+	// Provisional ranking from live quotes, shown until the stored ranking is
+	// reloaded; never written to the database
+	provisional bool
+	quoted      map[string]bool
+	asOf        time.Time
 
 	// UI state
 	width  int
@@ -41,6 +52,23 @@ type LeadersTheme struct {
 // NewLeaders creates a new leaders model.
 func NewLeaders(database *db.DB, width, height int) LeadersModel {
 	// Create empty table initially
+	tableModel := components.NewTable(leadersColumns(nil), []table.Row{}, width-4, height-8)
+
+	return LeadersModel{
+		database: database,
+		table:    tableModel,
+		theme:    defaultLeadersTheme(),
+		topN:     10, // Default to top 10
+		strategy: db.DefaultStrategy,
+		width:    width,
+		height:   height,
+		ready:    false,
+	}
+}
+
+// leadersColumns returns the fixed table columns followed by one column per
+// configured indicator.
+func leadersColumns(extra []string) []table.Column {
 	columns := []table.Column{
 		{Title: "Rank", Width: 6},
 		{Title: "Symbol", Width: 10},
@@ -51,18 +79,24 @@ func NewLeaders(database *db.DB, width, height int) LeadersModel {
 		{Title: "Vol", Width: 10},
 		{Title: "ADV", Width: 12},
 	}
+	for _, name := range extra {
+		columns = append(columns, table.Column{Title: strings.ToUpper(name), Width: 10})
+	}
+	return columns
+}
 
-	tableModel := components.NewTable(columns, []table.Row{}, width-4, height-8)
+// SetExtraColumns shows the named configured indicators as extra columns.
+func (m *LeadersModel) SetExtraColumns(names []string) {
+	m.extraColumns = names
+	// Rows must match the columns, so clear them before switching
+	m.table.SetRows([]table.Row{})
+	m.table.SetColumns(leadersColumns(names))
+	m.updateTableRows()
+}
 
-	return LeadersModel{
-		database: database,
-		table:    tableModel,
-		theme:    defaultLeadersTheme(),
-		topN:     10, // Default to top 10
-		width:    width,
-		height:   height,
-		ready:    false,
-	}
+// SetStrategy shows the ranking stored by the named scoring strategy.
+func (m *LeadersModel) SetStrategy(strategy string) {
+	m.strategy = strategy
 }
 
 // defaultLeadersTheme returns the default leaders theme.
@@ -124,8 +158,11 @@ func (m LeadersModel) Update(msg tea.Msg) (LeadersModel, tea.Cmd) {
 
 	case leadersDataMsg:
 		m.leaders = msg.leaders
+		m.date = msg.date
 		m.ready = true
 		m.err = nil
+		m.provisional = false
+		m.quoted = nil
 
 		// Update table with data
 		m.updateTableRows()
@@ -155,6 +192,9 @@ func (m LeadersModel) View() string {
 		return m.theme.EmptyMsg.Render("Loading top performers...")
 	}
 
+	if len(m.leaders) == 0 && m.date != "" {
+		return m.theme.EmptyMsg.Render(fmt.Sprintf("No symbol beat the cash hurdle on %s.\nThe strategy is out of the market.", m.date))
+	}
 	if len(m.leaders) == 0 {
 		return m.theme.EmptyMsg.Render("No ranking data available.\nRun a refresh to compute momentum indicators.")
 	}
@@ -162,12 +202,21 @@ func (m LeadersModel) View() string {
 	// Header
 	title := m.theme.Title.Render("🏆 Top Leaders")
 	subtitle := m.theme.Subtitle.Render(fmt.Sprintf("Showing top %d momentum leaders", len(m.leaders)))
+	if m.provisional {
+		title = m.theme.Title.Render("🏆 Top Leaders - PROVISIONAL")
+		subtitle = m.theme.Subtitle.Render(fmt.Sprintf("%d live quotes as of %s, other symbols at their last close - not saved",
+			len(m.quoted), m.asOf.Format("15:04:05")))
+	}
 
 	// Table
 	tableView := m.table.View()
 
 	// Help text
-	help := m.theme.Neutral.Render("↑/↓: Navigate | Enter: View Details | r: Refresh")
+	liveHelp := "l: Live Snapshot"
+	if m.provisional {
+		liveHelp = "l: Stored Ranking"
+	}
+	help := m.theme.Neutral.Render("↑/↓: Navigate | Enter: View Details | r: Refresh | " + liveHelp)
 
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -187,6 +236,9 @@ func (m *LeadersModel) updateTableRows() {
 	for _, leader := range m.leaders {
 		// Format values
 		rank := fmt.Sprintf("#%d", valueOrZero(leader.Rank))
+		if m.provisional {
+			rank = "~" + rank
+		}
 		symbol := leader.Symbol
 		score := m.formatValue(leader.Score, true)
 		r1m := m.formatPercent(leader.R1M)
@@ -195,7 +247,7 @@ func (m *LeadersModel) updateTableRows() {
 		vol := m.formatPercent(leader.Vol3M)
 		adv := m.formatLargeNumber(leader.ADV)
 
-		rows = append(rows, table.Row{
+		row := table.Row{
 			rank,
 			symbol,
 			score,
@@ -204,12 +256,43 @@ func (m *LeadersModel) updateTableRows() {
 			r6m,
 			vol,
 			adv,
-		})
+		}
+		for _, name := range m.extraColumns {
+			var value *float64
+			if v, ok := leader.Values[name]; ok {
+				value = &v
+			}
+			row = append(row, m.formatPercent(value))
+		}
+
+		rows = append(rows, row)
 	}
 
 	m.table.SetRows(rows)
 }
 
+// SetProvisional shows a provisional ranking computed from live quotes in place
+// of the stored one. quoted marks the symbols priced from a live quote.
+func (m *LeadersModel) SetProvisional(leaders []db.Indicator, quoted map[string]bool, asOf time.Time) {
+	m.leaders = leaders
+	m.provisional = true
+	m.quoted = quoted
+	m.asOf = asOf
+	m.ready = true
+	m.err = nil
+	m.updateTableRows()
+}
+
+// Provisional reports whether a provisional ranking is shown.
+func (m LeadersModel) Provisional() bool {
+	return m.provisional
+}
+
+// TopN returns the number of leaders shown.
+func (m LeadersModel) TopN() int {
+	return m.topN
+}
+
 // formatValue formats a float pointer with color coding.
 func (m LeadersModel) formatValue(val *float64, colorize bool) string {
 	if val == nil {
@@ -280,8 +363,9 @@ func (m LeadersModel) loadLeaders() tea.Msg {
 	query := `
 		SELECT COALESCE(MAX(date), '')
 		FROM indicators
+		WHERE strategy = ?
 	`
-	err := m.database.QueryRow(query).Scan(&latestDate)
+	err := m.database.QueryRow(query, m.strategy).Scan(&latestDate)
 	if err != nil {
 		return leadersErrorMsg{err: fmt.Errorf("failed to find latest indicator date: %w", err)}
 	}
@@ -293,17 +377,18 @@ func (m LeadersModel) loadLeaders() tea.Msg {
 
 	// Get top N indicators for that date
 	indicatorRepo := db.NewIndicatorRepository(m.database)
-	leaders, err := indicatorRepo.GetTopN(latestDate, m.topN)
+	leaders, err := indicatorRepo.GetTopN(latestDate, m.strategy, m.topN)
 	if err != nil {
 		return leadersErrorMsg{err: fmt.Errorf("failed to get top leaders: %w", err)}
 	}
 
-	return leadersDataMsg{leaders: leaders}
+	return leadersDataMsg{leaders: leaders, date: latestDate}
 }
 
 // leadersDataMsg carries loaded leaders data.
 type leadersDataMsg struct {
 	leaders []db.Indicator
+	date    string // Date of the ranking, empty if none is stored
 }
 
 // leadersErrorMsg carries an error from data loading.
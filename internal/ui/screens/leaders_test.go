@@ -2,6 +2,7 @@ package screens
 
 import (
 	"testing"
+	"time"
 
 	"github.com/cajundata/momorot/internal/db"
 	tea "github.com/charmbracelet/bubbletea"
@@ -155,6 +156,54 @@ func TestLeadersViewWithData(t *testing.T) {
 	assert.Contains(t, view, "SPY")
 }
 
+func TestLeadersProvisional(t *testing.T) {
+	database := setupTestDB(t)
+	model := NewLeaders(database, 120, 30)
+
+	score := 1.5
+	rank := 1
+	stored := []db.Indicator{{Symbol: "SPY", Score: &score, Rank: &rank}}
+	model, _ = model.Update(leadersDataMsg{leaders: stored})
+
+	model.SetProvisional([]db.Indicator{{Symbol: "QQQ", Score: &score, Rank: &rank}},
+		map[string]bool{"QQQ": true}, time.Date(2024, 3, 15, 14, 30, 0, 0, time.Local))
+	assert.True(t, model.Provisional())
+
+	view := model.View()
+	assert.Contains(t, view, "PROVISIONAL")
+	assert.Contains(t, view, "not saved")
+	assert.Contains(t, view, "14:30:00")
+	assert.Contains(t, view, "~#1")
+	assert.Equal(t, "QQQ", model.table.SelectedRow()[1])
+
+	// Reloading the stored ranking leaves provisional mode
+	model, _ = model.Update(leadersDataMsg{leaders: stored})
+	assert.False(t, model.Provisional())
+	assert.NotContains(t, model.View(), "PROVISIONAL")
+}
+
+func TestLeadersExtraColumns(t *testing.T) {
+	database := setupTestDB(t)
+	model := NewLeaders(database, 160, 30)
+
+	score := 1.5
+	rank := 1
+	model, _ = model.Update(leadersDataMsg{leaders: []db.Indicator{
+		{Symbol: "SPY", Score: &score, Rank: &rank, Values: map[string]float64{"r12_1": 0.25}},
+	}})
+
+	model.SetExtraColumns([]string{"r12_1", "r9m"})
+
+	row := model.table.SelectedRow()
+	require.Len(t, row, 10)
+	assert.Contains(t, row[8], "25.00%")
+	assert.Contains(t, row[9], "N/A")
+
+	view := model.View()
+	assert.Contains(t, view, "R12_1")
+	assert.Contains(t, view, "R9M")
+}
+
 func TestLeadersFormatValue(t *testing.T) {
 	database := setupTestDB(t)
 	model := NewLeaders(database, 100, 30)
@@ -346,3 +395,24 @@ func setupTestIndicators(t *testing.T, database *db.DB) {
 	err = indicatorRepo.UpsertBatch(indicators)
 	require.NoError(t, err)
 }
+
+func TestLeadersStrategy(t *testing.T) {
+	database := setupTestDB(t)
+	require.NoError(t, db.NewSymbolRepository(database).Create(&db.Symbol{Symbol: "SPY", Name: "SPY", AssetType: "ETF", Active: true}))
+	require.NoError(t, db.NewPriceRepository(database).Create(&db.Price{Symbol: "SPY", Date: "2025-10-03", Open: 1, High: 1, Low: 1, Close: 1}))
+
+	// Momentum ranked SPY; dual momentum held cash the same day
+	rank := 1
+	indicatorRepo := db.NewIndicatorRepository(database)
+	require.NoError(t, indicatorRepo.UpsertBatch([]db.Indicator{{Symbol: "SPY", Date: "2025-10-03", Rank: &rank}}))
+	require.NoError(t, indicatorRepo.UpsertBatch([]db.Indicator{{Symbol: "SPY", Date: "2025-10-03", Strategy: "dual_momentum"}}))
+
+	model := NewLeaders(database, 120, 30)
+	model, _ = model.Update(model.loadLeaders())
+	require.Len(t, model.leaders, 1)
+
+	model.SetStrategy("dual_momentum")
+	model, _ = model.Update(model.loadLeaders())
+	assert.Empty(t, model.leaders)
+	assert.Contains(t, model.View(), "No symbol beat the cash hurdle on 2025-10-03")
+}
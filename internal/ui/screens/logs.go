@@ -16,16 +16,19 @@ type LogsModel struct {
 	database   *db.DB
 	runsTable  components.TableModel
 	logsTable  components.TableModel
+	findingsTable components.TableModel
 	theme      LogsTheme
 
 	// Screen data
 	runs         []db.Run
 	errorLogs    []db.FetchLog
+	findings     []db.QualityFinding
 	selectedRun  int64
 	filterStatus string // "", "OK", "ERROR", "RUNNING"
 
 	// UI state
-	focusedTable int  // 0 = runs, 1 = logs
+	focusedTable int  // 0 = runs, 1 = logs or findings
+	showFindings bool // Lower table shows data quality findings instead of errors
 	width        int
 	height       int
 	ready        bool
@@ -57,6 +60,7 @@ func NewLogs(database *db.DB, width, height int) LogsModel {
 		{Title: "Status", Width: 12},
 		{Title: "Processed", Width: 12},
 		{Title: "Failed", Width: 10},
+		{Title: "Strategy", Width: 14},
 	}
 	runsTable := components.NewTable(runsColumns, []table.Row{}, width-4, runsHeight)
 
@@ -69,10 +73,21 @@ func NewLogs(database *db.DB, width, height int) LogsModel {
 	}
 	logsTable := components.NewTable(logsColumns, []table.Row{}, width-4, logsHeight)
 
+	// Create data quality findings table
+	findingsColumns := []table.Column{
+		{Title: "Symbol", Width: 10},
+		{Title: "Date", Width: 12},
+		{Title: "Check", Width: 13},
+		{Title: "Severity", Width: 10},
+		{Title: "Detail", Width: 50},
+	}
+	findingsTable := components.NewTable(findingsColumns, []table.Row{}, width-4, logsHeight)
+
 	return LogsModel{
-		database:     database,
-		runsTable:    runsTable,
-		logsTable:    logsTable,
+		database:      database,
+		runsTable:     runsTable,
+		logsTable:     logsTable,
+		findingsTable: findingsTable,
 		theme:        defaultLogsTheme(),
 		focusedTable: 0,
 		width:        width,
@@ -136,6 +151,8 @@ func (m LogsModel) Update(msg tea.Msg) (LogsModel, tea.Cmd) {
 		m.runsTable.SetHeight(runsHeight)
 		m.logsTable.SetWidth(msg.Width - 4)
 		m.logsTable.SetHeight(logsHeight)
+		m.findingsTable.SetWidth(msg.Width - 4)
+		m.findingsTable.SetHeight(logsHeight)
 		return m, nil
 
 	case tea.KeyMsg:
@@ -143,13 +160,13 @@ func (m LogsModel) Update(msg tea.Msg) (LogsModel, tea.Cmd) {
 		case "tab":
 			// Switch focus between tables
 			m.focusedTable = (m.focusedTable + 1) % 2
-			if m.focusedTable == 0 {
-				m.runsTable.Focus()
-				m.logsTable.Blur()
-			} else {
-				m.runsTable.Blur()
-				m.logsTable.Focus()
-			}
+			m.updateFocus()
+			return m, nil
+
+		case "d":
+			// Switch the lower table between errors and data quality findings
+			m.showFindings = !m.showFindings
+			m.updateFocus()
 			return m, nil
 
 		case "f":
@@ -170,6 +187,7 @@ func (m LogsModel) Update(msg tea.Msg) (LogsModel, tea.Cmd) {
 	case logsDataMsg:
 		m.runs = msg.runs
 		m.errorLogs = msg.errorLogs
+		m.findings = msg.findings
 		m.ready = true
 		m.err = nil
 		// Set selectedRun to the first run if available
@@ -178,11 +196,14 @@ func (m LogsModel) Update(msg tea.Msg) (LogsModel, tea.Cmd) {
 		}
 		m.updateRunsTable()
 		m.updateLogsTable()
+		m.updateFindingsTable()
 		return m, nil
 
 	case errorLogsMsg:
 		m.errorLogs = msg.logs
+		m.findings = msg.findings
 		m.updateLogsTable()
+		m.updateFindingsTable()
 		return m, nil
 
 	case logsErrorMsg:
@@ -192,15 +213,34 @@ func (m LogsModel) Update(msg tea.Msg) (LogsModel, tea.Cmd) {
 	}
 
 	// Pass through to focused table
-	if m.focusedTable == 0 {
+	switch {
+	case m.focusedTable == 0:
 		m.runsTable, cmd = m.runsTable.Update(msg)
-	} else {
+	case m.showFindings:
+		m.findingsTable, cmd = m.findingsTable.Update(msg)
+	default:
 		m.logsTable, cmd = m.logsTable.Update(msg)
 	}
 
 	return m, cmd
 }
 
+// updateFocus focuses the runs table or whichever lower table is shown.
+func (m *LogsModel) updateFocus() {
+	m.runsTable.Blur()
+	m.logsTable.Blur()
+	m.findingsTable.Blur()
+
+	switch {
+	case m.focusedTable == 0:
+		m.runsTable.Focus()
+	case m.showFindings:
+		m.findingsTable.Focus()
+	default:
+		m.logsTable.Focus()
+	}
+}
+
 // View renders the logs screen.
 func (m LogsModel) View() string {
 	if m.err != nil {
@@ -237,9 +277,16 @@ func (m LogsModel) View() string {
 		m.selectedRun,
 	))
 	logsView := m.logsTable.View()
+	if m.showFindings {
+		logsTitle = m.theme.SectionTitle.Render(fmt.Sprintf(
+			"Data Quality Findings (Run #%d)",
+			m.selectedRun,
+		))
+		logsView = m.findingsTable.View()
+	}
 
 	// Help text
-	help := m.theme.Help.Render("Tab: Switch Tables | ↑/↓: Navigate | Enter: Load Errors | f: Filter Status")
+	help := m.theme.Help.Render("Tab: Switch Tables | ↑/↓: Navigate | Enter: Load Errors | d: Errors/Data Quality | f: Filter Status")
 
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -277,12 +324,19 @@ func (m *LogsModel) updateRunsTable() {
 		// Format started time
 		started := run.StartedAt.Format("2006-01-02 15:04:05")
 
+		// Runs that computed no rankings have no strategy
+		strategy := "-"
+		if run.Strategy != nil {
+			strategy = *run.Strategy
+		}
+
 		rows = append(rows, table.Row{
 			fmt.Sprintf("#%d", run.RunID),
 			started,
 			status,
 			fmt.Sprintf("%d", run.SymbolsProcessed),
 			fmt.Sprintf("%d", run.SymbolsFailed),
+			strategy,
 		})
 	}
 
@@ -328,6 +382,33 @@ func (m *LogsModel) updateLogsTable() {
 	m.logsTable.SetRows(rows)
 }
 
+// updateFindingsTable updates the data quality findings table with data.
+func (m *LogsModel) updateFindingsTable() {
+	rows := make([]table.Row, 0, len(m.findings))
+
+	for _, f := range m.findings {
+		severity := m.theme.StatusRunning.Render(f.Severity)
+		if f.Severity == "ERROR" {
+			severity = m.theme.StatusError.Render(f.Severity)
+		}
+
+		detail := f.Detail
+		if len(detail) > 47 {
+			detail = detail[:44] + "..."
+		}
+
+		rows = append(rows, table.Row{
+			f.Symbol,
+			f.Date,
+			f.Check,
+			severity,
+			detail,
+		})
+	}
+
+	m.findingsTable.SetRows(rows)
+}
+
 // cycleFilter cycles through status filters.
 func (m LogsModel) cycleFilter() tea.Cmd {
 	// Cycle: "" -> "OK" -> "ERROR" -> "RUNNING" -> ""
@@ -349,7 +430,7 @@ func (m LogsModel) cycleFilter() tea.Cmd {
 func (m LogsModel) loadLogs() tea.Msg {
 	// Build query based on filter
 	query := `
-		SELECT run_id, started_at, finished_at, status, symbols_processed, symbols_failed, notes
+		SELECT run_id, started_at, finished_at, status, symbols_processed, symbols_failed, notes, strategy
 		FROM runs
 	`
 
@@ -381,6 +462,7 @@ func (m LogsModel) loadLogs() tea.Msg {
 			&run.SymbolsProcessed,
 			&run.SymbolsFailed,
 			&notes,
+			&run.Strategy,
 		)
 		if err != nil {
 			return logsErrorMsg{err: fmt.Errorf("failed to scan run: %w", err)}
@@ -403,21 +485,33 @@ func (m LogsModel) loadLogs() tea.Msg {
 
 	// Load error logs for the first run (if any)
 	var errorLogs []db.FetchLog
+	var findings []db.QualityFinding
 	if len(runs) > 0 {
 		m.selectedRun = runs[0].RunID
 		errorLogs = m.loadErrorLogsForRun(runs[0].RunID)
+		findings = m.loadFindingsForRun(runs[0].RunID)
 	}
 
 	return logsDataMsg{
 		runs:      runs,
 		errorLogs: errorLogs,
+		findings:  findings,
 	}
 }
 
-// loadErrorLogs loads error logs for the selected run.
+// loadErrorLogs loads error logs and data quality findings for the selected run.
 func (m LogsModel) loadErrorLogs() tea.Msg {
 	logs := m.loadErrorLogsForRun(m.selectedRun)
-	return errorLogsMsg{logs: logs}
+	return errorLogsMsg{logs: logs, findings: m.loadFindingsForRun(m.selectedRun)}
+}
+
+// loadFindingsForRun loads the data quality findings recorded by a run.
+func (m LogsModel) loadFindingsForRun(runID int64) []db.QualityFinding {
+	findings, err := db.NewQualityFindingRepository(m.database).ListForRun(runID)
+	if err != nil {
+		return []db.QualityFinding{}
+	}
+	return findings
 }
 
 // loadErrorLogsForRun loads error logs for a specific run ID.
@@ -468,11 +562,13 @@ func (m LogsModel) loadErrorLogsForRun(runID int64) []db.FetchLog {
 type logsDataMsg struct {
 	runs      []db.Run
 	errorLogs []db.FetchLog
+	findings  []db.QualityFinding
 }
 
-// errorLogsMsg carries error logs for a specific run.
+// errorLogsMsg carries error logs and data quality findings for a specific run.
 type errorLogsMsg struct {
-	logs []db.FetchLog
+	logs     []db.FetchLog
+	findings []db.QualityFinding
 }
 
 // logsErrorMsg carries an error from data loading.
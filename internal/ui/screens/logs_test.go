@@ -335,6 +335,7 @@ func TestLogsLoadData_WithRuns(t *testing.T) {
 	require.NoError(t, err)
 	err = runRepo.Finish(runID1, "OK", 10, 0)
 	require.NoError(t, err)
+	require.NoError(t, runRepo.SetStrategy(runID1, "dual_momentum"))
 
 	runID2, err := runRepo.Create("test run 2")
 	require.NoError(t, err)
@@ -349,6 +350,9 @@ func TestLogsLoadData_WithRuns(t *testing.T) {
 	require.True(t, ok, "expected logsDataMsg, got %T", msg)
 	assert.Equal(t, 2, len(dataMsg.runs))
 	assert.Equal(t, runID2, dataMsg.runs[0].RunID) // Newer run first
+	assert.Nil(t, dataMsg.runs[0].Strategy)
+	require.NotNil(t, dataMsg.runs[1].Strategy)
+	assert.Equal(t, "dual_momentum", *dataMsg.runs[1].Strategy)
 }
 
 func TestLogsLoadData_WithFilter(t *testing.T) {
@@ -523,3 +527,29 @@ func TestLogsEnterKeyLoadsErrorLogs(t *testing.T) {
 	assert.Equal(t, 0, len(model.errorLogs))
 	assert.Equal(t, runID1, model.selectedRun)
 }
+
+func TestLogsToggleDataQuality(t *testing.T) {
+	database := setupTestDB(t)
+	require.NoError(t, db.NewSymbolRepository(database).Create(&db.Symbol{Symbol: "SPY", Name: "SPY", AssetType: "ETF", Active: true}))
+
+	runID, err := db.NewRunRepository(database).Create("refresh")
+	require.NoError(t, err)
+	require.NoError(t, db.NewQualityFindingRepository(database).ReplaceForSymbol("SPY", []db.QualityFinding{
+		{Date: "2024-01-10", Check: "missing_days", Severity: "WARN", Detail: "1 day missing (2024-01-10)", RunID: &runID},
+	}))
+
+	model := NewLogs(database, 120, 40)
+	model, _ = model.Update(model.loadLogs())
+	require.Len(t, model.findings, 1)
+
+	view := model.View()
+	assert.Contains(t, view, "Error Logs")
+	assert.NotContains(t, view, "missing_days")
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	assert.True(t, model.showFindings)
+
+	view = model.View()
+	assert.Contains(t, view, "Data Quality Findings")
+	assert.Contains(t, view, "missing_days")
+}
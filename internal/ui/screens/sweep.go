@@ -0,0 +1,218 @@
+package screens
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cajundata/momorot/internal/backtest"
+	"github.com/cajundata/momorot/internal/ui/components"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SweepModel represents the parameter sweep screen state. The sweep itself is
+// run by the application model; this screen shows its comparison table.
+type SweepModel struct {
+	table components.TableModel
+	theme SweepTheme
+
+	// Screen data
+	results      []backtest.SweepResult // Best CAGR first
+	combinations int                    // Combinations in the configured grid
+	finished     time.Time
+	elapsed      time.Duration
+
+	// UI state
+	width   int
+	height  int
+	running bool
+	err     error
+}
+
+// SweepTheme contains styling for the sweep screen.
+type SweepTheme struct {
+	Title    lipgloss.Style
+	Subtitle lipgloss.Style
+	Positive lipgloss.Style
+	Negative lipgloss.Style
+	Neutral  lipgloss.Style
+	EmptyMsg lipgloss.Style
+}
+
+// NewSweep creates a new sweep model.
+func NewSweep(width, height int) SweepModel {
+	columns := []table.Column{
+		{Title: "#", Width: 4},
+		{Title: "Lambda", Width: 8},
+		{Title: "Weights", Width: 28},
+		{Title: "Top", Width: 5},
+		{Title: "Return", Width: 10},
+		{Title: "CAGR", Width: 9},
+		{Title: "Max DD", Width: 9},
+		{Title: "Turnover", Width: 9},
+		{Title: "Hit Rate", Width: 9},
+	}
+
+	return SweepModel{
+		table:  components.NewTable(columns, []table.Row{}, width-4, height-8),
+		theme:  defaultSweepTheme(),
+		width:  width,
+		height: height,
+	}
+}
+
+// defaultSweepTheme returns the default sweep theme.
+func defaultSweepTheme() SweepTheme {
+	return SweepTheme{
+		Title: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("12")).
+			MarginBottom(1),
+		Subtitle: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("8")).
+			Italic(true).
+			MarginBottom(1),
+		Positive: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("10")),
+		Negative: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("9")),
+		Neutral: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("8")),
+		EmptyMsg: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("8")).
+			Italic(true).
+			Padding(2, 4),
+	}
+}
+
+// Init initializes the sweep screen. Nothing is loaded until a sweep is run.
+func (m SweepModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the sweep screen.
+func (m SweepModel) Update(msg tea.Msg) (SweepModel, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.table.SetWidth(msg.Width - 4)
+		m.table.SetHeight(msg.Height - 8)
+		return m, nil
+	}
+
+	// Pass through to table for navigation
+	m.table, cmd = m.table.Update(msg)
+	return m, cmd
+}
+
+// View renders the sweep screen.
+func (m SweepModel) View() string {
+	title := m.theme.Title.Render("🔬 Parameter Sweep")
+	help := m.theme.Neutral.Render("↑/↓: Navigate | s: Run Sweep")
+
+	var body string
+	switch {
+	case m.running:
+		body = m.theme.EmptyMsg.Render(fmt.Sprintf("Backtesting %d combinations...", m.combinations))
+	case m.err != nil:
+		body = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("9")).
+			Padding(1, 2).
+			Render(fmt.Sprintf("Sweep failed: %v", m.err))
+	case len(m.results) == 0:
+		body = m.theme.EmptyMsg.Render(fmt.Sprintf(
+			"No sweep results yet.\nPress s to backtest the %d combinations in the sweep section of the config.", m.combinations))
+	default:
+		subtitle := m.theme.Subtitle.Render(fmt.Sprintf("%d combinations ranked by CAGR - run at %s in %v",
+			len(m.results), m.finished.Format("15:04:05"), m.elapsed.Round(time.Millisecond)))
+		body = lipgloss.JoinVertical(lipgloss.Left, subtitle, "", m.table.View())
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		body,
+		"",
+		help,
+	)
+}
+
+// SetCombinations sets the number of combinations in the configured grid.
+func (m *SweepModel) SetCombinations(n int) {
+	m.combinations = n
+}
+
+// SetRunning marks a sweep as started.
+func (m *SweepModel) SetRunning() {
+	m.running = true
+	m.err = nil
+}
+
+// Running reports whether a sweep is in progress.
+func (m SweepModel) Running() bool {
+	return m.running
+}
+
+// SetResults shows the results of a finished sweep, best CAGR first.
+func (m *SweepModel) SetResults(results []backtest.SweepResult, elapsed time.Duration) {
+	m.results = results
+	m.finished = time.Now()
+	m.elapsed = elapsed
+	m.running = false
+	m.err = nil
+	m.updateTableRows()
+}
+
+// SetError shows why a sweep could not run.
+func (m *SweepModel) SetError(err error) {
+	m.running = false
+	m.err = err
+}
+
+// updateTableRows updates the table with the sweep results.
+func (m *SweepModel) updateTableRows() {
+	rows := make([]table.Row, 0, len(m.results))
+
+	for i, r := range m.results {
+		row := table.Row{
+			fmt.Sprintf("%d", i+1),
+			fmt.Sprintf("%g", r.Params.Lambda),
+			r.Params.WeightsLabel(),
+			fmt.Sprintf("%d", r.Params.TopN),
+		}
+		if r.Err != nil {
+			row = append(row, m.theme.Negative.Render("failed"), "", "", "", "")
+		} else {
+			row = append(row,
+				m.formatPercent(r.Result.TotalReturn, true),
+				m.formatPercent(r.Result.CAGR, true),
+				m.formatPercent(r.Result.MaxDrawdown, false),
+				m.formatPercent(r.Result.Turnover, false),
+				m.formatPercent(r.Result.HitRate, false),
+			)
+		}
+		rows = append(rows, row)
+	}
+
+	m.table.SetRows(rows)
+}
+
+// formatPercent formats a fraction as a percentage, colored by sign if colorize.
+func (m SweepModel) formatPercent(val float64, colorize bool) string {
+	formatted := fmt.Sprintf("%.2f%%", val*100)
+
+	if !colorize {
+		return formatted
+	}
+
+	if val > 0 {
+		return m.theme.Positive.Render(formatted)
+	} else if val < 0 {
+		return m.theme.Negative.Render(formatted)
+	}
+	return formatted
+}
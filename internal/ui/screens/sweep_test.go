@@ -0,0 +1,63 @@
+package screens
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cajundata/momorot/internal/backtest"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSweep(t *testing.T) {
+	model := NewSweep(100, 30)
+
+	assert.Equal(t, 100, model.width)
+	assert.Equal(t, 30, model.height)
+	assert.False(t, model.Running())
+	assert.Nil(t, model.Init())
+}
+
+func TestSweepUpdateWindowSize(t *testing.T) {
+	model := NewSweep(100, 30)
+
+	updated, cmd := model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	assert.Nil(t, cmd)
+	assert.Equal(t, 120, updated.width)
+	assert.Equal(t, 40, updated.height)
+}
+
+func TestSweepView(t *testing.T) {
+	model := NewSweep(120, 30)
+	model.SetCombinations(18)
+	assert.Contains(t, model.View(), "backtest the 18 combinations")
+
+	model.SetRunning()
+	assert.True(t, model.Running())
+	assert.Contains(t, model.View(), "Backtesting 18 combinations")
+
+	model.SetError(fmt.Errorf("no active symbols found"))
+	assert.False(t, model.Running())
+	assert.Contains(t, model.View(), "Sweep failed: no active symbols found")
+
+	model.SetResults([]backtest.SweepResult{
+		{
+			Params: backtest.Params{Lambda: 0.35, Weights: map[string]float64{"r12m": 2}, TopN: 3},
+			Result: &backtest.Result{TotalReturn: 0.5, CAGR: 0.12, MaxDrawdown: 0.2, Turnover: 0.3, HitRate: 0.55},
+		},
+		{Params: backtest.Params{Lambda: 0.7, TopN: 5}, Err: fmt.Errorf("no data")},
+	}, 1500*time.Millisecond)
+
+	view := model.View()
+	assert.Contains(t, view, "2 combinations ranked by CAGR")
+	assert.Contains(t, view, "r12m=2")
+	assert.Contains(t, view, "12.00%")
+	assert.Contains(t, view, "failed")
+
+	rows := model.table.SelectedRow()
+	require.Len(t, rows, 9)
+	assert.Equal(t, "1", rows[0])
+}
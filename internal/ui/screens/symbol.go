@@ -2,6 +2,7 @@ package screens
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/cajundata/momorot/internal/db"
 	"github.com/cajundata/momorot/internal/ui/components"
@@ -20,7 +21,10 @@ type SymbolModel struct {
 	symbolInfo *db.Symbol
 	prices     []db.Price
 	indicators *db.Indicator
+	actions    []db.CorporateAction
+	findings   []db.QualityFinding
 	rank       int
+	strategy   string // Scoring strategy whose indicators are shown
 
 	// UI state
 	width  int
@@ -53,12 +57,19 @@ func NewSymbol(database *db.DB, symbol string, width, height int) SymbolModel {
 		sparkline: sparkline,
 		theme:     defaultSymbolTheme(),
 		symbol:    symbol,
+		strategy:  db.DefaultStrategy,
 		width:     width,
 		height:    height,
 		ready:     false,
 	}
 }
 
+// SetStrategy shows the indicators and rank stored by the named scoring
+// strategy.
+func (m *SymbolModel) SetStrategy(strategy string) {
+	m.strategy = strategy
+}
+
 // defaultSymbolTheme returns the default symbol theme.
 func defaultSymbolTheme() SymbolTheme {
 	return SymbolTheme{
@@ -117,6 +128,8 @@ func (m SymbolModel) Update(msg tea.Msg) (SymbolModel, tea.Cmd) {
 		m.symbolInfo = msg.symbolInfo
 		m.prices = msg.prices
 		m.indicators = msg.indicators
+		m.actions = msg.actions
+		m.findings = msg.findings
 		m.rank = msg.rank
 		m.ready = true
 		m.err = nil
@@ -165,7 +178,7 @@ func (m SymbolModel) View() string {
 	// Header
 	title := m.theme.Title.Render(fmt.Sprintf("📈 %s - %s", m.symbol, m.symbolInfo.Name))
 	subtitle := m.theme.Subtitle.Render(fmt.Sprintf("%s | Rank: %s",
-		m.symbolInfo.AssetType,
+		m.formatProfile(),
 		m.formatRank()))
 
 	// Price chart section
@@ -177,6 +190,12 @@ func (m SymbolModel) View() string {
 	// Volatility section
 	volSection := m.renderVolatilitySection()
 
+	// Corporate actions section
+	actionsSection := m.renderActionsSection()
+
+	// Data quality section
+	qualitySection := m.renderQualitySection()
+
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
 		title,
@@ -187,9 +206,25 @@ func (m SymbolModel) View() string {
 		metricsSection,
 		"",
 		volSection,
+		"",
+		actionsSection,
+		"",
+		qualitySection,
 	)
 }
 
+// formatProfile joins the asset type with whatever exchange, currency and
+// sector metadata is known, e.g. "STOCK | NASDAQ | USD | TECHNOLOGY".
+func (m SymbolModel) formatProfile() string {
+	parts := []string{m.symbolInfo.AssetType}
+	for _, field := range []string{m.symbolInfo.Exchange, m.symbolInfo.Currency, m.symbolInfo.Sector} {
+		if field != "" {
+			parts = append(parts, field)
+		}
+	}
+	return strings.Join(parts, " | ")
+}
+
 // renderChartSection renders the price chart section.
 func (m SymbolModel) renderChartSection() string {
 	if len(m.prices) == 0 {
@@ -275,13 +310,136 @@ func (m SymbolModel) renderVolatilitySection() string {
 
 	cardsRow := lipgloss.JoinHorizontal(lipgloss.Top, cards...)
 
+	// Risk metrics, when they have been computed
+	if !m.hasRiskMetrics() {
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			sectionTitle,
+			cardsRow,
+		)
+	}
+
+	riskCards := []string{
+		m.renderVolCard("Max Drawdown", m.indicatorValue("max_drawdown")),
+		m.renderVolCard("Downside Dev", m.indicatorValue("downside_dev")),
+		m.renderVolCard("Ulcer Index", m.indicatorValue("ulcer")),
+		m.renderRatioCard("Sharpe", m.indicatorValue("sharpe")),
+		m.renderRatioCard("Sortino", m.indicatorValue("sortino")),
+		m.renderRatioCard("Beta", m.indicatorValue("beta")),
+	}
+
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
 		sectionTitle,
 		cardsRow,
+		lipgloss.JoinHorizontal(lipgloss.Top, riskCards...),
 	)
 }
 
+// riskMetricNames are the risk metrics stored with a symbol's indicators.
+var riskMetricNames = []string{"max_drawdown", "downside_dev", "ulcer", "sharpe", "sortino", "beta"}
+
+// hasRiskMetrics reports whether any risk metric is stored for the symbol.
+func (m SymbolModel) hasRiskMetrics() bool {
+	for _, name := range riskMetricNames {
+		if m.indicatorValue(name) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// indicatorValue returns a named indicator value, or nil if it is not stored.
+func (m SymbolModel) indicatorValue(name string) *float64 {
+	if m.indicators == nil {
+		return nil
+	}
+	value, ok := m.indicators.Values[name]
+	if !ok {
+		return nil
+	}
+	return &value
+}
+
+// maxActionsShown limits the corporate actions listed on the detail screen.
+const maxActionsShown = 5
+
+// renderActionsSection renders the most recent dividends and splits.
+func (m SymbolModel) renderActionsSection() string {
+	sectionTitle := m.theme.SectionTitle.Render("💰 Corporate Actions")
+
+	if len(m.actions) == 0 {
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			sectionTitle,
+			m.theme.EmptyMsg.Render("No corporate actions recorded"),
+		)
+	}
+
+	lines := []string{sectionTitle}
+	// Actions are stored oldest first; show the newest at the top
+	for i := len(m.actions) - 1; i >= 0 && len(lines) <= maxActionsShown; i-- {
+		lines = append(lines, m.formatAction(m.actions[i]))
+	}
+	if hidden := len(m.actions) - maxActionsShown; hidden > 0 {
+		lines = append(lines, m.theme.Label.Render(fmt.Sprintf("... and %d earlier", hidden)))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// formatAction formats a corporate action as "date  Dividend $x.xx  Split n:1".
+func (m SymbolModel) formatAction(action db.CorporateAction) string {
+	text := m.theme.Label.Render(action.Date)
+	if action.Dividend != 0 {
+		text += "  " + m.theme.Value.Render(fmt.Sprintf("Dividend $%.4g", action.Dividend))
+	}
+	if action.SplitCoefficient != 0 && action.SplitCoefficient != 1 {
+		text += "  " + m.theme.Value.Render("Split "+formatSplitRatio(action.SplitCoefficient))
+	}
+	return text
+}
+
+// formatSplitRatio formats a split coefficient as a ratio, e.g. 4 -> "4:1" and 0.5 -> "1:2".
+func formatSplitRatio(coefficient float64) string {
+	if coefficient < 1 {
+		return fmt.Sprintf("1:%g", 1/coefficient)
+	}
+	return fmt.Sprintf("%g:1", coefficient)
+}
+
+// maxFindingsShown limits the data quality findings listed on the detail screen.
+const maxFindingsShown = 5
+
+// renderQualitySection renders the most recent data quality findings.
+func (m SymbolModel) renderQualitySection() string {
+	sectionTitle := m.theme.SectionTitle.Render("🩺 Data Quality")
+
+	if len(m.findings) == 0 {
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			sectionTitle,
+			m.theme.EmptyMsg.Render("No data quality issues found"),
+		)
+	}
+
+	lines := []string{sectionTitle}
+	// Findings are loaded newest first
+	for i := 0; i < len(m.findings) && i < maxFindingsShown; i++ {
+		f := m.findings[i]
+		severity := m.theme.Neutral.Render(f.Severity)
+		if f.Severity == "ERROR" {
+			severity = m.theme.Negative.Render(f.Severity)
+		}
+		lines = append(lines, fmt.Sprintf("%s  %s  %s", m.theme.Label.Render(f.Date), severity, f.Detail))
+	}
+	if hidden := len(m.findings) - maxFindingsShown; hidden > 0 {
+		lines = append(lines, m.theme.Label.Render(fmt.Sprintf("... and %d earlier", hidden)))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
 // renderMetricCard renders a return metric card.
 func (m SymbolModel) renderMetricCard(label string, value *float64) string {
 	if value == nil {
@@ -333,6 +491,26 @@ func (m SymbolModel) renderVolCard(label string, value *float64) string {
 	return m.theme.Card.Render(content)
 }
 
+// renderRatioCard renders a risk-adjusted ratio or beta card.
+func (m SymbolModel) renderRatioCard(label string, value *float64) string {
+	if value == nil {
+		content := lipgloss.JoinVertical(
+			lipgloss.Left,
+			m.theme.Label.Render(label),
+			m.theme.Neutral.Render("N/A"),
+		)
+		return m.theme.Card.Render(content)
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		m.theme.Label.Render(label),
+		m.theme.Value.Render(fmt.Sprintf("%.2f", *value)),
+	)
+
+	return m.theme.Card.Render(content)
+}
+
 // renderADVCard renders the average daily volume card.
 func (m SymbolModel) renderADVCard(label string, value *float64) string {
 	if value == nil {
@@ -477,22 +655,38 @@ func (m SymbolModel) loadSymbolData() tea.Msg {
 	var indicators *db.Indicator
 	if latestDate != "" {
 		query := `
-			SELECT symbol, date, r_1m, r_3m, r_6m, r_12m, vol_3m, vol_6m, adv, score, rank, created_at
+			SELECT symbol, date, r_1m, r_3m, r_6m, r_12m, vol_3m, vol_6m, adv, score, rank, strategy, created_at
 			FROM indicators
-			WHERE symbol = ? AND date = ?
+			WHERE symbol = ? AND date = ? AND strategy = ?
 		`
 		var ind db.Indicator
 		var createdAt string
-		err := m.database.QueryRow(query, m.symbol, latestDate).Scan(
+		err := m.database.QueryRow(query, m.symbol, latestDate, m.strategy).Scan(
 			&ind.Symbol, &ind.Date, &ind.R1M, &ind.R3M, &ind.R6M, &ind.R12M,
-			&ind.Vol3M, &ind.Vol6M, &ind.ADV, &ind.Score, &ind.Rank, &createdAt,
+			&ind.Vol3M, &ind.Vol6M, &ind.ADV, &ind.Score, &ind.Rank, &ind.Strategy, &createdAt,
 		)
 		if err == nil {
-			indicators = &ind
+			loaded := []db.Indicator{ind}
+			if err := db.NewIndicatorRepository(m.database).LoadValues(loaded); err != nil {
+				return symbolErrorMsg{err: fmt.Errorf("failed to get indicator values: %w", err)}
+			}
+			indicators = &loaded[0]
 		}
 		// If no indicators, that's okay - just means they haven't been computed yet
 	}
 
+	// Get corporate actions
+	actions, err := db.NewCorporateActionRepository(m.database).ListForSymbol(m.symbol)
+	if err != nil {
+		return symbolErrorMsg{err: fmt.Errorf("failed to get corporate actions: %w", err)}
+	}
+
+	// Get data quality findings
+	findings, err := db.NewQualityFindingRepository(m.database).ListForSymbol(m.symbol)
+	if err != nil {
+		return symbolErrorMsg{err: fmt.Errorf("failed to get data quality findings: %w", err)}
+	}
+
 	// Get rank from indicators
 	rank := 0
 	if indicators != nil && indicators.Rank != nil {
@@ -503,6 +697,8 @@ func (m SymbolModel) loadSymbolData() tea.Msg {
 		symbolInfo: symbolInfo,
 		prices:     prices,
 		indicators: indicators,
+		actions:    actions,
+		findings:   findings,
 		rank:       rank,
 	}
 }
@@ -512,6 +708,8 @@ type symbolDataMsg struct {
 	symbolInfo *db.Symbol
 	prices     []db.Price
 	indicators *db.Indicator
+	actions    []db.CorporateAction
+	findings   []db.QualityFinding
 	rank       int
 }
 
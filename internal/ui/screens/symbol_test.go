@@ -2,6 +2,7 @@ package screens
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/cajundata/momorot/internal/db"
@@ -189,6 +190,33 @@ func TestSymbolViewWithData(t *testing.T) {
 	assert.Contains(t, view, "#1")
 }
 
+func TestSymbolView_CorporateActions(t *testing.T) {
+	database := setupTestDB(t)
+	model := NewSymbol(database, "AAPL", 100, 30)
+	model.ready = true
+	model.symbolInfo = &db.Symbol{Symbol: "AAPL", Name: "Apple", AssetType: "STOCK", Active: true}
+
+	view := model.View()
+	assert.Contains(t, view, "No corporate actions recorded")
+
+	model.actions = []db.CorporateAction{
+		{Symbol: "AAPL", Date: "2020-08-31", Dividend: 0, SplitCoefficient: 4},
+		{Symbol: "AAPL", Date: "2020-11-06", Dividend: 0.205, SplitCoefficient: 1},
+	}
+
+	view = model.View()
+	assert.Contains(t, view, "Corporate Actions")
+	assert.Contains(t, view, "Split 4:1")
+	assert.Contains(t, view, "Dividend $0.205")
+	assert.Less(t, strings.Index(view, "2020-11-06"), strings.Index(view, "2020-08-31"), "newest action first")
+}
+
+func TestFormatSplitRatio(t *testing.T) {
+	assert.Equal(t, "4:1", formatSplitRatio(4))
+	assert.Equal(t, "1.5:1", formatSplitRatio(1.5))
+	assert.Equal(t, "1:2", formatSplitRatio(0.5))
+}
+
 func TestSymbolFormatRank(t *testing.T) {
 	database := setupTestDB(t)
 	model := NewSymbol(database, "SPY", 100, 30)
@@ -279,6 +307,7 @@ func TestSymbolLoadData_WithFullData(t *testing.T) {
 	assert.Equal(t, "SPY", dataMsg.symbolInfo.Symbol)
 	assert.NotEmpty(t, dataMsg.prices)
 	assert.NotNil(t, dataMsg.indicators)
+	assert.Equal(t, map[string]float64{"max_drawdown": 0.08, "sharpe": 1.25}, dataMsg.indicators.Values)
 	assert.Equal(t, 1, dataMsg.rank)
 }
 
@@ -369,6 +398,25 @@ func TestSymbolRenderVolCard(t *testing.T) {
 	assert.Contains(t, card, "N/A")
 }
 
+func TestSymbolView_RiskMetrics(t *testing.T) {
+	database := setupTestDB(t)
+	model := NewSymbol(database, "SPY", 200, 30)
+	model.ready = true
+	model.symbolInfo = &db.Symbol{Symbol: "SPY", Name: "SPDR S&P 500", AssetType: "ETF", Active: true}
+
+	vol6m := 0.15
+	model.indicators = &db.Indicator{Symbol: "SPY", Date: "2025-10-08", Vol6M: &vol6m}
+	assert.NotContains(t, model.View(), "Max Drawdown")
+
+	model.indicators.Values = map[string]float64{"max_drawdown": 0.125, "sharpe": 1.5, "beta": 0.95}
+	view := model.View()
+	assert.Contains(t, view, "Max Drawdown")
+	assert.Contains(t, view, "12.50%")
+	assert.Contains(t, view, "1.50")
+	assert.Contains(t, view, "0.95")
+	assert.Contains(t, view, "Sortino")
+}
+
 func TestSymbolRenderADVCard(t *testing.T) {
 	database := setupTestDB(t)
 	model := NewSymbol(database, "SPY", 100, 30)
@@ -456,6 +504,7 @@ func setupFullSymbolData(t *testing.T, database *db.DB, symbol string) {
 			ADV:    &adv,
 			Score:  &score,
 			Rank:   &rank,
+			Values: map[string]float64{"max_drawdown": 0.08, "sharpe": 1.25},
 		},
 	}
 
@@ -463,3 +512,40 @@ func setupFullSymbolData(t *testing.T, database *db.DB, symbol string) {
 	err = indicatorRepo.UpsertBatch(indicators)
 	require.NoError(t, err)
 }
+
+func TestSymbolView_Metadata(t *testing.T) {
+	database := setupTestDB(t)
+	model := NewSymbol(database, "AAPL", 100, 30)
+	model.ready = true
+	model.symbolInfo = &db.Symbol{Symbol: "AAPL", Name: "Apple Inc", AssetType: "STOCK", Active: true}
+
+	view := model.View()
+	assert.Contains(t, view, "STOCK | Rank:")
+
+	model.symbolInfo.Exchange = "NASDAQ"
+	model.symbolInfo.Sector = "TECHNOLOGY"
+	model.symbolInfo.Currency = "USD"
+
+	view = model.View()
+	assert.Contains(t, view, "STOCK | NASDAQ | USD | TECHNOLOGY | Rank:")
+}
+
+func TestSymbolView_DataQuality(t *testing.T) {
+	database := setupTestDB(t)
+	model := NewSymbol(database, "AAPL", 100, 30)
+	model.ready = true
+	model.symbolInfo = &db.Symbol{Symbol: "AAPL", Name: "Apple", AssetType: "STOCK", Active: true}
+
+	view := model.View()
+	assert.Contains(t, view, "No data quality issues found")
+
+	model.findings = []db.QualityFinding{
+		{Symbol: "AAPL", Date: "2024-03-04", Check: "split_jump", Severity: "ERROR", Detail: "close moved -75.0% from 400.00 to 100.00, looks like an unadjusted 4:1 split"},
+		{Symbol: "AAPL", Date: "2024-01-10", Check: "missing_days", Severity: "WARN", Detail: "1 day missing (2024-01-10)"},
+	}
+
+	view = model.View()
+	assert.Contains(t, view, "Data Quality")
+	assert.Contains(t, view, "unadjusted 4:1 split")
+	assert.Contains(t, view, "1 day missing")
+}
@@ -35,6 +35,9 @@ type SymbolWithFetch struct {
 	Symbol       string
 	Name         string
 	AssetType    string
+	Exchange     string
+	Sector       string
+	Currency     string
 	Active       bool
 	LastFetchDate string
 }
@@ -54,11 +57,14 @@ type UniverseTheme struct {
 func NewUniverse(database *db.DB, width, height int) UniverseModel {
 	// Create table
 	columns := []table.Column{
-		{Title: "Symbol", Width: 12},
-		{Title: "Name", Width: 30},
-		{Title: "Type", Width: 10},
+		{Title: "Symbol", Width: 8},
+		{Title: "Name", Width: 28},
+		{Title: "Type", Width: 6},
+		{Title: "Exchange", Width: 10},
+		{Title: "Sector", Width: 16},
+		{Title: "Ccy", Width: 4},
 		{Title: "Status", Width: 12},
-		{Title: "Last Fetch", Width: 15},
+		{Title: "Last Fetch", Width: 12},
 	}
 
 	tableModel := components.NewTable(columns, []table.Row{}, width-4, height-10)
@@ -263,6 +269,9 @@ func (m *UniverseModel) updateTableRows() {
 			sym.Symbol,
 			sym.Name,
 			sym.AssetType,
+			orDash(sym.Exchange),
+			orDash(sym.Sector),
+			orDash(sym.Currency),
 			status,
 			lastFetch,
 		})
@@ -271,6 +280,14 @@ func (m *UniverseModel) updateTableRows() {
 	m.table.SetRows(rows)
 }
 
+// orDash returns s, or "-" when metadata has not been filled in.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
 // filterSymbols filters the symbol list based on search query.
 func (m *UniverseModel) filterSymbols(query string) {
 	if query == "" {
@@ -284,7 +301,8 @@ func (m *UniverseModel) filterSymbols(query string) {
 
 	for _, sym := range m.symbols {
 		if strings.Contains(strings.ToLower(sym.Symbol), query) ||
-			strings.Contains(strings.ToLower(sym.Name), query) {
+			strings.Contains(strings.ToLower(sym.Name), query) ||
+			strings.Contains(strings.ToLower(sym.Sector), query) {
 			filtered = append(filtered, sym)
 		}
 	}
@@ -329,26 +347,10 @@ func (m UniverseModel) toggleActive() tea.Cmd {
 // loadSymbols loads all symbols from the database with last fetch dates.
 func (m UniverseModel) loadSymbols() tea.Msg {
 	// Get all symbols (not just active)
-	var symbols []db.Symbol
-	query := `SELECT symbol, name, asset_type, active, created_at, updated_at FROM symbols ORDER BY symbol`
-	rows, err := m.database.Query(query)
+	symbols, err := db.NewSymbolRepository(m.database).ListAll()
 	if err != nil {
 		return universeErrorMsg{err: fmt.Errorf("failed to query symbols: %w", err)}
 	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var sym db.Symbol
-		var active int
-		var createdAt, updatedAt string
-		err := rows.Scan(&sym.Symbol, &sym.Name, &sym.AssetType, &active, &createdAt, &updatedAt)
-		if err != nil {
-			return universeErrorMsg{err: fmt.Errorf("failed to scan symbol: %w", err)}
-		}
-		sym.Active = active == 1
-		// Note: created_at and updated_at are scanned as strings but not used in display
-		symbols = append(symbols, sym)
-	}
 
 	// Get last fetch date for each symbol
 	result := make([]SymbolWithFetch, 0, len(symbols))
@@ -364,6 +366,9 @@ func (m UniverseModel) loadSymbols() tea.Msg {
 			Symbol:        sym.Symbol,
 			Name:          sym.Name,
 			AssetType:     sym.AssetType,
+			Exchange:      sym.Exchange,
+			Sector:        sym.Sector,
+			Currency:      sym.Currency,
 			Active:        sym.Active,
 			LastFetchDate: lastFetch,
 		})
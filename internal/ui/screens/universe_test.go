@@ -400,3 +400,25 @@ func TestUniverseUpdateTableRows(t *testing.T) {
 		model.updateTableRows()
 	})
 }
+
+func TestUniverseLoadSymbols_Metadata(t *testing.T) {
+	database := setupTestDB(t)
+	symbolRepo := db.NewSymbolRepository(database)
+	require.NoError(t, symbolRepo.Create(&db.Symbol{Symbol: "AAPL", Name: "AAPL", AssetType: "STOCK", Active: true}))
+	require.NoError(t, symbolRepo.UpdateMetadata(&db.Symbol{
+		Symbol: "AAPL", Name: "Apple Inc", AssetType: "STOCK",
+		Exchange: "NASDAQ", Sector: "TECHNOLOGY", Currency: "USD",
+	}))
+
+	model := NewUniverse(database, 140, 30)
+	msg, ok := model.loadSymbols().(universeDataMsg)
+	require.True(t, ok)
+	require.Len(t, msg.symbols, 1)
+	assert.Equal(t, "NASDAQ", msg.symbols[0].Exchange)
+
+	updated, _ := model.Update(msg)
+	view := updated.View()
+	assert.Contains(t, view, "Apple Inc")
+	assert.Contains(t, view, "TECHNOLOGY")
+	assert.Contains(t, view, "USD")
+}
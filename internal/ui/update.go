@@ -1,10 +1,21 @@
 package ui
 
 import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cajundata/momorot/internal/backtest"
+	"github.com/cajundata/momorot/internal/export"
+	"github.com/cajundata/momorot/internal/fetch"
+	"github.com/cajundata/momorot/internal/pipeline"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// snapshotTimeout bounds a live quote snapshot started from the Leaders screen.
+const snapshotTimeout = 2 * time.Minute
+
 // Update implements tea.Model.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
@@ -23,19 +34,65 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.universe, _ = m.universe.Update(msg)
 		m.symbol, _ = m.symbol.Update(msg)
 		m.logs, _ = m.logs.Update(msg)
+		m.sweep, _ = m.sweep.Update(msg)
 
 		return m, nil
 
+	case refreshProgressMsg:
+		m.SetLoading(true, formatRefreshProgress(msg.event))
+		return m, waitForRefresh(msg.updates)
+
 	case refreshCompleteMsg:
 		m.SetLoading(false, "")
-		m.SetStatus("Refresh complete")
-		return m, nil
+		m.refreshCancel = nil
+		status := fmt.Sprintf("Refresh complete: %d updated, %d failed, %d up to date",
+			msg.result.Succeeded, msg.result.Failed, len(msg.result.Skipped))
+		if n := len(msg.result.Deferred); n > 0 {
+			status += fmt.Sprintf(", %d deferred (quota)", n)
+		}
+		m.SetStatus(status)
+		// Reload every screen with the new data
+		return m, tea.Batch(
+			m.dashboard.Init(),
+			m.leaders.Init(),
+			m.universe.Init(),
+			m.symbol.Init(),
+			m.logs.Init(),
+		)
 
 	case refreshErrorMsg:
 		m.SetLoading(false, "")
+		m.refreshCancel = nil
 		m.SetError(string(msg))
 		return m, nil
 
+	case snapshotCompleteMsg:
+		m.SetLoading(false, "")
+		m.leaders.SetProvisional(msg.snapshot.Leaders, msg.snapshot.Quoted, msg.snapshot.AsOf)
+		status := fmt.Sprintf("Provisional ranking from %d live quotes (not saved)", len(msg.snapshot.Quoted))
+		if n := len(msg.snapshot.Failed); n > 0 {
+			status += fmt.Sprintf(", %d not quoted", n)
+		}
+		m.SetStatus(status)
+		return m, nil
+
+	case snapshotErrorMsg:
+		m.SetLoading(false, "")
+		m.SetError(string(msg))
+		return m, nil
+
+	case sweepCompleteMsg:
+		m.SetLoading(false, "")
+		m.sweep.SetResults(msg.results, msg.elapsed)
+		m.SetStatus(fmt.Sprintf("Sweep complete: %d combinations, exported to %s", len(msg.results), msg.filename))
+		return m, nil
+
+	case sweepErrorMsg:
+		m.SetLoading(false, "")
+		m.sweep.SetError(msg.err)
+		m.SetError(fmt.Sprintf("sweep failed: %v", msg.err))
+		return m, nil
+
 	case NavigateToSymbolMsg:
 		m.NavigateToSymbol(msg.Symbol)
 		return m, m.symbol.Init()
@@ -53,6 +110,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.symbol, cmd = m.symbol.Update(msg)
 	case ScreenLogs:
 		m.logs, cmd = m.logs.Update(msg)
+	case ScreenSweep:
+		m.sweep, cmd = m.sweep.Update(msg)
 	}
 
 	return m, cmd
@@ -63,10 +122,22 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Global key bindings
 	switch {
 	case key.Matches(msg, m.keys.Quit):
+		if m.refreshCancel != nil {
+			m.refreshCancel()
+		}
 		return m, tea.Quit
 
 	case key.Matches(msg, m.keys.Refresh):
-		return m, m.triggerRefresh()
+		if m.refreshCancel != nil {
+			// A refresh is already running
+			return m, nil
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), pipeline.RefreshTimeout(m.config, m.db))
+		m.refreshCancel = cancel
+		m.ClearError()
+		m.SetStatus("")
+		m.SetLoading(true, "Starting refresh")
+		return m, m.triggerRefresh(ctx, cancel)
 
 	case key.Matches(msg, m.keys.NextTab):
 		return m.navigateNext(), nil
@@ -91,6 +162,8 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.updateSymbol(msg)
 	case ScreenLogs:
 		return m.updateLogs(msg)
+	case ScreenSweep:
+		return m.updateSweep(msg)
 	}
 
 	return m, nil
@@ -108,6 +181,8 @@ func (m Model) navigateNext() Model {
 	case ScreenSymbol:
 		m.currentScreen = ScreenLogs
 	case ScreenLogs:
+		m.currentScreen = ScreenSweep
+	case ScreenSweep:
 		m.currentScreen = ScreenDashboard
 	}
 	return m
@@ -117,7 +192,7 @@ func (m Model) navigateNext() Model {
 func (m Model) navigatePrev() Model {
 	switch m.currentScreen {
 	case ScreenDashboard:
-		m.currentScreen = ScreenLogs
+		m.currentScreen = ScreenSweep
 	case ScreenLeaders:
 		m.currentScreen = ScreenDashboard
 	case ScreenUniverse:
@@ -126,6 +201,8 @@ func (m Model) navigatePrev() Model {
 		m.currentScreen = ScreenUniverse
 	case ScreenLogs:
 		m.currentScreen = ScreenSymbol
+	case ScreenSweep:
+		m.currentScreen = ScreenLogs
 	}
 	return m
 }
@@ -139,6 +216,10 @@ func (m Model) updateDashboard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) updateLeaders(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, m.keys.Live) {
+		return m.toggleLiveSnapshot()
+	}
+
 	var cmd tea.Cmd
 	m.leaders, cmd = m.leaders.Update(msg)
 	return m, cmd
@@ -162,17 +243,174 @@ func (m Model) updateLogs(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+func (m Model) updateSweep(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, m.keys.Sweep) {
+		return m.startSweep()
+	}
+
+	var cmd tea.Cmd
+	m.sweep, cmd = m.sweep.Update(msg)
+	return m, cmd
+}
+
+// startSweep backtests every combination of the configured grid over all
+// stored prices in the background and exports the comparison table.
+func (m Model) startSweep() (tea.Model, tea.Cmd) {
+	if m.loading || m.sweep.Running() {
+		return m, nil
+	}
+
+	grid := pipeline.NewSweepGrid(m.config)
+	m.ClearError()
+	m.SetStatus("")
+	m.SetLoading(true, fmt.Sprintf("Backtesting %d combinations", len(grid.Combinations())))
+	m.sweep.SetRunning()
+
+	cfg, database := m.config, m.db
+	return m, func() tea.Msg {
+		start := time.Now()
+		btCfg := pipeline.NewBacktestConfig(cfg, time.Time{}, time.Now())
+		results, err := pipeline.RunSweep(cfg, database, btCfg, grid)
+		if err != nil {
+			return sweepErrorMsg{err: err}
+		}
+		elapsed := time.Since(start)
+
+		filename, err := export.New(database, cfg.Data.ExportDir).ExportSweep(results)
+		if err != nil {
+			return sweepErrorMsg{err: fmt.Errorf("failed to export results: %w", err)}
+		}
+		return sweepCompleteMsg{results: results, elapsed: elapsed, filename: filename}
+	}
+}
+
+// toggleLiveSnapshot switches the Leaders screen between the stored ranking and
+// a provisional one computed from live quotes.
+func (m Model) toggleLiveSnapshot() (tea.Model, tea.Cmd) {
+	if m.leaders.Provisional() {
+		m.SetStatus("")
+		return m, m.leaders.Init()
+	}
+	if m.loading {
+		return m, nil
+	}
+
+	snapshotter := pipeline.NewSnapshotter(m.config, m.db, pipeline.NewProviders(m.config, m.db))
+	if !snapshotter.Enabled() {
+		m.SetStatus("Live quotes are off (set live_quotes.enabled and use the alpha_vantage provider)")
+		return m, nil
+	}
+
+	m.ClearError()
+	m.SetLoading(true, "Fetching live quotes")
+	topN := m.leaders.TopN()
+	return m, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), snapshotTimeout)
+		defer cancel()
+
+		snapshot, err := snapshotter.Run(ctx, topN)
+		if err != nil {
+			return snapshotErrorMsg(fmt.Sprintf("live snapshot failed: %v", err))
+		}
+		return snapshotCompleteMsg{snapshot: snapshot}
+	}
+}
+
 // Messages for async operations.
 
-type refreshCompleteMsg struct{}
+// refreshProgressMsg carries a scheduler event from a running refresh along with
+// the channel the next update arrives on.
+type refreshProgressMsg struct {
+	event   fetch.FetchEvent
+	updates <-chan tea.Msg
+}
+
+type refreshCompleteMsg struct {
+	result *pipeline.RefreshResult
+}
 
 type refreshErrorMsg string
 
-// triggerRefresh initiates a background refresh operation.
-func (m Model) triggerRefresh() tea.Cmd {
+type snapshotCompleteMsg struct {
+	snapshot *pipeline.Snapshot
+}
+
+type snapshotErrorMsg string
+
+type sweepCompleteMsg struct {
+	results  []backtest.SweepResult
+	elapsed  time.Duration
+	filename string // Exported comparison table
+}
+
+type sweepErrorMsg struct {
+	err error
+}
+
+// triggerRefresh runs a refresh in the background. Scheduler events are
+// forwarded as refreshProgressMsg until a complete or error message ends it.
+// Once ctx is done nothing may be reading, so sends give up rather than block
+// the refresh and its scheduler workers.
+func (m Model) triggerRefresh(ctx context.Context, cancel context.CancelFunc) tea.Cmd {
+	updates := make(chan tea.Msg, 16)
+
+	send := func(msg tea.Msg) {
+		// Deliver if there is room, so a timed-out refresh still reports why
+		select {
+		case updates <- msg:
+			return
+		default:
+		}
+		select {
+		case updates <- msg:
+		case <-ctx.Done():
+		}
+	}
+
+	go func() {
+		defer close(updates)
+		defer cancel()
+
+		refresher := pipeline.NewRefresher(m.config, m.db, pipeline.NewProviders(m.config, m.db))
+		refresher.SetProgress(func(event fetch.FetchEvent) {
+			send(refreshProgressMsg{event: event, updates: updates})
+		})
+
+		result, err := refresher.Run(ctx, "TUI refresh")
+		if err != nil {
+			send(refreshErrorMsg(fmt.Sprintf("refresh failed: %v", err)))
+			return
+		}
+		send(refreshCompleteMsg{result: result})
+	}()
+
+	return waitForRefresh(updates)
+}
+
+// waitForRefresh waits for the next message from a running refresh. A refresh
+// that ends without a complete or error message was canceled.
+func waitForRefresh(updates <-chan tea.Msg) tea.Cmd {
 	return func() tea.Msg {
-		// TODO: Implement actual refresh logic
-		// This is a placeholder
-		return refreshCompleteMsg{}
+		msg, ok := <-updates
+		if !ok {
+			return refreshErrorMsg("refresh canceled")
+		}
+		return msg
+	}
+}
+
+// formatRefreshProgress describes a scheduler event for the status bar.
+func formatRefreshProgress(event fetch.FetchEvent) string {
+	progress := fmt.Sprintf("[%d/%d]", event.Completed, event.Total)
+	switch event.Type {
+	case fetch.FetchRetrying:
+		return fmt.Sprintf("%s Retrying %s (retry %d)", progress, event.Symbol, event.Attempt)
+	case fetch.FetchFinished:
+		if event.Result != nil && !event.Result.Success {
+			return fmt.Sprintf("%s %s failed", progress, event.Symbol)
+		}
+		return fmt.Sprintf("%s Fetched %s", progress, event.Symbol)
+	default:
+		return fmt.Sprintf("%s Fetching %s", progress, event.Symbol)
 	}
 }
@@ -23,6 +23,8 @@ func (m Model) View() string {
 		content = m.viewSymbol()
 	case ScreenLogs:
 		content = m.viewLogs()
+	case ScreenSweep:
+		content = m.viewSweep()
 	default:
 		content = "Unknown screen"
 	}
@@ -50,6 +52,7 @@ func (m Model) renderHeader() string {
 		m.renderTab("Universe", ScreenUniverse),
 		m.renderTab("Symbol", ScreenSymbol),
 		m.renderTab("Logs", ScreenLogs),
+		m.renderTab("Sweep", ScreenSweep),
 	}
 
 	tabBar := lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
@@ -127,6 +130,8 @@ func (m Model) getScreenName() string {
 		return "Symbol"
 	case ScreenLogs:
 		return "Logs"
+	case ScreenSweep:
+		return "Sweep"
 	default:
 		return "Unknown"
 	}
@@ -153,3 +158,7 @@ func (m Model) viewSymbol() string {
 func (m Model) viewLogs() string {
 	return m.logs.View()
 }
+
+func (m Model) viewSweep() string {
+	return m.sweep.View()
+}